@@ -51,6 +51,43 @@ const (
 	// from the status annotation.
 	StatusAnnotation = "baremetalhost.metal3.io/status"
 
+	// ProvisionerLabel is the label selecting the provisioner managing the host
+	// when the operator runs several of them. Hosts without it are managed by
+	// the default provisioner.
+	ProvisionerLabel = "provisioner.metal3.io/name"
+
+	// ShardLabel is the label pinning the host to one of the instances of
+	// its provisioner, when the provisioner is configured with several
+	// shards. Hosts without it are assigned a shard when first reconciled.
+	ShardLabel = "shard.metal3.io/name"
+
+	// ConductorGroupLabel is the label setting the Ironic conductor group of
+	// the host when spec.conductorGroup is empty.
+	ConductorGroupLabel = "ironic.metal3.io/conductor-group"
+
+	// NodeShardLabel is the label setting the Ironic node shard of the host
+	// when spec.nodeShard is empty.
+	NodeShardLabel = "ironic.metal3.io/shard"
+
+	// RotateCredentialsAnnotation is the annotation requesting a new BMC
+	// password to be set on the BMC and in the credentials secret of the
+	// host. It is removed once the rotation is done or has failed.
+	RotateCredentialsAnnotation = "credentials.metal3.io/rotate"
+
+	// CredentialsRotationIntervalAnnotation is the annotation setting how
+	// often the BMC password of the host is rotated, as a duration such as
+	// "720h".
+	CredentialsRotationIntervalAnnotation = "credentials.metal3.io/rotation-interval"
+
+	// CredentialsRotationFailedAnnotation records on the host when the last
+	// scheduled rotation of its BMC password failed, so that it is not
+	// retried on every reconcile.
+	CredentialsRotationFailedAnnotation = "credentials.metal3.io/rotation-failed-at"
+
+	// CredentialsRotatedAtAnnotation records on the credentials secret when
+	// its password was last rotated.
+	CredentialsRotatedAtAnnotation = "credentials.metal3.io/rotated-at"
+
 	// RebootAnnotationPrefix is the annotation which tells the host which mode to use
 	// when rebooting - hard/soft.
 	RebootAnnotationPrefix = "reboot.metal3.io"
@@ -158,6 +195,10 @@ const (
 	// RegistrationFailedReason is the reason used when the BareMetalHost is not
 	// registered.
 	RegistrationFailedReason = "RegistrationFailed"
+	// BMCProbeFailedReason is the reason used when the BareMetalHost is not
+	// registered because the operator could not validate the BMC address or
+	// credentials before registration.
+	BMCProbeFailedReason = "BMCProbeFailed"
 	// PowerFailureReason is the reason used when the BareMetalHost is experiencing a
 	// power failure.
 	PowerFailureReason = "PowerFailure"
@@ -224,6 +265,44 @@ const (
 	WarningHealthReason = "Warning"
 	// CriticalHealthReason is the reason used when BMC reports critical errors.
 	CriticalHealthReason = "CriticalError"
+	// HealthNotSupportedReason is the reason used when the provisioner of
+	// the BareMetalHost cannot report its health.
+	HealthNotSupportedReason = "NotSupported"
+
+	// NetworkConfiguredCondition documents whether the switch port
+	// configuration requested in Spec.NetworkInterfaces has been applied to
+	// the ports of the BareMetalHost.
+	NetworkConfiguredCondition = "NetworkConfigured"
+	// NetworkConfiguredReason is the reason used when the requested switch
+	// port configuration has been applied.
+	NetworkConfiguredReason = "NetworkConfigured"
+	// NetworkConfiguringReason is the reason used while the requested switch
+	// port configuration is being applied during provisioning.
+	NetworkConfiguringReason = "NetworkConfiguring"
+	// NetworkNotConfiguredReason is the reason used when the BareMetalHost is
+	// not provisioned and its ports use the default configuration.
+	NetworkNotConfiguredReason = "NetworkNotConfigured"
+	// NetworkConfigErrorReason is the reason used when the requested network
+	// configuration cannot be resolved, for example because an interface or
+	// a HostNetworkAttachment cannot be found.
+	NetworkConfigErrorReason = "NetworkConfigError"
+
+	// ProvisioningDelayedCondition is set while the BareMetalHost waits for
+	// a free (de)provisioning slot, its reason and message name the limit
+	// that was reached.
+	ProvisioningDelayedCondition = "ProvisioningDelayed"
+	// ProvisionerLimitReason is the reason used when the provisioner has no
+	// free slot, e.g. because PROVISIONING_LIMIT was reached.
+	ProvisionerLimitReason = "ProvisionerLimit"
+	// NamespaceLimitReason is the reason used when too many hosts of the
+	// namespace are (de)provisioning.
+	NamespaceLimitReason = "NamespaceLimit"
+	// LabelLimitReason is the reason used when too many hosts with the same
+	// value of a limited label are (de)provisioning.
+	LabelLimitReason = "LabelLimit"
+	// ConductorGroupLimitReason is the reason used when too many hosts of the
+	// conductor group are (de)provisioning.
+	ConductorGroupLimitReason = "ConductorGroupLimit"
 )
 
 // OperationalStatus represents the state of the host.
@@ -369,8 +448,42 @@ type BMCDetails struct {
 	// insecure because it allows a man-in-the-middle to intercept the
 	// connection.
 	DisableCertificateVerification bool `json:"disableCertificateVerification,omitempty"`
+
+	// CABundle references the CA certificates the server certificate of
+	// the BMC is verified with, instead of the system ones, when using
+	// HTTPS to connect to the BMC.
+	// +optional
+	CABundle *BMCCABundle `json:"caBundle,omitempty"`
+
+	// CertificateFingerprint pins the server certificate of the BMC: the
+	// SHA-256 fingerprint of the certificate, as hexadecimal digits
+	// optionally separated by colons. Unless a CA bundle is set as well, a
+	// self-signed certificate with this fingerprint is accepted.
+	// +optional
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty"`
+}
+
+// BMCCABundle references a ConfigMap or a Secret, in the namespace of the
+// host, holding PEM encoded CA certificates.
+type BMCCABundle struct {
+	// ConfigMapName is the name of the ConfigMap holding the certificates.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// SecretName is the name of the Secret holding the certificates.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Key is the key of the certificates in the ConfigMap or Secret.
+	// +kubebuilder:default:=ca.crt
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
+// DefaultBMCCABundleKey is the key of the certificates in a CA bundle
+// ConfigMap or Secret when no key is given.
+const DefaultBMCCABundleKey = "ca.crt"
+
 // HardwareRAIDVolume defines the desired configuration of volume in hardware RAID.
 type HardwareRAIDVolume struct {
 	// Size of the logical disk to be created in GiB. If unspecified or
@@ -656,6 +769,21 @@ type BareMetalHostSpec struct {
 	// or MAC address of the NIC.
 	// +optional
 	NetworkInterfaces []NetworkInterface `json:"networkInterfaces,omitempty"`
+
+	// ConductorGroup restricts the management of the host to the Ironic
+	// conductors of this group, e.g. the conductors of one datacenter row.
+	// Overrides the ironic.metal3.io/conductor-group label.
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.:-]*$`
+	ConductorGroup string `json:"conductorGroup,omitempty"`
+
+	// NodeShard is the Ironic node shard of the host, used by Ironic API
+	// consumers to split the nodes between them. Overrides the
+	// ironic.metal3.io/shard label.
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	NodeShard string `json:"nodeShard,omitempty"`
 }
 
 // AutomatedCleaningMode is the interface to enable/disable automated cleaning
@@ -899,6 +1027,11 @@ type ProvisionStatus struct {
 	//nolint:tagliatelle
 	ID string `json:"ID"`
 
+	// Shard is the instance of the provisioner managing the host, when the
+	// provisioner is configured with several shards.
+	// +optional
+	Shard string `json:"shard,omitempty"`
+
 	// Image holds the details of the last image successfully
 	// provisioned to the host.
 	Image Image `json:"image,omitempty"`
@@ -1237,6 +1370,24 @@ func (iface *NetworkInterface) GetKey() string {
 	return iface.Name
 }
 
+// HostNetworkAttachmentKeys returns the "namespace/name" keys of the
+// HostNetworkAttachments referenced by the host's network interfaces, as
+// used by HostNetworkAttachmentIndexField.
+func (host *BareMetalHost) HostNetworkAttachmentKeys() []string {
+	var keys []string
+	for _, iface := range host.Spec.NetworkInterfaces {
+		if iface.HostNetworkAttachment.Name == "" {
+			continue
+		}
+		ns := iface.HostNetworkAttachment.Namespace
+		if ns == "" {
+			ns = host.Namespace
+		}
+		keys = append(keys, ns+"/"+iface.HostNetworkAttachment.Name)
+	}
+	return keys
+}
+
 // +kubebuilder:object:root=true
 
 // BareMetalHostList contains a list of BareMetalHost.
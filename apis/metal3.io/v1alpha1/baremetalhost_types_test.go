@@ -883,3 +883,21 @@ func TestIsLiveISO(t *testing.T) {
 		})
 	}
 }
+
+func TestHostNetworkAttachmentKeys(t *testing.T) {
+	host := BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myhost",
+			Namespace: "myns",
+		},
+		Spec: BareMetalHostSpec{
+			NetworkInterfaces: []NetworkInterface{
+				{Name: "eth0", HostNetworkAttachment: HostNetworkAttachmentRef{Name: "vlan100"}},
+				{Name: "eth1"},
+				{Name: "eth2", HostNetworkAttachment: HostNetworkAttachmentRef{Name: "vlan200", Namespace: "shared"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"myns/vlan100", "shared/vlan200"}, host.HostNetworkAttachmentKeys())
+}
@@ -33,8 +33,10 @@ type BareMetalSwitchSpec struct {
 	// +kubebuilder:validation:Required
 	MACAddress string `json:"macAddress"`
 
-	// Driver specifies the driver to use. Currently only "generic-switch" is supported.
-	// +kubebuilder:validation:Enum=generic-switch
+	// Driver specifies the driver used to manage the switch, which
+	// determines the format of its configuration. Supported drivers are
+	// "generic-switch" (networking-generic-switch) and "netconf-openconfig"
+	// (networking-baremetal).
 	// +kubebuilder:default=generic-switch
 	// +optional
 	Driver string `json:"driver,omitempty"`
@@ -44,6 +46,8 @@ type BareMetalSwitchSpec struct {
 	// Examples: netmiko_cisco_ios, netmiko_dell_force10, netmiko_dell_os10,
 	//   netmiko_juniper_junos, netmiko_arista_eos
 	// See https://docs.openstack.org/networking-generic-switch/latest/configuration.html
+	// For the netconf-openconfig driver, this is the ncclient device handler
+	// name, for example: default, nexus, junos.
 	// +kubebuilder:validation:Required
 	DeviceType string `json:"deviceType"`
 
@@ -73,6 +77,15 @@ const (
 	// SwitchConditionValid indicates whether the switch has been
 	// successfully reconciled into the switch config secret.
 	SwitchConditionValid SwitchConditionType = "Valid"
+
+	// SwitchConditionReachable indicates whether the management interface
+	// of the switch accepted a connection and completed the SSH handshake
+	// during the last probe.
+	SwitchConditionReachable SwitchConditionType = "Reachable"
+
+	// SwitchConditionAuthenticated indicates whether the switch accepted
+	// the referenced credentials during the last probe.
+	SwitchConditionAuthenticated SwitchConditionType = "Authenticated"
 )
 
 // BareMetalSwitchStatus defines the observed state of BareMetalSwitch.
@@ -84,6 +97,17 @@ type BareMetalSwitchStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// LastProbeTime is the time the switch was last probed for
+	// reachability and authentication. Probing is disabled unless the
+	// operator is configured with a probe interval.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// LastSuccessfulProbeTime is the last time a probe both reached the
+	// switch and authenticated with its credentials.
+	// +optional
+	LastSuccessfulProbeTime *metav1.Time `json:"lastSuccessfulProbeTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -93,6 +117,8 @@ type BareMetalSwitchStatus struct {
 // +kubebuilder:printcolumn:name="Device Type",type="string",JSONPath=".spec.deviceType",description="Switch device type"
 // +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".spec.address",description="Switch address"
 // +kubebuilder:printcolumn:name="Valid",type="string",JSONPath=".status.conditions[?(@.type==\"Valid\")].status",description="Valid"
+// +kubebuilder:printcolumn:name="Reachable",type="string",JSONPath=".status.conditions[?(@.type==\"Reachable\")].status",description="Reachable",priority=1
+// +kubebuilder:printcolumn:name="Authenticated",type="string",JSONPath=".status.conditions[?(@.type==\"Authenticated\")].status",description="Authenticated",priority=1
 
 // BareMetalSwitch represents a Top-of-Rack switch managed by Ironic Networking.
 type BareMetalSwitch struct {
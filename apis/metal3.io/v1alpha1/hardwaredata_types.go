@@ -230,16 +230,125 @@ type HardwareDataSpec struct {
 	HardwareDetails *HardwareDetails `json:"hardware,omitempty"`
 }
 
+// HardwareDataConditionType is the type of a HardwareData condition.
+type HardwareDataConditionType string
+
+const (
+	// SwitchPortsResolvedCondition indicates whether every NIC with a known
+	// switch port is connected to a BareMetalSwitch and whether the switch
+	// ports given on the BareMetalHost agree with LLDP.
+	SwitchPortsResolvedCondition HardwareDataConditionType = "SwitchPortsResolved"
+
+	// SwitchPortsResolvedReason is used when all switch ports were
+	// resolved to a BareMetalSwitch.
+	SwitchPortsResolvedReason string = "Resolved"
+
+	// SwitchPortsNotFoundReason is used when no NIC has LLDP data or a
+	// switch port given on the BareMetalHost.
+	SwitchPortsNotFoundReason string = "NoSwitchPorts"
+
+	// UnknownSwitchReason is used when a NIC is connected to a switch that
+	// has no matching BareMetalSwitch.
+	UnknownSwitchReason string = "UnknownSwitch"
+
+	// SwitchPortMismatchReason is used when the switch port given on the
+	// BareMetalHost disagrees with LLDP.
+	SwitchPortMismatchReason string = "SwitchPortMismatch"
+)
+
+// SwitchPortSource identifies where the switch port of a NIC comes from.
+// +kubebuilder:validation:Enum=LLDP;BareMetalHost
+type SwitchPortSource string
+
+const (
+	// SwitchPortSourceLLDP means the switch port was reported by LLDP
+	// during inspection.
+	SwitchPortSourceLLDP SwitchPortSource = "LLDP"
+
+	// SwitchPortSourceBareMetalHost means the switch port was given in the
+	// SwitchPort field of a BareMetalHost network interface, which takes
+	// precedence over LLDP.
+	SwitchPortSourceBareMetalHost SwitchPortSource = "BareMetalHost"
+)
+
+// SwitchReference identifies a BareMetalSwitch.
+type SwitchReference struct {
+	// Name of the BareMetalSwitch.
+	Name string `json:"name"`
+
+	// Namespace of the BareMetalSwitch.
+	Namespace string `json:"namespace"`
+}
+
+// NICSwitchPort describes the switch port a NIC is connected to.
+type NICSwitchPort struct {
+	// Name of the NIC.
+	Name string `json:"name"`
+
+	// MACAddress of the NIC.
+	MACAddress string `json:"macAddress"`
+
+	// SwitchID is the management MAC address of the switch.
+	// +optional
+	SwitchID string `json:"switchID,omitempty"`
+
+	// PortID is the name of the port on the switch.
+	// +optional
+	PortID string `json:"portID,omitempty"`
+
+	// Source of the switch port information.
+	Source SwitchPortSource `json:"source"`
+
+	// Switch references the BareMetalSwitch whose MAC address matches
+	// SwitchID. It is not set if no such switch exists.
+	// +optional
+	Switch *SwitchReference `json:"switch,omitempty"`
+
+	// Warning describes a problem with the switch port, such as an unknown
+	// switch or a disagreement between the BareMetalHost and LLDP.
+	// +optional
+	Warning string `json:"warning,omitempty"`
+}
+
+// HardwareDataStatus defines the observed state of HardwareData.
+type HardwareDataStatus struct {
+	// SwitchPorts lists the switch ports the NICs of the host are
+	// connected to, as reported by LLDP or given on the BareMetalHost.
+	// +optional
+	SwitchPorts []NICSwitchPort `json:"switchPorts,omitempty"`
+
+	// Conditions describe the state of the switch port discovery.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=hardwaredata,scope=Namespaced,shortName=hd
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of HardwareData"
+// +kubebuilder:printcolumn:name="Switch Ports",type="string",JSONPath=".status.conditions[?(@.type==\"SwitchPortsResolved\")].reason",description="Switch port discovery result",priority=1
 
 // HardwareData is the Schema for the hardwaredata API.
 type HardwareData struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec HardwareDataSpec `json:"spec,omitempty"`
+	Spec   HardwareDataSpec   `json:"spec,omitempty"`
+	Status HardwareDataStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (hd *HardwareData) GetConditions() []metav1.Condition {
+	return hd.Status.Conditions
+}
+
+// SetConditions sets conditions for this object.
+func (hd *HardwareData) SetConditions(conditions []metav1.Condition) {
+	hd.Status.Conditions = conditions
 }
 
 //+kubebuilder:object:root=true
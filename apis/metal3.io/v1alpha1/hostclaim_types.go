@@ -31,6 +31,12 @@ const (
 
 	// BareMetalHostAssociatedReason is the reason used when the HostClaim is successfully associated with a BareMetalHost.
 	BareMetalHostAssociatedReason = "BareMetalHostAssociated"
+	// BareMetalHostReservedReason is the reason used when the HostClaim holds a BareMetalHost without
+	// provisioning it, as neither Image nor CustomDeploy is set.
+	BareMetalHostReservedReason = "BareMetalHostReserved"
+	// ReservationExpiredReason is the reason used when the reservation of a BareMetalHost expired and the
+	// host was released.
+	ReservationExpiredReason = "ReservationExpired"
 	// MissingBareMetalHostReason is a reason used when the associated BareMetalHost is no more found.
 	MissingBareMetalHostReason = "MissingBareMetalHost"
 	// NoBareMetalHostReason is a reason used when no BareMetalHost matching the constraints is found.
@@ -65,6 +71,31 @@ const (
 	// BareMetalHostNotSynchronizedReason is the reason used when the synchronization of BareMetalHost state
 	// is not successful.
 	BareMetalHostNotSynchronizedReason = "BareMetalHostNotSynchronized"
+
+	// ReprovisionAnnotation on a HostClaim requests to deprovision the bound BareMetalHost and to provision it
+	// again with the same settings. The reboot.metal3.io and inspect.metal3.io annotations on a HostClaim are
+	// also passed to the bound BareMetalHost.
+	ReprovisionAnnotation = "reprovision.metal3.io"
+
+	// RebootedCondition documents the last reboot of the BareMetalHost requested through the HostClaim.
+	RebootedCondition = "Rebooted"
+	// InspectedCondition documents the last inspection of the BareMetalHost requested through the HostClaim.
+	InspectedCondition = "Inspected"
+	// ReprovisionedCondition documents the last reprovisioning of the BareMetalHost requested through the
+	// HostClaim.
+	ReprovisionedCondition = "Reprovisioned"
+
+	// OperationPendingReason is the reason used while the BareMetalHost has not started the requested operation.
+	OperationPendingReason = "OperationPending"
+	// OperationInProgressReason is the reason used while the BareMetalHost performs the requested operation.
+	OperationInProgressReason = "OperationInProgress"
+	// OperationCompletedReason is the reason used when the requested operation succeeded.
+	OperationCompletedReason = "OperationCompleted"
+	// OperationFailedReason is the reason used when the requested operation failed.
+	OperationFailedReason = "OperationFailed"
+	// OperationRejectedReason is the reason used when the BareMetalHost cannot perform the requested operation
+	// in its current state.
+	OperationRejectedReason = "OperationRejected"
 )
 
 // HostClaimSpec defines the desired state of HostClaim.
@@ -117,8 +148,124 @@ type HostClaimSpec struct {
 	// infrastructure.cluster.x-k8s.io/failure-domain set to the value of
 	// the field.
 	FailureDomain string `json:"failureDomain,omitempty"`
+
+	// SelectionStrategy selects how a BareMetalHost is chosen among the
+	// ones satisfying HostSelector and FailureDomain. Balanced, the
+	// default, spreads claims of a namespace over failure domains and
+	// racks, prefers the smallest hardware that fits, hosts that did not
+	// fail provisioning and hosts that are powered off. Spread and
+	// SmallestFit favor the corresponding criterion. Random picks any
+	// suitable host.
+	// +optional
+	SelectionStrategy HostSelectionStrategy `json:"selectionStrategy,omitempty"`
+
+	// HardwareRequirements restricts the BareMetalHosts considered for
+	// claiming to those whose HardwareData satisfies them. Hosts without
+	// HardwareData are not considered when requirements are set.
+	// +optional
+	HardwareRequirements *HardwareRequirements `json:"hardwareRequirements,omitempty"`
+
+	// ReservationTTL is how long the BareMetalHost bound to the HostClaim
+	// is reserved while neither Image nor CustomDeploy is set. A reserved
+	// host is held in the available state without being provisioned. When
+	// the reservation expires the host is released and the HostClaim is
+	// not bound again until Image or CustomDeploy is set. Without a TTL,
+	// the reservation does not expire.
+	// +optional
+	ReservationTTL *metav1.Duration `json:"reservationTTL,omitempty"`
+}
+
+// HardwareRequirements are minimal hardware characteristics of a
+// BareMetalHost, as reported by inspection.
+type HardwareRequirements struct {
+	// CPU requirements.
+	// +optional
+	CPU *CPURequirements `json:"cpu,omitempty"`
+
+	// MinRAMMebibytes is the minimal amount of memory.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinRAMMebibytes int `json:"minRAMMebibytes,omitempty"`
+
+	// Disk requirements.
+	// +optional
+	Disks *DiskRequirements `json:"disks,omitempty"`
+
+	// NIC requirements.
+	// +optional
+	NICs *NICRequirements `json:"nics,omitempty"`
+}
+
+// CPURequirements are requirements on the processors of a host.
+type CPURequirements struct {
+	// MinCount is the minimal number of CPUs.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCount int `json:"minCount,omitempty"`
+
+	// Arch is the required CPU architecture, e.g. "x86_64".
+	// +optional
+	Arch string `json:"arch,omitempty"`
+
+	// Flags are CPU flags that must all be present, e.g. "vmx".
+	// +optional
+	Flags []string `json:"flags,omitempty"`
 }
 
+// DiskRequirements are requirements on the storage devices of a host.
+// Only the disks matching MinSizeBytes and Type are counted.
+type DiskRequirements struct {
+	// MinCount is the minimal number of matching disks. It defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCount int `json:"minCount,omitempty"`
+
+	// MinSizeBytes is the minimal size of a matching disk.
+	// +optional
+	MinSizeBytes Capacity `json:"minSizeBytes,omitempty"`
+
+	// Type is the device type of a matching disk, one of: HDD, SSD, NVME.
+	// +kubebuilder:validation:Enum=HDD;SSD;NVME
+	// +optional
+	Type DiskType `json:"type,omitempty"`
+}
+
+// NICRequirements are requirements on the network interfaces of a host.
+// Only the NICs matching MinSpeedGbps are counted.
+type NICRequirements struct {
+	// MinCount is the minimal number of matching NICs. It defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCount int `json:"minCount,omitempty"`
+
+	// MinSpeedGbps is the minimal speed of a matching NIC in Gigabits per
+	// second.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSpeedGbps int `json:"minSpeedGbps,omitempty"`
+}
+
+// HostSelectionStrategy is the strategy used to choose a BareMetalHost for
+// a HostClaim.
+// +kubebuilder:validation:Enum=Balanced;Spread;SmallestFit;Random
+type HostSelectionStrategy string
+
+const (
+	// HostSelectionBalanced weighs all host selection criteria.
+	HostSelectionBalanced HostSelectionStrategy = "Balanced"
+
+	// HostSelectionSpread favors spreading the claims of a namespace over
+	// failure domains and racks.
+	HostSelectionSpread HostSelectionStrategy = "Spread"
+
+	// HostSelectionSmallestFit favors the hosts with the least CPU,
+	// memory and storage.
+	HostSelectionSmallestFit HostSelectionStrategy = "SmallestFit"
+
+	// HostSelectionRandom picks a random suitable host.
+	HostSelectionRandom HostSelectionStrategy = "Random"
+)
+
 // HostSelector specifies matching criteria for labels on BareMetalHosts.
 // This is used to limit the set of BareMetalHost objects considered for
 // claiming for a Machine.
@@ -157,6 +304,11 @@ type HostClaimStatus struct {
 	// BareMetalHost is a pointer to the name of the bound BareMetalHost
 	// +optional
 	BareMetalHost *ObjectReference `json:"bareMetalHost,omitempty"`
+	// ReservationExpirationTime is when the reservation of the bound
+	// BareMetalHost expires, or expired.
+	// +optional
+	ReservationExpirationTime *metav1.Time `json:"reservationExpirationTime,omitempty"`
+
 	// HardwareData is a pointer to the name of the bound HardwareData
 	// structure.
 	// +optional
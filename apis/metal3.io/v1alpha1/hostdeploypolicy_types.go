@@ -25,6 +25,39 @@ type HostDeployPolicySpec struct {
 	// HostClaimNamespaces constrains the namespaces of the HostClaims allowed
 	// to bind the BareMetalHosts in the same namespace as the HostDeployPolicy
 	HostClaimNamespaces *HostClaimNamespaces `json:"hostClaimNamespaces,omitempty"`
+
+	// Quotas limit the number of BareMetalHosts in the same namespace as
+	// the HostDeployPolicy that the HostClaims of a namespace may bind.
+	// Each quota is counted separately for each namespace of HostClaims.
+	// When several HostDeployPolicies accept a namespace, the quotas of
+	// all of them apply.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Quotas []HostQuota `json:"quotas,omitempty"`
+}
+
+// HostQuota is the maximum number of BareMetalHosts, optionally of a given
+// class, that the HostClaims of a namespace may bind.
+type HostQuota struct {
+	// Name identifies the quota in the status.
+	Name string `json:"name"`
+
+	// Namespaces are the namespaces of HostClaims the quota applies to.
+	// When empty, the quota applies to every namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// MatchLabels restricts the quota to the BareMetalHosts having all of
+	// those labels, e.g. a class of hardware. When empty, all the hosts
+	// are counted.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MaxHosts is the maximum number of matching BareMetalHosts bound to
+	// HostClaims of a namespace.
+	// +kubebuilder:validation:Minimum=0
+	MaxHosts int `json:"maxHosts"`
 }
 
 type HostClaimNamespaces struct {
@@ -51,6 +84,43 @@ type NameValuePair struct {
 
 // HostDeployPolicyStatus defines the observed state of HostDeployPolicy.
 type HostDeployPolicyStatus struct {
+	// Namespaces reports the BareMetalHosts in the same namespace as the
+	// HostDeployPolicy bound to the HostClaims of each namespace, and the
+	// remaining capacity of the quotas applying to them.
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Namespaces []NamespaceHostUsage `json:"namespaces,omitempty"`
+}
+
+// NamespaceHostUsage is the use of BareMetalHosts by the HostClaims of a
+// namespace.
+type NamespaceHostUsage struct {
+	// Namespace of the HostClaims.
+	Namespace string `json:"namespace"`
+
+	// ClaimedHosts is the number of BareMetalHosts bound to HostClaims of
+	// the namespace.
+	ClaimedHosts int `json:"claimedHosts"`
+
+	// Quotas reports the use of each quota applying to the namespace.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Quotas []HostQuotaUsage `json:"quotas,omitempty"`
+}
+
+// HostQuotaUsage is the use of a quota by the HostClaims of a namespace.
+type HostQuotaUsage struct {
+	// Name of the quota.
+	Name string `json:"name"`
+
+	// Used is the number of BareMetalHosts counted against the quota.
+	Used int `json:"used"`
+
+	// Remaining is the number of BareMetalHosts that can still be bound
+	// before reaching the quota.
+	Remaining int `json:"remaining"`
 }
 
 //+kubebuilder:object:root=true
@@ -73,18 +73,120 @@ type HostNetworkAttachmentSpec struct {
 	MTU *int `json:"mtu,omitempty"`
 }
 
+// HostNetworkAttachmentConditionType defines the condition types for
+// HostNetworkAttachment.
+type HostNetworkAttachmentConditionType string
+
+const (
+	// HostNetworkAttachmentConditionInUse indicates whether the attachment
+	// is referenced by any BareMetalHost network interface.
+	HostNetworkAttachmentConditionInUse HostNetworkAttachmentConditionType = "InUse"
+
+	// HostNetworkAttachmentReferencedReason is used when at least one
+	// BareMetalHost references the attachment.
+	HostNetworkAttachmentReferencedReason = "Referenced"
+	// HostNetworkAttachmentNotReferencedReason is used when no
+	// BareMetalHost references the attachment.
+	HostNetworkAttachmentNotReferencedReason = "NotReferenced"
+)
+
+// HostNetworkAttachmentIndexField is the name of the field index of
+// BareMetalHost resources by the HostNetworkAttachments referenced from
+// their network interfaces. The index keys are in "namespace/name" format.
+const HostNetworkAttachmentIndexField = ".spec.networkInterfaces.hostNetworkAttachment.name"
+
+// HostNetworkAttachmentReference identifies a BareMetalHost network
+// interface that uses a HostNetworkAttachment.
+type HostNetworkAttachmentReference struct {
+	// Namespace of the BareMetalHost.
+	Namespace string `json:"namespace"`
+
+	// Name of the BareMetalHost.
+	Name string `json:"name"`
+
+	// Interface is the name or MAC address of the network interface as
+	// given in the BareMetalHost spec.
+	Interface string `json:"interface"`
+}
+
+// SwitchPortState describes whether an attachment has been applied to a
+// switch port.
+type SwitchPortState string
+
+const (
+	// SwitchPortStateNotApplied means the configuration is not applied
+	// because the host is not provisioned.
+	SwitchPortStateNotApplied SwitchPortState = "NotApplied"
+	// SwitchPortStatePending means the configuration is being applied.
+	SwitchPortStatePending SwitchPortState = "Pending"
+	// SwitchPortStateApplied means the configuration has been applied.
+	SwitchPortStateApplied SwitchPortState = "Applied"
+	// SwitchPortStateFailed means the configuration could not be applied.
+	SwitchPortStateFailed SwitchPortState = "Failed"
+)
+
+// HostNetworkAttachmentPortStatus reports the result of applying the
+// attachment to the switch port of a host network interface.
+type HostNetworkAttachmentPortStatus struct {
+	HostNetworkAttachmentReference `json:",inline"`
+
+	// MACAddress of the network interface, if known.
+	// +optional
+	MACAddress string `json:"macAddress,omitempty"`
+
+	// State of the switch port configuration.
+	State SwitchPortState `json:"state"`
+
+	// Message explains a failure to apply the configuration.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HostNetworkAttachmentStatus defines the observed state of HostNetworkAttachment.
+type HostNetworkAttachmentStatus struct {
+	// ReferencedBy lists the BareMetalHost network interfaces that use
+	// this attachment.
+	// +optional
+	ReferencedBy []HostNetworkAttachmentReference `json:"referencedBy,omitempty"`
+
+	// Ports reports the result of applying this attachment to the switch
+	// port of each referencing network interface.
+	// +optional
+	Ports []HostNetworkAttachmentPortStatus `json:"ports,omitempty"`
+
+	// Conditions describes the state of the HostNetworkAttachment resource.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
 // HostNetworkAttachment defines switchport configuration for BMH network interfaces.
 // Spec fields are mutable when no BMH references the attachment, immutable when in use.
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Switchport mode"
 // +kubebuilder:printcolumn:name="Native VLAN",type="integer",JSONPath=".spec.nativeVLAN",description="Native VLAN ID"
+// +kubebuilder:printcolumn:name="In Use",type="string",JSONPath=".status.conditions[?(@.type==\"InUse\")].status",description="Whether the attachment is referenced by any host"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation"
 type HostNetworkAttachment struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec HostNetworkAttachmentSpec `json:"spec,omitempty"`
+	Spec   HostNetworkAttachmentSpec   `json:"spec,omitempty"`
+	Status HostNetworkAttachmentStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (a *HostNetworkAttachment) GetConditions() []metav1.Condition {
+	return a.Status.Conditions
+}
+
+// SetConditions sets conditions for this object.
+func (a *HostNetworkAttachment) SetConditions(conditions []metav1.Condition) {
+	a.Status.Conditions = conditions
 }
 
 // +kubebuilder:object:root=true
@@ -56,9 +56,29 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BMCCABundle) DeepCopyInto(out *BMCCABundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCCABundle.
+func (in *BMCCABundle) DeepCopy() *BMCCABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(BMCCABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BMCDetails) DeepCopyInto(out *BMCDetails) {
 	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(BMCCABundle)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BMCDetails.
@@ -234,7 +254,7 @@ func (in *BareMetalHostSpec) DeepCopyInto(out *BareMetalHostSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.BMC = in.BMC
+	in.BMC.DeepCopyInto(&out.BMC)
 	if in.RAID != nil {
 		in, out := &in.RAID, &out.RAID
 		*out = new(RAIDConfig)
@@ -433,6 +453,14 @@ func (in *BareMetalSwitchStatus) DeepCopyInto(out *BareMetalSwitchStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulProbeTime != nil {
+		in, out := &in.LastSuccessfulProbeTime, &out.LastSuccessfulProbeTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BareMetalSwitchStatus.
@@ -465,6 +493,26 @@ func (in *CPU) DeepCopy() *CPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPURequirements) DeepCopyInto(out *CPURequirements) {
+	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPURequirements.
+func (in *CPURequirements) DeepCopy() *CPURequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(CPURequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialsStatus) DeepCopyInto(out *CredentialsStatus) {
 	*out = *in
@@ -646,6 +694,21 @@ func (in *DetachedAnnotationArguments) DeepCopy() *DetachedAnnotationArguments {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskRequirements) DeepCopyInto(out *DiskRequirements) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskRequirements.
+func (in *DiskRequirements) DeepCopy() *DiskRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firmware) DeepCopyInto(out *Firmware) {
 	*out = *in
@@ -809,6 +872,7 @@ func (in *HardwareData) DeepCopyInto(out *HardwareData) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareData.
@@ -881,6 +945,35 @@ func (in *HardwareDataSpec) DeepCopy() *HardwareDataSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareDataStatus) DeepCopyInto(out *HardwareDataStatus) {
+	*out = *in
+	if in.SwitchPorts != nil {
+		in, out := &in.SwitchPorts, &out.SwitchPorts
+		*out = make([]NICSwitchPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareDataStatus.
+func (in *HardwareDataStatus) DeepCopy() *HardwareDataStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareDataStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareDetails) DeepCopyInto(out *HardwareDetails) {
 	*out = *in
@@ -948,6 +1041,36 @@ func (in *HardwareRAIDVolume) DeepCopy() *HardwareRAIDVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareRequirements) DeepCopyInto(out *HardwareRequirements) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(CPURequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = new(DiskRequirements)
+		**out = **in
+	}
+	if in.NICs != nil {
+		in, out := &in.NICs, &out.NICs
+		*out = new(NICRequirements)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareRequirements.
+func (in *HardwareRequirements) DeepCopy() *HardwareRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareSystemVendor) DeepCopyInto(out *HardwareSystemVendor) {
 	*out = *in
@@ -1081,6 +1204,16 @@ func (in *HostClaimSpec) DeepCopyInto(out *HostClaimSpec) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.HardwareRequirements != nil {
+		in, out := &in.HardwareRequirements, &out.HardwareRequirements
+		*out = new(HardwareRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReservationTTL != nil {
+		in, out := &in.ReservationTTL, &out.ReservationTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostClaimSpec.
@@ -1112,6 +1245,10 @@ func (in *HostClaimStatus) DeepCopyInto(out *HostClaimStatus) {
 		*out = new(ObjectReference)
 		**out = **in
 	}
+	if in.ReservationExpirationTime != nil {
+		in, out := &in.ReservationExpirationTime, &out.ReservationExpirationTime
+		*out = (*in).DeepCopy()
+	}
 	if in.HardwareData != nil {
 		in, out := &in.HardwareData, &out.HardwareData
 		*out = new(ObjectReference)
@@ -1135,7 +1272,7 @@ func (in *HostDeployPolicy) DeepCopyInto(out *HostDeployPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDeployPolicy.
@@ -1196,6 +1333,13 @@ func (in *HostDeployPolicySpec) DeepCopyInto(out *HostDeployPolicySpec) {
 		*out = new(HostClaimNamespaces)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]HostQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDeployPolicySpec.
@@ -1211,6 +1355,13 @@ func (in *HostDeployPolicySpec) DeepCopy() *HostDeployPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostDeployPolicyStatus) DeepCopyInto(out *HostDeployPolicyStatus) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceHostUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostDeployPolicyStatus.
@@ -1465,6 +1616,7 @@ func (in *HostNetworkAttachment) DeepCopyInto(out *HostNetworkAttachment) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkAttachment.
@@ -1517,6 +1669,22 @@ func (in *HostNetworkAttachmentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkAttachmentPortStatus) DeepCopyInto(out *HostNetworkAttachmentPortStatus) {
+	*out = *in
+	out.HostNetworkAttachmentReference = in.HostNetworkAttachmentReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkAttachmentPortStatus.
+func (in *HostNetworkAttachmentPortStatus) DeepCopy() *HostNetworkAttachmentPortStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkAttachmentPortStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostNetworkAttachmentRef) DeepCopyInto(out *HostNetworkAttachmentRef) {
 	*out = *in
@@ -1532,6 +1700,21 @@ func (in *HostNetworkAttachmentRef) DeepCopy() *HostNetworkAttachmentRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkAttachmentReference) DeepCopyInto(out *HostNetworkAttachmentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkAttachmentReference.
+func (in *HostNetworkAttachmentReference) DeepCopy() *HostNetworkAttachmentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkAttachmentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostNetworkAttachmentSpec) DeepCopyInto(out *HostNetworkAttachmentSpec) {
 	*out = *in
@@ -1557,6 +1740,80 @@ func (in *HostNetworkAttachmentSpec) DeepCopy() *HostNetworkAttachmentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkAttachmentStatus) DeepCopyInto(out *HostNetworkAttachmentStatus) {
+	*out = *in
+	if in.ReferencedBy != nil {
+		in, out := &in.ReferencedBy, &out.ReferencedBy
+		*out = make([]HostNetworkAttachmentReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]HostNetworkAttachmentPortStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkAttachmentStatus.
+func (in *HostNetworkAttachmentStatus) DeepCopy() *HostNetworkAttachmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkAttachmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostQuota) DeepCopyInto(out *HostQuota) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostQuota.
+func (in *HostQuota) DeepCopy() *HostQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(HostQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostQuotaUsage) DeepCopyInto(out *HostQuotaUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostQuotaUsage.
+func (in *HostQuotaUsage) DeepCopy() *HostQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(HostQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostSelector) DeepCopyInto(out *HostSelector) {
 	*out = *in
@@ -1760,6 +2017,41 @@ func (in *NIC) DeepCopy() *NIC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NICRequirements) DeepCopyInto(out *NICRequirements) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NICRequirements.
+func (in *NICRequirements) DeepCopy() *NICRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(NICRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NICSwitchPort) DeepCopyInto(out *NICSwitchPort) {
+	*out = *in
+	if in.Switch != nil {
+		in, out := &in.Switch, &out.Switch
+		*out = new(SwitchReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NICSwitchPort.
+func (in *NICSwitchPort) DeepCopy() *NICSwitchPort {
+	if in == nil {
+		return nil
+	}
+	out := new(NICSwitchPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NameValuePair) DeepCopyInto(out *NameValuePair) {
 	*out = *in
@@ -1775,6 +2067,26 @@ func (in *NameValuePair) DeepCopy() *NameValuePair {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceHostUsage) DeepCopyInto(out *NamespaceHostUsage) {
+	*out = *in
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]HostQuotaUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceHostUsage.
+func (in *NamespaceHostUsage) DeepCopy() *NamespaceHostUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceHostUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
 	*out = *in
@@ -2227,6 +2539,21 @@ func (in *SwitchPort) DeepCopy() *SwitchPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchReference) DeepCopyInto(out *SwitchReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchReference.
+func (in *SwitchReference) DeepCopy() *SwitchReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VLAN) DeepCopyInto(out *VLAN) {
 	*out = *in
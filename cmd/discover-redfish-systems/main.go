@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/metal3-io/baremetal-operator/cmd/make-bm-worker/templates"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// hostName builds a valid resource name for the host of a system.
+func hostName(prefix string, system bmc.RedfishSystem) string {
+	id := system.ID
+	if id == "" {
+		id = system.Path[strings.LastIndex(system.Path, "/")+1:]
+	}
+	name := strings.ToLower(strings.ReplaceAll(prefix, "_", "-") + "-" + id)
+	return strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+}
+
+func main() {
+	var username = flag.String("user", "", "username for BMC")
+	var password = flag.String("password", "", "password for BMC")
+	var bmcAddress = flag.String("address", "", "address URL of the Redfish service of the BMC")
+	var disableCertificateVerification = flag.Bool("disableCertificateVerification", false, "will skip certificate validation when true")
+	var macAddress = flag.String("boot-mac", "", "only use the system with a network interface having this MAC address")
+	var bootMode = flag.String("boot-mode", "", "boot-mode for the hosts (UEFI, UEFISecureBoot or legacy)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] [name-prefix]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Lists the systems managed by a Redfish BMC. With a name prefix, prints\n")
+		fmt.Fprintf(os.Stderr, "a BareMetalHost manifest for each of them instead. With -boot-mac and\n")
+		fmt.Fprintf(os.Stderr, "no name prefix, prints the BMC address of the matching system, to be\n")
+		fmt.Fprintf(os.Stderr, "used as spec.bmc.address since the operator does not resolve it.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	namePrefix := flag.Arg(0)
+	if *username == "" {
+		fmt.Fprintf(os.Stderr, "Missing -user argument\n")
+		os.Exit(1)
+	}
+	if *password == "" {
+		fmt.Fprintf(os.Stderr, "Missing -password argument\n")
+		os.Exit(1)
+	}
+	if *bmcAddress == "" {
+		fmt.Fprintf(os.Stderr, "Missing -address argument\n")
+		os.Exit(1)
+	}
+	if *bootMode != "" && *bootMode != "UEFI" && *bootMode != "UEFISecureBoot" && *bootMode != "legacy" {
+		fmt.Fprintf(os.Stderr, "Invalid boot mode %q, use \"UEFI\", \"UEFISecureBoot\" or \"legacy\"\n", *bootMode)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	creds := bmc.Credentials{Username: *username, Password: *password}
+	var systems []bmc.RedfishSystem
+	if *macAddress != "" {
+		system, err := bmc.FindRedfishSystem(ctx, *bmcAddress, *disableCertificateVerification, creds, *macAddress)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+			os.Exit(1)
+		}
+		systems = []bmc.RedfishSystem{*system}
+	} else {
+		var err error
+		systems, err = bmc.DiscoverRedfishSystems(ctx, *bmcAddress, *disableCertificateVerification, creds)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	addresses := make([]string, len(systems))
+	for i, system := range systems {
+		address, err := system.Address(*bmcAddress)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+			os.Exit(1)
+		}
+		addresses[i] = address
+	}
+
+	switch {
+	case namePrefix != "":
+		for i, system := range systems {
+			template := templates.Template{
+				Name:                           hostName(namePrefix, system),
+				BMCAddress:                     addresses[i],
+				DisableCertificateVerification: *disableCertificateVerification,
+				Username:                       *username,
+				Password:                       *password,
+				BootMacAddress:                 *macAddress,
+				BootMode:                       *bootMode,
+			}
+			if template.BootMacAddress == "" && len(system.MACAddresses) > 0 {
+				template.BootMacAddress = system.MACAddresses[0]
+			}
+			result, err := template.Render()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprint(os.Stdout, result)
+		}
+	case *macAddress != "":
+		fmt.Fprintln(os.Stdout, addresses[0])
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+		fmt.Fprintln(w, "ADDRESS\tSERIAL\tUUID\tMAC ADDRESSES")
+		for i, system := range systems {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", addresses[i], system.SerialNumber, system.UUID, strings.Join(system.MACAddresses, ","))
+		}
+		w.Flush()
+	}
+}
@@ -49,6 +49,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -72,6 +73,15 @@ type BareMetalHostReconciler struct {
 	APIReader              client.Reader
 	Recorder               record.EventRecorder
 	MaxProvisioningRetries int
+	ProvisioningLimits     ProvisioningLimits
+	// ProbeBMC enables checking the address and credentials of the BMC of
+	// hosts before registering them, for the BMC types the operator can
+	// contact itself.
+	ProbeBMC bool
+	// CredentialsProvider, when set, is where the BMC credentials of hosts
+	// are looked up instead of Secrets. The credentials name of a host is
+	// then the name of its credentials in the provider.
+	CredentialsProvider secretutils.CredentialsProvider
 }
 
 // Instead of passing a zillion arguments to the action of a phase,
@@ -81,7 +91,9 @@ type reconcileInfo struct {
 	host                             *metal3api.BareMetalHost
 	hardwareData                     *metal3api.HardwareData
 	request                          ctrl.Request
+	bmcCreds                         bmc.Credentials
 	bmcCredsSecret                   *corev1.Secret
+	bmcCABundle                      []byte
 	preprovisioningNetworkDataSecret *corev1.Secret
 	events                           []corev1.Event
 	postSaveCallbacks                []func()
@@ -99,6 +111,7 @@ func (info *reconcileInfo) publishEvent(reason, message string) {
 // +kubebuilder:rbac:groups=metal3.io,resources=hardwaredata,verbs=get;list;watch;create;delete;patch;update
 // +kubebuilder:rbac:groups=metal3.io,resources=hardware/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;update;patch
 
 // Allow for managing hostfirmwaresettings, firmwareschema, bmceventsubscriptions and hostfirmwarecomponents
@@ -114,6 +127,9 @@ func (info *reconcileInfo) publishEvent(reason, message string) {
 // Allow for updating hostupdatepolicies
 // +kubebuilder:rbac:groups=metal3.io,resources=hostupdatepolicies,verbs=get;list;watch;update;delete
 
+// Allow reading hostnetworkattachments
+// +kubebuilder:rbac:groups=metal3.io,resources=hostnetworkattachments,verbs=get;list;watch
+
 // Allow reading Ironic resources
 // +kubebuilder:rbac:groups=ironic.metal3.io,resources=ironics,verbs=get;list;watch
 
@@ -235,11 +251,21 @@ func (r *BareMetalHostReconciler) Reconcile(ctx context.Context, request ctrl.Re
 		host:                             host,
 		hardwareData:                     hardwareData,
 		request:                          request,
+		bmcCreds:                         *bmcCreds,
 		bmcCredsSecret:                   bmcCredsSecret,
 		preprovisioningNetworkDataSecret: preprovisioningNetworkDataSecret,
 	}
 
-	prov, err := r.ProvisionerFactory.NewProvisioner(ctx, provisioner.BuildHostData(*host, *bmcCreds), info.publishEvent)
+	provHostData := provisioner.BuildHostData(*host, *bmcCreds)
+	if haveCreds {
+		provHostData.BMCCABundle, err = r.bmcCABundle(ctx, host)
+		if err != nil {
+			return r.credentialsErrorResult(ctx, err, request, host)
+		}
+		info.bmcCABundle = provHostData.BMCCABundle
+	}
+
+	prov, err := r.ProvisionerFactory.NewProvisioner(ctx, provHostData, info.publishEvent)
 	if err != nil {
 		if errors.Is(err, provisioner.ErrNotReady) {
 			provisionerNotReady.Inc()
@@ -251,6 +277,18 @@ func (r *BareMetalHostReconciler) Reconcile(ctx context.Context, request ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to create provisioner: %w", err)
 	}
 
+	// Record the shard as soon as it is selected, so that it is not
+	// selected again on every reconcile until the host is registered.
+	if sharded, ok := prov.(provisioner.Sharded); ok {
+		if shard := sharded.Shard(); shard != "" && host.Status.Provisioning.Shard != shard {
+			reqLogger.Info("setting provisioner shard", "shard", shard)
+			host.Status.Provisioning.Shard = shard
+			if err := r.saveHostStatus(ctx, host); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to save the provisioner shard: %w", err)
+			}
+		}
+	}
+
 	stateMachine := newHostStateMachine(host, r, prov, haveCreds)
 	actResult := stateMachine.ReconcileState(ctx, info)
 	result, err = actResult.Result()
@@ -379,7 +417,7 @@ func recordActionFailure(info *reconcileInfo, errorType metal3api.ErrorType, err
 	return actionFailed{dirty: true, ErrorType: errorType, errorCount: info.host.Status.ErrorCount}
 }
 
-func recordActionDelayed(info *reconcileInfo, state metal3api.ProvisioningState) actionResult {
+func recordActionDelayed(info *reconcileInfo, state metal3api.ProvisioningState, reason, message string) actionResult {
 	var counter prometheus.Counter
 
 	if state == metal3api.StateDeprovisioning {
@@ -391,14 +429,20 @@ func recordActionDelayed(info *reconcileInfo, state metal3api.ProvisioningState)
 	info.postSaveCallbacks = append(info.postSaveCallbacks, counter.Inc)
 
 	info.host.SetOperationalStatus(metal3api.OperationalStatusDelayed)
+	conditions.Set(info.host, metav1.Condition{
+		Type:    metal3api.ProvisioningDelayedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
 	return actionDelayed{}
 }
 
 func (r *BareMetalHostReconciler) credentialsErrorResult(ctx context.Context, err error, request ctrl.Request, host *metal3api.BareMetalHost) (ctrl.Result, error) {
 	// In the event a credential secret is defined, but we cannot find it
 	// we requeue the host as we will not know if they create the secret
-	// at some point in the future.
-	if errors.As(err, new(*ResolveBMCSecretRefError)) {
+	// at some point in the future. The same goes for the CA bundle.
+	if errors.As(err, new(*ResolveBMCSecretRefError)) || errors.As(err, new(*BMCCABundleError)) {
 		credentialsMissing.Inc()
 		saveErr := r.setErrorCondition(ctx, request, host, metal3api.RegistrationError, err.Error())
 		if saveErr != nil {
@@ -781,6 +825,15 @@ func getHostArchitecture(host *metal3api.BareMetalHost) string {
 	return getControllerArchitecture()
 }
 
+// specOrLabel returns the value of a spec field, or of the label that can
+// be used instead when the field is empty.
+func specOrLabel(host *metal3api.BareMetalHost, value, label string) string {
+	if value != "" {
+		return value
+	}
+	return host.Labels[label]
+}
+
 func (r *BareMetalHostReconciler) getPreprovImage(ctx context.Context, info *reconcileInfo, formats []metal3api.ImageFormat) (*provisioner.PreprovisioningImage, error) {
 	if formats == nil {
 		// No image build requested
@@ -904,6 +957,12 @@ func (r *BareMetalHostReconciler) registerHost(ctx context.Context, prov provisi
 		dirty = true
 	}
 
+	if r.ProbeBMC && needsBMCProbe(info.host, credsChanged) {
+		if result := r.probeBMC(ctx, info); result != nil {
+			return result
+		}
+	}
+
 	preprovImgFormats, err := prov.PreprovisioningImageFormats(ctx)
 	if err != nil {
 		return actionError{err}
@@ -949,6 +1008,9 @@ func (r *BareMetalHostReconciler) registerHost(ctx context.Context, prov provisi
 		return recordActionFailure(info, metal3api.RegistrationError, fmt.Sprintf("failed to read preprovisioningNetworkData: %v", err))
 	}
 
+	// Interfaces that are not known yet are picked up after inspection.
+	networkInterfaces, _ := resolveNetworkInterfaces(info.host, info.hardwareData)
+
 	provResult, provID, err := prov.Register(
 		ctx,
 		provisioner.ManagementAccessData{
@@ -965,6 +1027,9 @@ func (r *BareMetalHostReconciler) registerHost(ctx context.Context, prov provisi
 			HardwareData:               info.hardwareData,
 			DisableInspection:          info.host.InspectionDisabled(),
 			InspectionMode:             info.host.Spec.InspectionMode,
+			NetworkInterfaces:          networkInterfaces,
+			ConductorGroup:             specOrLabel(info.host, info.host.Spec.ConductorGroup, metal3api.ConductorGroupLabel),
+			NodeShard:                  specOrLabel(info.host, info.host.Spec.NodeShard, metal3api.NodeShardLabel),
 		},
 		credsChanged,
 		info.host.Status.ErrorType == metal3api.RegistrationError)
@@ -1005,6 +1070,14 @@ func (r *BareMetalHostReconciler) registerHost(ctx context.Context, prov provisi
 		dirty = true
 	}
 
+	if sharded, ok := prov.(provisioner.Sharded); ok {
+		if shard := sharded.Shard(); shard != "" && info.host.Status.Provisioning.Shard != shard {
+			info.log.Info("setting provisioner shard", "shard", shard)
+			info.host.Status.Provisioning.Shard = shard
+			dirty = true
+		}
+	}
+
 	if provResult.Dirty {
 		info.log.V(VerbosityLevelDebug).Info("host not ready",
 			LogFieldRequeueAfter, provResult.RequeueAfter)
@@ -1033,7 +1106,11 @@ func (r *BareMetalHostReconciler) registerHost(ctx context.Context, prov provisi
 			info.log.Info("will not attempt to create new hostFirmwareSettings and hostFirmwareComponents in " + info.host.Namespace)
 		} else {
 			// Check if the host can support firmware components before creating the resource
-			firmwareComponents, errGetFirmwareComponents := prov.GetFirmwareComponents(ctx)
+			var firmwareComponents []metal3api.FirmwareComponentStatus
+			errGetFirmwareComponents := provisioner.CheckCapability(ctx, prov, provisioner.CapabilityFirmwareComponents)
+			if errGetFirmwareComponents == nil {
+				firmwareComponents, errGetFirmwareComponents = prov.GetFirmwareComponents(ctx)
+			}
 			if errGetFirmwareComponents != nil {
 				info.log.V(1).Error(errGetFirmwareComponents, "failed to retrieve firmware components; deferring HostFirmwareComponents creation")
 				firmwareComponents = nil
@@ -1433,14 +1510,31 @@ func (r *BareMetalHostReconciler) actionProvisioning(ctx context.Context, prov p
 		return recordActionFailure(info, metal3api.ProvisioningError, err.Error())
 	}
 
+	networkInterfaces, err := r.getNetworkInterfaceData(ctx, info)
+	if err != nil {
+		if !errors.As(err, &networkConfigError{}) {
+			return actionError{err}
+		}
+		// The missing interfaces or attachments may still show up, so wait
+		// for them instead of failing provisioning.
+		info.log.Info("network configuration cannot be resolved", "reason", err.Error())
+		result := actionContinue{subResourceNotReadyRetryDelay}
+		if setNetworkConfiguredCondition(info.host, metav1.ConditionFalse, metal3api.NetworkConfigErrorReason, err.Error()) {
+			return actionUpdate{result}
+		}
+		return result
+	}
+	networkDirty := setNetworkConfiguredCondition(info.host, metav1.ConditionFalse, metal3api.NetworkConfiguringReason, "")
+
 	provResult, err := prov.Provision(ctx, provisioner.ProvisionData{
-		Image:           image,
-		CustomDeploy:    info.host.Spec.CustomDeploy.DeepCopy(),
-		HostConfig:      hostConf,
-		BootMode:        info.host.Status.Provisioning.BootMode,
-		HardwareProfile: hwProf,
-		RootDeviceHints: info.host.Status.Provisioning.RootDeviceHints.DeepCopy(),
-		ImagePullSecret: authSecret,
+		Image:             image,
+		CustomDeploy:      info.host.Spec.CustomDeploy.DeepCopy(),
+		HostConfig:        hostConf,
+		BootMode:          info.host.Status.Provisioning.BootMode,
+		HardwareProfile:   hwProf,
+		RootDeviceHints:   info.host.Status.Provisioning.RootDeviceHints.DeepCopy(),
+		ImagePullSecret:   authSecret,
+		NetworkInterfaces: networkInterfaces,
 	}, forceReboot)
 	if err != nil {
 		return actionError{fmt.Errorf("failed to provision: %w", err)}
@@ -1463,12 +1557,14 @@ func (r *BareMetalHostReconciler) actionProvisioning(ctx context.Context, prov p
 		// to return false, indicating that it has no more work to
 		// do.
 		result := actionContinue{provResult.RequeueAfter}
-		if clearError(info.host) {
+		if clearError(info.host) || networkDirty {
 			return actionUpdate{result}
 		}
 		return result
 	}
 
+	setNetworkConfiguredCondition(info.host, metav1.ConditionTrue, metal3api.NetworkConfiguredReason, "")
+
 	// If the provisioner had no work, ensure the image settings match.
 	if info.host.Spec.Image != nil && info.host.Status.Provisioning.Image != *(info.host.Spec.Image) {
 		info.log.V(VerbosityLevelDebug).Info("updating deployed image in status")
@@ -1846,6 +1942,19 @@ func (r *BareMetalHostReconciler) handleDataImageActions(ctx context.Context, pr
 	// given constant ?
 	dataImageRetryBackoff := max(dataImageUpdateDelay, calculateBackoff(dataImage.Status.Error.Count))
 
+	// Power on without the dataImage when the provisioner cannot attach it,
+	// nothing can have been attached before either
+	if err := provisioner.CheckCapability(ctx, prov, provisioner.CapabilityDataImage); err != nil {
+		info.log.Info("not handling dataImage", LogFieldError, err.Error())
+		if dataImage.Status.Error.Message != err.Error() {
+			dataImage.Status.Error.Message = err.Error()
+			if err := r.Status().Update(ctx, dataImage); err != nil {
+				return actionError{fmt.Errorf("failed to update DataImage status, %w", err)}
+			}
+		}
+		return nil
+	}
+
 	// Check if dataImage is attached to the node or not
 	// Given that this is a synchronous call to Ironic, should we add
 	// a longer wait ?
@@ -2397,9 +2506,12 @@ func computeConditions(ctx context.Context, host *metal3api.BareMetalHost, prov
 		setConditionFalse(host, metal3api.ProgressingCondition, metal3api.NotProgressingReason)
 		powerFailureCheck = false
 	case metal3api.StateRegistering:
-		if host.Status.OperationalStatus == metal3api.OperationalStatusError {
+		switch {
+		case hasBMCProbeFailure(host):
+			// The probe sets the details of the failure.
+		case host.Status.OperationalStatus == metal3api.OperationalStatusError:
 			setConditionFalse(host, metal3api.ManageableCondition, metal3api.RegistrationFailedReason)
-		} else {
+		default:
 			setConditionFalse(host, metal3api.ManageableCondition, metal3api.RegisteringReason)
 		}
 		setConditionFalse(host, metal3api.AvailableForProvisioningCondition, metal3api.NotAvailableReason)
@@ -2445,6 +2557,15 @@ func computeConditions(ctx context.Context, host *metal3api.BareMetalHost, prov
 		setConditionFalse(host, metal3api.ProgressingCondition, metal3api.DetachedReason)
 	default:
 	}
+	if host.Status.OperationalStatus != metal3api.OperationalStatusDelayed {
+		conditions.Delete(host, metal3api.ProvisioningDelayedCondition)
+	}
+	switch host.Status.Provisioning.State {
+	case metal3api.StateProvisioning, metal3api.StateProvisioned:
+		// The NetworkConfigured condition is managed while provisioning.
+	default:
+		setNetworkConfiguredCondition(host, metav1.ConditionFalse, metal3api.NetworkNotConfiguredReason, "")
+	}
 	if powerFailureCheck && prov != nil && prov.HasPowerFailure(ctx) {
 		setConditionFalse(host, metal3api.ManageableCondition, metal3api.PowerFailureReason)
 	}
@@ -2452,6 +2573,15 @@ func computeConditions(ctx context.Context, host *metal3api.BareMetalHost, prov
 		setConditionUnknown(host, metal3api.HealthyCondition, metal3api.UnknownHealthReason)
 		return
 	}
+	if err := provisioner.CheckCapability(ctx, prov, provisioner.CapabilityHealth); err != nil {
+		conditions.Set(host, metav1.Condition{
+			Type:    metal3api.HealthyCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  metal3api.HealthNotSupportedReason,
+			Message: err.Error(),
+		})
+		return
+	}
 	switch health := prov.GetHealth(ctx); health {
 	case "":
 		if meta.FindStatusCondition(host.Status.Conditions, string(metal3api.HealthyCondition)) == nil {
@@ -2603,8 +2733,15 @@ func credentialsFromSecret(bmcCredsSecret *corev1.Secret) *bmc.Credentials {
 // right and manufacture bmc.Credentials.  This does not actually try
 // to use the credentials.
 func (r *BareMetalHostReconciler) buildAndValidateBMCCredentials(ctx context.Context, request ctrl.Request, host *metal3api.BareMetalHost) (bmcCreds *bmc.Credentials, bmcCredsSecret *corev1.Secret, err error) {
-	// Retrieve the BMC secret from Kubernetes for this host
-	bmcCredsSecret, err = r.getBMCSecretAndSetOwner(ctx, request, host)
+	if r.CredentialsProvider != nil {
+		bmcCreds, bmcCredsSecret, err = r.externalBMCCredentials(ctx, host)
+	} else {
+		// Retrieve the BMC secret from Kubernetes for this host
+		bmcCredsSecret, err = r.getBMCSecretAndSetOwner(ctx, request, host)
+		if err == nil {
+			bmcCreds = credentialsFromSecret(bmcCredsSecret)
+		}
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2615,8 +2752,6 @@ func (r *BareMetalHostReconciler) buildAndValidateBMCCredentials(ctx context.Con
 		return nil, nil, &EmptyBMCAddressError{message: "Missing BMC connection detail 'Address'"}
 	}
 
-	bmcCreds = credentialsFromSecret(bmcCredsSecret)
-
 	// Verify that the secret contains the expected info.
 	err = bmcCreds.Validate()
 	if err != nil {
@@ -2626,6 +2761,33 @@ func (r *BareMetalHostReconciler) buildAndValidateBMCCredentials(ctx context.Con
 	return bmcCreds, bmcCredsSecret, nil
 }
 
+// externalBMCCredentials looks the BMC credentials of the host up with the
+// credentials provider. They come with a Secret standing for them, which is
+// never stored: its resource version is the version of the credentials, so
+// that the credentials status of the host tracks them as it tracks Secrets.
+func (r *BareMetalHostReconciler) externalBMCCredentials(ctx context.Context, host *metal3api.BareMetalHost) (*bmc.Credentials, *corev1.Secret, error) {
+	if host.Spec.BMC.CredentialsName == "" {
+		return nil, nil, &EmptyBMCSecretError{message: "The BMC secret reference is empty"}
+	}
+
+	key := host.CredentialsKey()
+	creds, err := r.CredentialsProvider.Credentials(ctx, key)
+	if errors.Is(err, secretutils.ErrCredentialsNotFound) {
+		return nil, nil, &ResolveBMCSecretRefError{message: fmt.Sprintf("The BMC credentials %s were not found by the credentials provider", key)}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to look up the BMC credentials %s: %w", key, err)
+	}
+
+	return &bmc.Credentials{Username: creds.Username, Password: creds.Password},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			ResourceVersion: creds.Version,
+		}},
+		nil
+}
+
 func (r *BareMetalHostReconciler) publishEvent(ctx context.Context, request ctrl.Request, event corev1.Event) {
 	reqLogger := r.Log.WithValues(LogFieldHost, request.NamespacedName)
 	reqLogger.V(VerbosityLevelDebug).Info("publishing event",
@@ -2683,7 +2845,11 @@ func (r *BareMetalHostReconciler) SetupWithManager(mgr ctrl.Manager, preprovImgE
 				UpdateFunc: r.updateEventHandler,
 			}).
 		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconcile}).
-		Owns(&corev1.Secret{}, builder.MatchEveryOwner)
+		Owns(&corev1.Secret{}, builder.MatchEveryOwner).
+		// CA bundles are shared by hosts rather than owned by them. Only the
+		// metadata of ConfigMaps is cached, as they are not labelled.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.caBundleToHosts)).
+		WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.caBundleToHosts))
 
 	if preprovImgEnable {
 		// We use SetControllerReference() to set the owner reference, so no
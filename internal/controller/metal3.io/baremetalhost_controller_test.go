@@ -2640,6 +2640,17 @@ func TestGetHostArchitecture(t *testing.T) {
 	assert.Equal(t, "aarch64", getHostArchitecture(host))
 }
 
+func TestSpecOrLabel(t *testing.T) {
+	host := newDefaultHost(t)
+	assert.Empty(t, specOrLabel(host, host.Spec.ConductorGroup, metal3api.ConductorGroupLabel))
+
+	host.Labels = map[string]string{metal3api.ConductorGroupLabel: "row-1"}
+	assert.Equal(t, "row-1", specOrLabel(host, host.Spec.ConductorGroup, metal3api.ConductorGroupLabel))
+
+	host.Spec.ConductorGroup = "row-2"
+	assert.Equal(t, "row-2", specOrLabel(host, host.Spec.ConductorGroup, metal3api.ConductorGroupLabel))
+}
+
 func TestGetPreprovImageNoFormats(t *testing.T) {
 	host := newDefaultHost(t)
 	r := newTestReconciler(t, host)
@@ -3425,6 +3436,23 @@ func TestComputeHealthyConditionEmptyHealth(t *testing.T) {
 	assert.Equal(t, metav1.ConditionTrue, cond.Status, "empty health should not overwrite existing condition")
 }
 
+func TestComputeHealthyConditionNotSupported(t *testing.T) {
+	host := bmhWithStatus(metal3api.OperationalStatusOK, metal3api.StateAvailable)
+	fix := &fixture.Fixture{
+		Health:      provisioner.HealthOK,
+		Unsupported: []provisioner.Capability{provisioner.CapabilityHealth},
+	}
+	prov, err := fix.NewProvisioner(t.Context(), provisioner.BuildHostData(*host, bmc.Credentials{}), nil)
+	require.NoError(t, err)
+
+	computeConditions(t.Context(), host, prov)
+	cond := conditions.Get(host, metal3api.HealthyCondition)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, metal3api.HealthNotSupportedReason, cond.Reason)
+	assert.Equal(t, "Health is not supported by provisioner fixture", cond.Message)
+}
+
 func TestComputeConditions(t *testing.T) {
 	fix := fixture.Fixture{PowerFailed: true}
 	provisionerWithPowerFailure, err := fix.NewProvisioner(t.Context(), provisioner.HostData{}, nil)
@@ -3727,3 +3755,48 @@ func createDockerConfigJSONSecretForTest(t *testing.T, name, ns string, auths ma
 		},
 	}
 }
+
+// shardedFactory assigns its provisioners to a shard, unless the host
+// already has one.
+type shardedFactory struct {
+	*fixture.Fixture
+	shard string
+}
+
+type shardedProvisioner struct {
+	provisioner.Provisioner
+	shard string
+}
+
+func (p shardedProvisioner) Shard() string {
+	return p.shard
+}
+
+func (f shardedFactory) NewProvisioner(ctx context.Context, hostData provisioner.HostData, publisher provisioner.EventPublisher) (provisioner.Provisioner, error) {
+	prov, err := f.Fixture.NewProvisioner(ctx, hostData, publisher)
+	if err != nil {
+		return nil, err
+	}
+	shard := hostData.Shard
+	if shard == "" {
+		shard = f.shard
+	}
+	return shardedProvisioner{Provisioner: prov, shard: shard}, nil
+}
+
+// TestShardRecordedBeforeRegistration checks that the shard selected for a
+// host is saved before it is registered, so it is not selected again.
+func TestShardRecordedBeforeRegistration(t *testing.T) {
+	host := newDefaultHost(t)
+	r := newTestReconciler(t, host)
+	r.ProvisionerFactory = shardedFactory{Fixture: &fixture.Fixture{}, shard: "east"}
+
+	tryReconcile(t, r, host,
+		func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+			return host.Status.Provisioning.Shard != ""
+		},
+	)
+
+	assert.Equal(t, "east", host.Status.Provisioning.Shard)
+	assert.Empty(t, host.Status.Provisioning.ID, "the shard must be recorded before registration")
+}
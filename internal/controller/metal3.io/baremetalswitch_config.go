@@ -21,13 +21,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"maps"
 	"sort"
-	"strings"
-	"text/template"
 
 	"github.com/go-logr/logr"
 	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/switchdriver"
 	"github.com/metal3-io/baremetal-operator/pkg/secretutils"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -54,6 +53,14 @@ type credentialSecretNotFoundError struct {
 
 func (e *credentialSecretNotFoundError) Error() string { return e.msg }
 
+// switchDriverError indicates that the driver requested by a switch is not
+// supported. The switch is skipped until its spec is fixed.
+type switchDriverError struct {
+	msg string
+}
+
+func (e *switchDriverError) Error() string { return e.msg }
+
 // switchConfigResult holds the per-switch config entries and any collected
 // SSH private key files for publickey-authenticated switches. Both maps
 // are keyed per-switch: configEntries by switch name, keyFiles by MAC address.
@@ -63,15 +70,16 @@ type switchConfigResult struct {
 	// keyFiles maps "<mac-address>.key" to SSH private key bytes for
 	// publickey-authenticated switches.
 	keyFiles map[string][]byte
-	// credentialErrors maps switch name to the credential error that caused
-	// the switch to be skipped during config generation. Values are either
-	// *credentialConfigError or *credentialSecretNotFoundError.
+	// credentialErrors maps switch name to the credential or driver error
+	// that caused the switch to be skipped during config generation. Values
+	// are *credentialConfigError, *credentialSecretNotFoundError or
+	// *switchDriverError.
 	credentialErrors map[string]error
 }
 
-// generateSwitchConfig generates the INI-format switch configuration for ironic-networking.
+// generateSwitchConfig generates the switch configuration for ironic-networking.
 // It returns per-switch config entries and a map of key files for publickey switches.
-// Switches with credential errors are skipped with a warning log instead of failing
+// Switches with credential or driver errors are skipped with a log instead of failing
 // the entire config generation.
 func generateSwitchConfig(ctx context.Context, c client.Client, sm secretutils.SecretManager, namespace, credentialsPath string, logger logr.Logger) (*switchConfigResult, error) {
 	// List all BareMetalSwitch resources in the namespace
@@ -89,6 +97,12 @@ func generateSwitchConfig(ctx context.Context, c client.Client, sm secretutils.S
 	// Generate config for each switch
 	for i := range switchList.Items {
 		if err := writeSwitchEntry(ctx, sm, &switchList.Items[i], credentialsPath, result.configEntries, result.keyFiles); err != nil {
+			if errors.As(err, new(*switchDriverError)) {
+				logger.Info("skipping switch with an unsupported driver",
+					"switch", switchList.Items[i].Name, "driver", switchList.Items[i].Spec.Driver, "error", err)
+				result.credentialErrors[switchList.Items[i].Name] = err
+				continue
+			}
 			if errors.As(err, new(*credentialConfigError)) || errors.As(err, new(*credentialSecretNotFoundError)) {
 				logger.Info("skipping switch due to credential error",
 					"switch", switchList.Items[i].Name, "error", err)
@@ -102,53 +116,57 @@ func generateSwitchConfig(ctx context.Context, c client.Client, sm secretutils.S
 	return result, nil
 }
 
-// switchConfigData holds the template data for generating a switch's INI config entry.
-type switchConfigData struct {
-	Name          string
-	Address       string
-	MACAddress    string
-	Port          *int32
-	Driver        string
-	DeviceType    string
-	Insecure      bool
-	Username      string
-	Password      string //nolint: gosec
-	KeyFile       string
-	AdminPassword string
+// writeSwitchEntry renders a single switch's config entry with the driver
+// selected by Spec.Driver and adds it to configEntries (keyed by switch name).
+// Files needed by the driver, such as the SSH private key of
+// publickey-authenticated switches, are added to keyFiles.
+func writeSwitchEntry(ctx context.Context, sm secretutils.SecretManager, sw *metal3api.BareMetalSwitch, credentialsPath string, configEntries map[string][]byte, keyFiles map[string][]byte) error {
+	driver, err := getSwitchDriver(sw)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := getSwitchCredentials(ctx, sm, sw, driver)
+	if err != nil {
+		return err
+	}
+
+	config, err := driver.RenderConfig(switchdriver.Switch{
+		Name:                           sw.Name,
+		Address:                        sw.Spec.Address,
+		MACAddress:                     sw.Spec.MACAddress,
+		Port:                           sw.Spec.Port,
+		DeviceType:                     sw.Spec.DeviceType,
+		DisableCertificateVerification: sw.Spec.DisableCertificateVerification,
+	}, credentials, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	configEntries[sw.Name] = config.Entry
+	maps.Copy(keyFiles, config.Files)
+	return nil
 }
 
-// switchConfigTemplate is the INI-format template for a single switch config section.
-var switchConfigTemplate = template.Must(template.New("switchConfig").Parse(
-	`[switch:{{.Name}}]
-address={{.Address}}
-mac_address={{.MACAddress}}
-{{- if .Port}}
-port={{.Port}}
-{{- end}}
-driver_type={{.Driver}}
-device_type={{.DeviceType}}
-{{- if .Insecure}}
-insecure={{.Insecure}}
-{{- end}}
-username={{.Username}}
-{{- if .KeyFile}}
-key_file={{.KeyFile}}
-{{- else}}
-password={{.Password}}
-{{- end}}
-{{- if .AdminPassword}}
-enable_secret={{.AdminPassword}}
-{{- end}}
-
-`))
-
-// writeSwitchEntry generates a single switch's INI config entry and adds it
-// to configEntries (keyed by switch name). For publickey-authenticated switches,
-// it also adds the SSH private key to keyFiles (keyed by "<mac-address>.key")
-// and emits a key_file= directive in the config instead of password=.
-func writeSwitchEntry(ctx context.Context, sm secretutils.SecretManager, sw *metal3api.BareMetalSwitch, credentialsPath string, configEntries map[string][]byte, keyFiles map[string][]byte) error {
+// getSwitchDriver returns the driver selected by Spec.Driver.
+func getSwitchDriver(sw *metal3api.BareMetalSwitch) (switchdriver.Driver, error) {
+	// Driver type (CRD defaults to "generic-switch", but defend against empty)
+	driverType := sw.Spec.Driver
+	if driverType == "" {
+		driverType = switchdriver.GenericSwitch
+	}
+	driver, err := switchdriver.NewDriver(driverType)
+	if err != nil {
+		return nil, &switchDriverError{msg: err.Error()}
+	}
+	return driver, nil
+}
+
+// getSwitchCredentials fetches the credentials secret referenced by a
+// switch and validates its contents for the driver.
+func getSwitchCredentials(ctx context.Context, sm secretutils.SecretManager, sw *metal3api.BareMetalSwitch, driver switchdriver.Driver) (switchdriver.Credentials, error) {
 	if sw.Spec.Credentials == nil {
-		return &credentialConfigError{msg: "credentials secret reference is not set"}
+		return nil, &credentialConfigError{msg: "credentials secret reference is not set"}
 	}
 
 	secretName := sw.Spec.Credentials.Name
@@ -165,67 +183,19 @@ func writeSwitchEntry(ctx context.Context, sm secretutils.SecretManager, sw *met
 	secret, err := sm.AcquireSecret(ctx, secretKey, sw, false)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			return &credentialSecretNotFoundError{
+			return nil, &credentialSecretNotFoundError{
 				msg: fmt.Sprintf("credentials secret %s/%s not found", secretNamespace, secretName),
 			}
 		}
-		return fmt.Errorf("failed to get credentials secret %s/%s: %w",
+		return nil, fmt.Errorf("failed to get credentials secret %s/%s: %w",
 			secretNamespace, secretName, err)
 	}
 
-	// Username (required for all credential types)
-	username, ok := secret.Data["username"]
-	if !ok {
-		return &credentialConfigError{msg: fmt.Sprintf("credentials secret %s missing 'username' key", secretName)}
-	}
-
-	// Driver type (CRD defaults to "generic-switch", but defend against empty)
-	driverType := sw.Spec.Driver
-	if driverType == "" {
-		driverType = "generic-switch"
+	credentials := switchdriver.Credentials(secret.Data)
+	if err := driver.ValidateCredentials(credentials); err != nil {
+		return nil, &credentialConfigError{msg: fmt.Sprintf("credentials secret %s %s", secretName, err)}
 	}
-
-	data := switchConfigData{
-		Name:       sw.Name,
-		Address:    sw.Spec.Address,
-		MACAddress: sw.Spec.MACAddress,
-		Port:       sw.Spec.Port,
-		Driver:     driverType,
-		DeviceType: sw.Spec.DeviceType,
-		Insecure:   sw.Spec.DisableCertificateVerification,
-		Username:   string(username),
-	}
-
-	// Infer auth type from secret keys
-	privateKey, hasPrivateKey := secret.Data["ssh-privatekey"]
-	password, hasPassword := secret.Data["password"]
-
-	switch {
-	case hasPrivateKey:
-		// Store the private key in the key files map, keyed by MAC address.
-		// Replace colons with dashes because colons are not valid in
-		// Kubernetes secret data keys.
-		keyFileName := strings.ReplaceAll(sw.Spec.MACAddress, ":", "-") + ".key"
-		keyFiles[keyFileName] = privateKey
-
-		data.KeyFile = filepath.Join(credentialsPath, keyFileName)
-	case hasPassword:
-		data.Password = string(password)
-	default:
-		return &credentialConfigError{msg: fmt.Sprintf("credentials secret %s missing 'password' or 'ssh-privatekey' key", secretName)}
-	}
-
-	if adminPassword, ok := secret.Data["admin-password"]; ok {
-		data.AdminPassword = string(adminPassword)
-	}
-
-	var buf bytes.Buffer
-	if err := switchConfigTemplate.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to render switch config template for %s: %w", sw.Name, err)
-	}
-
-	configEntries[sw.Name] = buf.Bytes()
-	return nil
+	return credentials, nil
 }
 
 // updateSwitchConfigSecret generates switch configuration from BareMetalSwitch CRDs
@@ -905,6 +905,28 @@ func TestReconcileConditions(t *testing.T) {
 			expectReason:  "CredentialError",
 			expectMessage: "missing 'password' or 'ssh-privatekey' key",
 		},
+		{
+			name: "sets Reconciled=False when the driver is unknown",
+			switch_: &metal3api.BareMetalSwitch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unknown-driver-switch",
+					Namespace: "test-ns",
+				},
+				Spec: metal3api.BareMetalSwitchSpec{
+					Address:    "192.168.1.1",
+					MACAddress: "00:00:5e:00:53:01",
+					Driver:     "no-such-driver",
+					DeviceType: "cisco_ios",
+					Credentials: &corev1.SecretReference{
+						Name: "good-creds",
+					},
+				},
+			},
+			expectRequeue: false,
+			expectStatus:  metav1.ConditionFalse,
+			expectReason:  "DriverError",
+			expectMessage: "unknown switch driver 'no-such-driver'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -56,6 +56,11 @@ type BareMetalSwitchReconciler struct {
 	// is mounted in the ironic-networking pod (from IRONIC_SWITCH_CREDENTIALS_PATH).
 	// Used to construct key_file= paths in the switch config INI.
 	SwitchCredentialPath string
+
+	// ProbeInterval is the interval at which each switch is probed for
+	// reachability and authentication (from IRONIC_SWITCH_PROBE_INTERVAL).
+	// Probing is disabled when zero.
+	ProbeInterval time.Duration
 }
 
 //+kubebuilder:rbac:groups=metal3.io,resources=baremetalswitches,verbs=get;list;watch
@@ -76,6 +81,7 @@ func (r *BareMetalSwitchReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		if k8serrors.IsNotFound(err) {
 			// Resource deleted - regenerate config without this switch
 			logger.Info("BareMetalSwitch deleted, updating switch config")
+			deleteSwitchProbeMetrics(req.Namespace, req.Name)
 			if _, updateErr := updateSwitchConfigSecret(ctx, r.Client, sm, req.Namespace, r.SwitchConfigsSecretName, r.SwitchCredentialSecretName, r.SwitchCredentialPath, logger); updateErr != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to update switch config after deletion: %w", updateErr)
 			}
@@ -92,11 +98,15 @@ func (r *BareMetalSwitchReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	// Set the Reconciled condition based on whether this switch had a credential error
 	if credErr, hasErr := result.credentialErrors[bmSwitch.Name]; hasErr {
+		reason := "CredentialError"
+		if errors.As(credErr, new(*switchDriverError)) {
+			reason = "DriverError"
+		}
 		if meta.SetStatusCondition(&bmSwitch.Status.Conditions, metav1.Condition{
 			Type:               string(metal3api.SwitchConditionValid),
 			Status:             metav1.ConditionFalse,
 			ObservedGeneration: bmSwitch.Generation,
-			Reason:             "CredentialError",
+			Reason:             reason,
 			Message:            credErr.Error(),
 		}) {
 			if statusErr := r.Status().Update(ctx, bmSwitch); statusErr != nil {
@@ -109,26 +119,45 @@ func (r *BareMetalSwitchReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			logger.Info("BareMetalSwitch credential secret missing, requeueing", "error", credErr)
 			return ctrl.Result{RequeueAfter: credentialErrorRequeueDelay}, nil
 		}
-		// The secret exists but is misconfigured. The Owns() watch will
-		// trigger a reconcile when the user fixes it, so no requeue needed.
+		// The secret exists but is misconfigured, or the driver is not
+		// supported. Fixing either triggers a reconcile through the watches,
+		// so no requeue needed.
 		logger.Info("BareMetalSwitch has credential error", "error", credErr)
 		return ctrl.Result{}, nil
 	}
 
-	if meta.SetStatusCondition(&bmSwitch.Status.Conditions, metav1.Condition{
+	statusChanged := meta.SetStatusCondition(&bmSwitch.Status.Conditions, metav1.Condition{
 		Type:               string(metal3api.SwitchConditionValid),
 		Status:             metav1.ConditionTrue,
 		ObservedGeneration: bmSwitch.Generation,
 		Reason:             "Reconciled",
 		Message:            "Switch configuration has been successfully reconciled into the config secret",
-	}) {
+	})
+
+	var reconcileResult ctrl.Result
+	if r.ProbeInterval > 0 {
+		delay := r.nextProbeDelay(bmSwitch)
+		if delay == 0 {
+			if err := r.probe(ctx, sm, bmSwitch); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to probe BareMetalSwitch: %w", err)
+			}
+			logger.Info("BareMetalSwitch probed",
+				"reachable", meta.IsStatusConditionTrue(bmSwitch.Status.Conditions, string(metal3api.SwitchConditionReachable)),
+				"authenticated", meta.IsStatusConditionTrue(bmSwitch.Status.Conditions, string(metal3api.SwitchConditionAuthenticated)))
+			statusChanged = true
+			delay = r.ProbeInterval
+		}
+		reconcileResult.RequeueAfter = delay
+	}
+
+	if statusChanged {
 		if err := r.Status().Update(ctx, bmSwitch); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to update BareMetalSwitch status: %w", err)
 		}
 	}
 
 	logger.Info("BareMetalSwitch reconciled")
-	return ctrl.Result{}, nil
+	return reconcileResult, nil
 }
 
 // SetupWithManager registers the reconciler to be run by the manager.
@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/switchdriver"
+	"github.com/metal3-io/baremetal-operator/pkg/secretutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ssh"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// switchProbeTimeout bounds the connection, SSH handshake and
+// authentication of a single probe.
+const switchProbeTimeout = 10 * time.Second
+
+const (
+	probeResultSuccess         = "success"
+	probeResultUnreachable     = "unreachable"
+	probeResultUnauthenticated = "unauthenticated"
+)
+
+// switchProbeResult is the outcome of probing the management interface of
+// a switch.
+type switchProbeResult struct {
+	// reachable is set when the TCP connection and the SSH key exchange
+	// succeeded.
+	reachable bool
+	// authenticated is set when the switch accepted the credentials.
+	authenticated bool
+	// err describes why the probe failed.
+	err error
+}
+
+func (r switchProbeResult) metricLabel() string {
+	switch {
+	case !r.reachable:
+		return probeResultUnreachable
+	case !r.authenticated:
+		return probeResultUnauthenticated
+	default:
+		return probeResultSuccess
+	}
+}
+
+// sshAuthMethods returns the SSH authentication methods for the switch
+// credentials. As in the generated configuration, a private key takes
+// precedence over a password.
+func sshAuthMethods(credentials switchdriver.Credentials) ([]ssh.AuthMethod, error) {
+	if privateKey, ok := credentials["ssh-privatekey"]; ok {
+		signer, err := ssh.ParsePrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh-privatekey: %w", err)
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	}
+
+	password := string(credentials["password"])
+	return []ssh.AuthMethod{
+		ssh.Password(password),
+		// Many network operating systems only offer keyboard-interactive
+		// authentication for passwords.
+		ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+			answers := make([]string, len(questions))
+			for i := range answers {
+				answers[i] = password
+			}
+			return answers, nil
+		}),
+	}, nil
+}
+
+// probeSwitch connects to the management port of a switch and
+// authenticates over SSH with the given credentials. The connection is
+// closed as soon as authentication succeeds, no session is opened.
+func probeSwitch(ctx context.Context, address string, port int32, credentials switchdriver.Credentials) switchProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, switchProbeTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(address, strconv.Itoa(int(port)))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return switchProbeResult{err: err}
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return switchProbeResult{err: err}
+		}
+	}
+
+	// An unusable private key is still reported after the handshake, so
+	// that reachability is known independently of the credentials.
+	auth, authErr := sshAuthMethods(credentials)
+
+	// The callback runs on the goroutine performing the key exchange.
+	var keyExchanged atomic.Bool
+	config := &ssh.ClientConfig{
+		User: string(credentials["username"]),
+		Auth: auth,
+		// The host key of the switch is not known to the operator. The
+		// callback only records that the key exchange completed, which
+		// distinguishes an SSH server rejecting the credentials from an
+		// endpoint that does not speak SSH at all.
+		HostKeyCallback: func(string, net.Addr, ssh.PublicKey) error {
+			keyExchanged.Store(true)
+			return nil
+		},
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		if authErr != nil {
+			err = authErr
+		}
+		return switchProbeResult{reachable: keyExchanged.Load(), err: err}
+	}
+	ssh.NewClient(sshConn, chans, reqs).Close()
+	if authErr != nil {
+		return switchProbeResult{reachable: true, err: authErr}
+	}
+	return switchProbeResult{reachable: true, authenticated: true}
+}
+
+// nextProbeDelay returns how long to wait before probing the switch again.
+// A switch that was never probed, or whose spec changed since the last
+// probe, is probed immediately.
+func (r *BareMetalSwitchReconciler) nextProbeDelay(bmSwitch *metal3api.BareMetalSwitch) time.Duration {
+	if bmSwitch.Status.LastProbeTime == nil {
+		return 0
+	}
+	cond := meta.FindStatusCondition(bmSwitch.Status.Conditions, string(metal3api.SwitchConditionReachable))
+	if cond == nil || cond.ObservedGeneration != bmSwitch.Generation {
+		return 0
+	}
+	return max(0, r.ProbeInterval-time.Since(bmSwitch.Status.LastProbeTime.Time))
+}
+
+// setSwitchProbeStatus records the result of a probe in the Reachable and
+// Authenticated conditions and the probe timestamps.
+func setSwitchProbeStatus(bmSwitch *metal3api.BareMetalSwitch, result switchProbeResult, now metav1.Time) {
+	reachable := metav1.Condition{
+		Type:               string(metal3api.SwitchConditionReachable),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: bmSwitch.Generation,
+		Reason:             "Connected",
+		Message:            "SSH handshake with the switch succeeded",
+	}
+	authenticated := metav1.Condition{
+		Type:               string(metal3api.SwitchConditionAuthenticated),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: bmSwitch.Generation,
+		Reason:             "Authenticated",
+		Message:            "The switch accepted the credentials",
+	}
+
+	switch {
+	case !result.reachable:
+		reachable.Status = metav1.ConditionFalse
+		reachable.Reason = "ConnectionFailed"
+		reachable.Message = result.err.Error()
+		authenticated.Status = metav1.ConditionUnknown
+		authenticated.Reason = "Unreachable"
+		authenticated.Message = "The switch could not be reached"
+	case !result.authenticated:
+		authenticated.Status = metav1.ConditionFalse
+		authenticated.Reason = "AuthenticationFailed"
+		authenticated.Message = result.err.Error()
+	default:
+		bmSwitch.Status.LastSuccessfulProbeTime = &now
+	}
+
+	meta.SetStatusCondition(&bmSwitch.Status.Conditions, reachable)
+	meta.SetStatusCondition(&bmSwitch.Status.Conditions, authenticated)
+	bmSwitch.Status.LastProbeTime = &now
+}
+
+// probe checks the reachability of the switch and its credentials and
+// updates the switch status with the result.
+func (r *BareMetalSwitchReconciler) probe(ctx context.Context, sm secretutils.SecretManager, bmSwitch *metal3api.BareMetalSwitch) error {
+	driver, err := getSwitchDriver(bmSwitch)
+	if err != nil {
+		return err
+	}
+	credentials, err := getSwitchCredentials(ctx, sm, bmSwitch, driver)
+	if err != nil {
+		return err
+	}
+	port := driver.DefaultPort()
+	if bmSwitch.Spec.Port != nil {
+		port = *bmSwitch.Spec.Port
+	}
+
+	start := time.Now()
+	result := probeSwitch(ctx, bmSwitch.Spec.Address, port, credentials)
+	switchProbeDuration.With(prometheus.Labels{
+		labelHostNamespace: bmSwitch.Namespace,
+		labelSwitchName:    bmSwitch.Name,
+	}).Observe(time.Since(start).Seconds())
+	switchProbeCounters.With(prometheus.Labels{
+		labelHostNamespace: bmSwitch.Namespace,
+		labelSwitchName:    bmSwitch.Name,
+		labelProbeResult:   result.metricLabel(),
+	}).Inc()
+
+	setSwitchProbeStatus(bmSwitch, result, metav1.Now())
+	return nil
+}
+
+// deleteSwitchProbeMetrics removes the probe metrics of a deleted switch, so
+// that its series are not exported forever.
+func deleteSwitchProbeMetrics(namespace, name string) {
+	switchProbeDuration.DeleteLabelValues(namespace, name)
+	for _, result := range []string{probeResultSuccess, probeResultUnreachable, probeResultUnauthenticated} {
+		switchProbeCounters.DeleteLabelValues(namespace, name, result)
+	}
+}
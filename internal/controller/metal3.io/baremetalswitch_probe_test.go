@@ -0,0 +1,488 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/switchdriver"
+	. "github.com/onsi/gomega"
+	promutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testSSHUsername = "admin"
+	testSSHPassword = "secret"
+)
+
+// newTestPrivateKey returns a PEM-encoded SSH private key and its public key.
+func newTestPrivateKey(t *testing.T) ([]byte, ssh.PublicKey) {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(block), signer.PublicKey()
+}
+
+// listen opens a TCP listener on a random local port that is closed at the
+// end of the test.
+func listen(t *testing.T) (net.Listener, int32) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	return listener, int32(listener.Addr().(*net.TCPAddr).Port)
+}
+
+// startTestSSHServer runs an SSH server on a random local port that
+// accepts testSSHUsername with either testSSHPassword or authorizedKey.
+// It returns the port of the server.
+func startTestSSHServer(t *testing.T, authorizedKey ssh.PublicKey) int32 {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == testSSHUsername && string(password) == testSSHPassword {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, errors.New("invalid password")
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == testSSHUsername && bytes.Equal(key.Marshal(), authorizedKey.Marshal()) {
+				return &ssh.Permissions{}, nil
+			}
+			return nil, errors.New("unauthorized key")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, port := listen(t)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for newChannel := range chans {
+					_ = newChannel.Reject(ssh.Prohibited, "no sessions")
+				}
+			}()
+		}
+	}()
+	return port
+}
+
+// startTestHTTPServer runs a server on a random local port that answers
+// every connection with an HTTP response instead of an SSH banner.
+func startTestHTTPServer(t *testing.T) int32 {
+	t.Helper()
+	listener, port := listen(t)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			conn.Close()
+		}
+	}()
+	return port
+}
+
+func TestProbeSwitch(t *testing.T) {
+	privateKey, publicKey := newTestPrivateKey(t)
+	otherKey, _ := newTestPrivateKey(t)
+	sshPort := startTestSSHServer(t, publicKey)
+	httpPort := startTestHTTPServer(t)
+	closedListener, closedPort := listen(t)
+	closedListener.Close()
+
+	tests := []struct {
+		name                string
+		port                int32
+		credentials         switchdriver.Credentials
+		expectReachable     bool
+		expectAuthenticated bool
+		expectError         string
+	}{
+		{
+			name: "password accepted",
+			port: sshPort,
+			credentials: switchdriver.Credentials{
+				"username": []byte(testSSHUsername),
+				"password": []byte(testSSHPassword),
+			},
+			expectReachable:     true,
+			expectAuthenticated: true,
+		},
+		{
+			name: "private key accepted",
+			port: sshPort,
+			credentials: switchdriver.Credentials{
+				"username":       []byte(testSSHUsername),
+				"ssh-privatekey": privateKey,
+			},
+			expectReachable:     true,
+			expectAuthenticated: true,
+		},
+		{
+			name: "wrong password",
+			port: sshPort,
+			credentials: switchdriver.Credentials{
+				"username": []byte(testSSHUsername),
+				"password": []byte("wrong"),
+			},
+			expectReachable: true,
+			expectError:     "unable to authenticate",
+		},
+		{
+			name: "unauthorized private key",
+			port: sshPort,
+			credentials: switchdriver.Credentials{
+				"username":       []byte(testSSHUsername),
+				"ssh-privatekey": otherKey,
+			},
+			expectReachable: true,
+			expectError:     "unable to authenticate",
+		},
+		{
+			name: "invalid private key",
+			port: sshPort,
+			credentials: switchdriver.Credentials{
+				"username":       []byte(testSSHUsername),
+				"ssh-privatekey": []byte("not a key"),
+			},
+			expectReachable: true,
+			expectError:     "failed to parse ssh-privatekey",
+		},
+		{
+			name: "not an SSH server",
+			port: httpPort,
+			credentials: switchdriver.Credentials{
+				"username": []byte(testSSHUsername),
+				"password": []byte(testSSHPassword),
+			},
+			expectError: "ssh: handshake failed",
+		},
+		{
+			name: "connection refused",
+			port: closedPort,
+			credentials: switchdriver.Credentials{
+				"username": []byte(testSSHUsername),
+				"password": []byte(testSSHPassword),
+			},
+			expectError: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			result := probeSwitch(t.Context(), "127.0.0.1", tt.port, tt.credentials)
+			g.Expect(result.reachable).To(Equal(tt.expectReachable))
+			g.Expect(result.authenticated).To(Equal(tt.expectAuthenticated))
+			if tt.expectError == "" {
+				g.Expect(result.err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(result.err).To(MatchError(ContainSubstring(tt.expectError)))
+			}
+		})
+	}
+}
+
+func TestReconcileProbe(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(metal3api.AddToScheme(scheme)).To(Succeed())
+
+	_, publicKey := newTestPrivateKey(t)
+	sshPort := startTestSSHServer(t, publicKey)
+	closedListener, closedPort := listen(t)
+	closedListener.Close()
+
+	const probeInterval = 5 * time.Minute
+
+	tests := []struct {
+		name                string
+		port                int32
+		password            string
+		lastProbeTime       *metav1.Time
+		expectProbe         bool
+		expectReachable     metav1.ConditionStatus
+		expectAuthenticated metav1.ConditionStatus
+		expectReason        string
+	}{
+		{
+			name:                "first probe succeeds",
+			port:                sshPort,
+			password:            testSSHPassword,
+			expectProbe:         true,
+			expectReachable:     metav1.ConditionTrue,
+			expectAuthenticated: metav1.ConditionTrue,
+			expectReason:        "Authenticated",
+		},
+		{
+			name:                "credentials rejected",
+			port:                sshPort,
+			password:            "wrong",
+			expectProbe:         true,
+			expectReachable:     metav1.ConditionTrue,
+			expectAuthenticated: metav1.ConditionFalse,
+			expectReason:        "AuthenticationFailed",
+		},
+		{
+			name:                "switch unreachable",
+			port:                closedPort,
+			password:            testSSHPassword,
+			expectProbe:         true,
+			expectReachable:     metav1.ConditionFalse,
+			expectAuthenticated: metav1.ConditionUnknown,
+			expectReason:        "Unreachable",
+		},
+		{
+			name:                "probe due again",
+			port:                sshPort,
+			password:            testSSHPassword,
+			lastProbeTime:       ptr.To(metav1.NewTime(time.Now().Add(-2 * probeInterval))),
+			expectProbe:         true,
+			expectReachable:     metav1.ConditionTrue,
+			expectAuthenticated: metav1.ConditionTrue,
+			expectReason:        "Authenticated",
+		},
+		{
+			name:                "probe not due yet",
+			port:                sshPort,
+			password:            "wrong",
+			lastProbeTime:       ptr.To(metav1.NewTime(time.Now().Add(-time.Minute))),
+			expectReachable:     metav1.ConditionTrue,
+			expectAuthenticated: metav1.ConditionTrue,
+			expectReason:        "Authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			bmSwitch := &metal3api.BareMetalSwitch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "probed-switch",
+					Namespace: "test-ns",
+				},
+				Spec: metal3api.BareMetalSwitchSpec{
+					Address:    "127.0.0.1",
+					MACAddress: "00:00:5e:00:53:01",
+					DeviceType: "cisco_ios",
+					Port:       ptr.To(tt.port),
+					Credentials: &corev1.SecretReference{
+						Name: "probe-creds",
+					},
+				},
+			}
+			if tt.lastProbeTime != nil {
+				bmSwitch.Status.LastProbeTime = tt.lastProbeTime
+				bmSwitch.Status.LastSuccessfulProbeTime = tt.lastProbeTime
+				setSwitchProbeStatus(bmSwitch, switchProbeResult{reachable: true, authenticated: true}, *tt.lastProbeTime)
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(
+					bmSwitch,
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "probe-creds",
+							Namespace: "test-ns",
+						},
+						Data: map[string][]byte{
+							"username": []byte(testSSHUsername),
+							"password": []byte(tt.password),
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      testSwitchConfigsSecretName,
+							Namespace: "test-ns",
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      testSwitchCredentialSecretName,
+							Namespace: "test-ns",
+						},
+					},
+				).
+				WithStatusSubresource(&metal3api.BareMetalSwitch{}).
+				Build()
+
+			r := &BareMetalSwitchReconciler{
+				Client:                     c,
+				Log:                        logr.Discard(),
+				APIReader:                  c,
+				SwitchConfigsSecretName:    testSwitchConfigsSecretName,
+				SwitchCredentialSecretName: testSwitchCredentialSecretName,
+				SwitchCredentialPath:       testSwitchCredentialPath,
+				ProbeInterval:              probeInterval,
+			}
+
+			result, err := r.Reconcile(t.Context(), ctrl.Request{
+				NamespacedName: client.ObjectKeyFromObject(bmSwitch),
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			updatedSwitch := &metal3api.BareMetalSwitch{}
+			g.Expect(c.Get(t.Context(), client.ObjectKeyFromObject(bmSwitch), updatedSwitch)).To(Succeed())
+
+			g.Expect(updatedSwitch.Status.LastProbeTime).ToNot(BeNil())
+			if tt.expectProbe {
+				g.Expect(result.RequeueAfter).To(Equal(probeInterval))
+				g.Expect(updatedSwitch.Status.LastProbeTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			} else {
+				g.Expect(result.RequeueAfter).To(BeNumerically("~", probeInterval-time.Minute, time.Minute))
+				g.Expect(updatedSwitch.Status.LastProbeTime.Time).To(BeTemporally("~", tt.lastProbeTime.Time, time.Second))
+			}
+
+			reachable := meta.FindStatusCondition(updatedSwitch.Status.Conditions, string(metal3api.SwitchConditionReachable))
+			g.Expect(reachable).ToNot(BeNil())
+			g.Expect(reachable.Status).To(Equal(tt.expectReachable))
+			authenticated := meta.FindStatusCondition(updatedSwitch.Status.Conditions, string(metal3api.SwitchConditionAuthenticated))
+			g.Expect(authenticated).ToNot(BeNil())
+			g.Expect(authenticated.Status).To(Equal(tt.expectAuthenticated))
+			g.Expect(authenticated.Reason).To(Equal(tt.expectReason))
+
+			if tt.expectAuthenticated == metav1.ConditionTrue {
+				g.Expect(updatedSwitch.Status.LastSuccessfulProbeTime).ToNot(BeNil())
+			} else {
+				g.Expect(updatedSwitch.Status.LastSuccessfulProbeTime).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestDeletedSwitchProbeMetrics(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(metal3api.AddToScheme(scheme)).To(Succeed())
+
+	closedListener, closedPort := listen(t)
+	closedListener.Close()
+
+	bmSwitch := &metal3api.BareMetalSwitch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deleted-switch",
+			Namespace: "test-ns",
+		},
+		Spec: metal3api.BareMetalSwitchSpec{
+			Address:    "127.0.0.1",
+			MACAddress: "00:00:5e:00:53:02",
+			DeviceType: "cisco_ios",
+			Port:       ptr.To(closedPort),
+			Credentials: &corev1.SecretReference{
+				Name: "probe-creds",
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			bmSwitch,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "probe-creds", Namespace: "test-ns"},
+				Data: map[string][]byte{
+					"username": []byte(testSSHUsername),
+					"password": []byte(testSSHPassword),
+				},
+			},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testSwitchConfigsSecretName, Namespace: "test-ns"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testSwitchCredentialSecretName, Namespace: "test-ns"}},
+		).
+		WithStatusSubresource(&metal3api.BareMetalSwitch{}).
+		Build()
+
+	r := &BareMetalSwitchReconciler{
+		Client:                     c,
+		Log:                        logr.Discard(),
+		APIReader:                  c,
+		SwitchConfigsSecretName:    testSwitchConfigsSecretName,
+		SwitchCredentialSecretName: testSwitchCredentialSecretName,
+		SwitchCredentialPath:       testSwitchCredentialPath,
+		ProbeInterval:              time.Minute,
+	}
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(bmSwitch)}
+
+	countersBefore := promutil.CollectAndCount(switchProbeCounters)
+	durationsBefore := promutil.CollectAndCount(switchProbeDuration)
+	_, err := r.Reconcile(t.Context(), request)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(promutil.CollectAndCount(switchProbeCounters)).To(Equal(countersBefore + 1))
+	g.Expect(promutil.CollectAndCount(switchProbeDuration)).To(Equal(durationsBefore + 1))
+
+	g.Expect(c.Delete(t.Context(), bmSwitch)).To(Succeed())
+	_, err = r.Reconcile(t.Context(), request)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(promutil.CollectAndCount(switchProbeCounters)).To(Equal(countersBefore))
+	g.Expect(promutil.CollectAndCount(switchProbeDuration)).To(Equal(durationsBefore))
+}
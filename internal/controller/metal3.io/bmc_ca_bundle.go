@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// bmcCABundle returns the CA certificates the certificate of the BMC of the
+// host is verified with, read from the ConfigMap or Secret referenced in its
+// spec. It returns nil when the host does not reference a CA bundle.
+func (r *BareMetalHostReconciler) bmcCABundle(ctx context.Context, host *metal3api.BareMetalHost) ([]byte, error) {
+	ref := host.Spec.BMC.CABundle
+	if ref == nil {
+		return nil, nil
+	}
+	dataKey := ref.Key
+	if dataKey == "" {
+		dataKey = metal3api.DefaultBMCCABundleKey
+	}
+
+	switch {
+	case ref.SecretName != "":
+		key := types.NamespacedName{Namespace: host.Namespace, Name: ref.SecretName}
+		// The secret is not acquired, as it is usually shared by several
+		// hosts and must not be deleted with any of them.
+		secretManager := r.secretManager(ctx, r.Log)
+		secret, err := secretManager.ObtainSecret(ctx, key)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, &BMCCABundleError{message: fmt.Sprintf("secret %s does not exist", key)}
+			}
+			return nil, err
+		}
+		if data := secret.Data[dataKey]; len(data) > 0 {
+			return data, nil
+		}
+		return nil, &BMCCABundleError{message: fmt.Sprintf("secret %s does not contain key %s", key, dataKey)}
+
+	case ref.ConfigMapName != "":
+		key := types.NamespacedName{Namespace: host.Namespace, Name: ref.ConfigMapName}
+		configMap := &corev1.ConfigMap{}
+		if err := r.APIReader.Get(ctx, key, configMap); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, &BMCCABundleError{message: fmt.Sprintf("config map %s does not exist", key)}
+			}
+			return nil, err
+		}
+		if data, found := configMap.Data[dataKey]; found && data != "" {
+			return []byte(data), nil
+		}
+		if data := configMap.BinaryData[dataKey]; len(data) > 0 {
+			return data, nil
+		}
+		return nil, &BMCCABundleError{message: fmt.Sprintf("config map %s does not contain key %s", key, dataKey)}
+
+	default:
+		return nil, &BMCCABundleError{message: "neither a config map nor a secret is referenced"}
+	}
+}
+
+// caBundleToHosts maps a ConfigMap or a Secret to the hosts of its namespace
+// using it as the CA bundle of their BMC, so that they pick up changes to the
+// certificates.
+func (r *BareMetalHostReconciler) caBundleToHosts(ctx context.Context, obj client.Object) []reconcile.Request {
+	hosts := &metal3api.BareMetalHostList{}
+	if err := r.List(ctx, hosts, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list BareMetalHosts", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	_, isSecret := obj.(*corev1.Secret)
+	var requests []reconcile.Request
+	for i := range hosts.Items {
+		ref := hosts.Items[i].Spec.BMC.CABundle
+		if ref == nil {
+			continue
+		}
+		name := ref.ConfigMapName
+		if isSecret {
+			name = ref.SecretName
+		}
+		if name != obj.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: hosts.Items[i].Namespace,
+				Name:      hosts.Items[i].Name,
+			},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestBMCCABundle(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-ca", Namespace: namespace},
+		Data:       map[string]string{metal3api.DefaultBMCCABundleKey: "config map bundle"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-ca", Namespace: namespace},
+		Data:       map[string][]byte{"bundle.pem": []byte("secret bundle")},
+	}
+
+	for _, tc := range []struct {
+		Scenario      string
+		Ref           *metal3api.BMCCABundle
+		Expected      string
+		ExpectedError bool
+	}{
+		{
+			Scenario: "no bundle",
+		},
+		{
+			Scenario: "config map",
+			Ref:      &metal3api.BMCCABundle{ConfigMapName: "bmc-ca"},
+			Expected: "config map bundle",
+		},
+		{
+			Scenario: "secret",
+			Ref:      &metal3api.BMCCABundle{SecretName: "bmc-ca", Key: "bundle.pem"},
+			Expected: "secret bundle",
+		},
+		{
+			Scenario:      "missing key",
+			Ref:           &metal3api.BMCCABundle{SecretName: "bmc-ca"},
+			ExpectedError: true,
+		},
+		{
+			Scenario:      "missing config map",
+			Ref:           &metal3api.BMCCABundle{ConfigMapName: "other-ca"},
+			ExpectedError: true,
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := newDefaultHost(t)
+			host.Spec.BMC.CABundle = tc.Ref
+			r := newTestReconciler(t, host, configMap, secret)
+
+			bundle, err := r.bmcCABundle(t.Context(), host)
+			if tc.ExpectedError {
+				var bundleErr *BMCCABundleError
+				require.ErrorAs(t, err, &bundleErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(bundle))
+		})
+	}
+}
+
+func TestMissingBMCCABundle(t *testing.T) {
+	host := newDefaultHost(t)
+	host.Spec.BMC.CABundle = &metal3api.BMCCABundle{ConfigMapName: "bmc-ca"}
+	r := newTestReconciler(t, host)
+
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.ErrorType != ""
+	})
+	assert.Equal(t, metal3api.RegistrationError, host.Status.ErrorType)
+	assert.Contains(t, host.Status.ErrorMessage, "config map test-namespace/bmc-ca does not exist")
+}
+
+func TestCABundleToHosts(t *testing.T) {
+	configMapHost := newHost("config-map-host", &metal3api.BareMetalHostSpec{})
+	configMapHost.Spec.BMC.CABundle = &metal3api.BMCCABundle{ConfigMapName: "bmc-ca"}
+	secretHost := newHost("secret-host", &metal3api.BareMetalHostSpec{})
+	secretHost.Spec.BMC.CABundle = &metal3api.BMCCABundle{SecretName: "bmc-ca"}
+	otherHost := newHost("other-host", &metal3api.BareMetalHostSpec{})
+	r := newTestReconciler(t, configMapHost, secretHost, otherHost)
+
+	configMap := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-ca", Namespace: namespace},
+	}
+	requests := r.caBundleToHosts(t.Context(), configMap)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "config-map-host", requests[0].Name)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-ca", Namespace: namespace},
+	}
+	requests = r.caBundleToHosts(t.Context(), secret)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "secret-host", requests[0].Name)
+
+	secret.Namespace = "other-namespace"
+	assert.Empty(t, r.caBundleToHosts(t.Context(), secret))
+}
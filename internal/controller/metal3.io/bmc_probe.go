@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// needsBMCProbe returns whether the BMC of the host should be probed before
+// registering it: the host has never been registered, or its credentials
+// just changed while registering.
+func needsBMCProbe(host *metal3api.BareMetalHost, credsChanged bool) bool {
+	if host.Status.Provisioning.State != metal3api.StateRegistering {
+		return false
+	}
+	return credsChanged || host.Status.Provisioning.ID == ""
+}
+
+// probeBMC contacts the BMC of the host directly, when its type allows it,
+// to check the address and credentials before the provisioner does. It
+// returns nil when the BMC passed the probe or cannot be probed.
+func (r *BareMetalHostReconciler) probeBMC(ctx context.Context, info *reconcileInfo) actionResult {
+	accessDetails, err := bmc.NewAccessDetails(info.host.Spec.BMC.Address, info.host.Spec.BMC.DisableCertificateVerification)
+	if err != nil {
+		// Already reported when building the credentials.
+		return nil
+	}
+	prober, ok := accessDetails.(bmc.Prober)
+	if !ok {
+		return nil
+	}
+	// Verify the certificate of the BMC like the provisioner does.
+	if len(info.bmcCABundle) > 0 || info.host.Spec.BMC.CertificateFingerprint != "" {
+		err = bmc.ConfigureTLS(accessDetails, bmc.TLSOptions{
+			CABundle:               info.bmcCABundle,
+			CertificateFingerprint: info.host.Spec.BMC.CertificateFingerprint,
+		})
+		if err != nil {
+			// Reported by the provisioner when registering.
+			return nil
+		}
+	}
+
+	systemPath, err := prober.Probe(ctx, info.bmcCreds)
+	result := probeResultSuccess
+	if probeErr := (&bmc.ProbeError{}); errors.As(err, &probeErr) {
+		result = string(probeErr.Failure)
+	}
+	bmcProbeCounters.With(prometheus.Labels{labelProbeResult: result}).Inc()
+
+	if err != nil {
+		info.log.Info("BMC probe failed", "error", err.Error())
+		conditions.Set(info.host, metav1.Condition{
+			Type:    metal3api.ManageableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  metal3api.BMCProbeFailedReason,
+			Message: err.Error(),
+		})
+		return recordActionFailure(info, metal3api.RegistrationError, err.Error())
+	}
+
+	info.log.Info("BMC probe succeeded", "system", systemPath)
+	if hasBMCProbeFailure(info.host) {
+		// Let a registration failure that follows be reported as such.
+		setConditionFalse(info.host, metal3api.ManageableCondition, metal3api.RegisteringReason)
+	}
+	return nil
+}
+
+// hasBMCProbeFailure returns whether the registration error of the host
+// comes from a failed BMC probe.
+func hasBMCProbeFailure(host *metal3api.BareMetalHost) bool {
+	if host.Status.ErrorType != metal3api.RegistrationError {
+		return false
+	}
+	cond := meta.FindStatusCondition(host.Status.Conditions, metal3api.ManageableCondition)
+	return cond != nil && cond.Reason == metal3api.BMCProbeFailedReason
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// newRedfishBMC serves a Redfish service with a single system, rejecting
+// the credentials unless authorized is set.
+func newRedfishBMC(t *testing.T, authorized *atomic.Bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resource any
+		switch r.URL.Path {
+		case "/redfish/v1/":
+			resource = map[string]any{"Systems": map[string]string{"@odata.id": "/redfish/v1/Systems"}}
+		case "/redfish/v1/Systems":
+			resource = map[string]any{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path != "/redfish/v1/" && !authorized.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resource)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBMCProbe(t *testing.T) {
+	var authorized atomic.Bool
+	server := newRedfishBMC(t, &authorized)
+
+	host := newDefaultHost(t)
+	host.Spec.BMC.Address = "redfish+" + server.URL + "/redfish/v1/"
+	host.Spec.BMC.DisableCertificateVerification = true
+	r := newTestReconciler(t, host)
+	r.ProbeBMC = true
+
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.ErrorType != ""
+	})
+	assert.Equal(t, metal3api.RegistrationError, host.Status.ErrorType)
+	assert.Equal(t, metal3api.StateRegistering, host.Status.Provisioning.State)
+	assert.Contains(t, host.Status.ErrorMessage, "the BMC rejected the credentials")
+	cond := meta.FindStatusCondition(host.Status.Conditions, metal3api.ManageableCondition)
+	require.NotNil(t, cond)
+	assert.Equal(t, metal3api.BMCProbeFailedReason, cond.Reason)
+	assert.Equal(t, host.Status.ErrorMessage, cond.Message)
+
+	authorized.Store(true)
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.Provisioning.State != metal3api.StateRegistering
+	})
+	assert.Empty(t, host.Status.ErrorType)
+	cond = meta.FindStatusCondition(host.Status.Conditions, metal3api.ManageableCondition)
+	require.NotNil(t, cond)
+	assert.NotEqual(t, metal3api.BMCProbeFailedReason, cond.Reason)
+}
+
+func TestBMCProbeCertificateVerification(t *testing.T) {
+	var authorized atomic.Bool
+	authorized.Store(true)
+	server := newRedfishBMC(t, &authorized)
+	// The certificate of the test server is its own CA.
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-ca", Namespace: namespace},
+		Data:       map[string]string{metal3api.DefaultBMCCABundleKey: string(caBundle)},
+	}
+
+	for _, tc := range []struct {
+		Scenario    string
+		CABundle    *metal3api.BMCCABundle
+		Fingerprint string
+	}{
+		{
+			Scenario: "CA bundle",
+			CABundle: &metal3api.BMCCABundle{ConfigMapName: "bmc-ca"},
+		},
+		{
+			Scenario:    "fingerprint",
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := newDefaultHost(t)
+			host.Spec.BMC.Address = "redfish+" + server.URL + "/redfish/v1/"
+			host.Spec.BMC.CABundle = tc.CABundle
+			host.Spec.BMC.CertificateFingerprint = tc.Fingerprint
+			r := newTestReconciler(t, host, configMap)
+			r.ProbeBMC = true
+
+			tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+				switch host.Status.Provisioning.State {
+				case metal3api.StateNone, metal3api.StateRegistering:
+					return host.Status.ErrorType != ""
+				default:
+					return true
+				}
+			})
+			assert.Empty(t, host.Status.ErrorMessage)
+			assert.NotEqual(t, metal3api.StateRegistering, host.Status.Provisioning.State)
+		})
+	}
+}
+
+func TestBMCProbeDisabled(t *testing.T) {
+	var authorized atomic.Bool
+	server := newRedfishBMC(t, &authorized)
+
+	host := newDefaultHost(t)
+	host.Spec.BMC.Address = "redfish+" + server.URL + "/redfish/v1/"
+	host.Spec.BMC.DisableCertificateVerification = true
+	r := newTestReconciler(t, host)
+
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.Provisioning.State != metal3api.StateRegistering
+	})
+	assert.Empty(t, host.Status.ErrorType)
+}
+
+func TestNeedsBMCProbe(t *testing.T) {
+	host := newDefaultHost(t)
+	host.Status.Provisioning.State = metal3api.StateRegistering
+	assert.True(t, needsBMCProbe(host, false))
+
+	host.Status.Provisioning.ID = "node-1"
+	assert.False(t, needsBMCProbe(host, false))
+	assert.True(t, needsBMCProbe(host, true))
+
+	host.Status.Provisioning.State = metal3api.StateProvisioned
+	assert.False(t, needsBMCProbe(host, true))
+}
@@ -108,6 +108,20 @@ func (r *BMCEventSubscriptionReconciler) Reconcile(ctx context.Context, request
 		return ctrl.Result{}, fmt.Errorf("failed to create provisioner: %w", err)
 	}
 
+	if err := provisioner.CheckCapability(ctx, prov, provisioner.CapabilityBMCEventSubscription); err != nil {
+		reqLogger.Info("subscriptions are not supported", "Error", err.Error())
+		if !subscription.DeletionTimestamp.IsZero() {
+			// Nothing can have been subscribed, let the subscription go
+			if controllerutil.RemoveFinalizer(subscription, metal3api.BMCEventSubscriptionFinalizer) {
+				if err := r.Update(ctx, subscription); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+				}
+			}
+			return ctrl.Result{}, nil
+		}
+		return r.handleError(ctx, subscription, err, err.Error(), true)
+	}
+
 	if subscription.DeletionTimestamp.IsZero() {
 		// Not being deleted
 		if err := r.createSubscription(ctx, prov, subscription); err != nil {
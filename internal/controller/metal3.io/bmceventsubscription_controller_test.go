@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -141,6 +142,22 @@ func TestBMCGetProvisioner(t *testing.T) {
 	}
 }
 
+func TestBMCSubscriptionNotSupported(t *testing.T) {
+	host := newDefaultHost(t)
+	subscription := newDefaultSubscription(t)
+	fix := fixture.Fixture{Unsupported: []provisioner.Capability{provisioner.CapabilityBMCEventSubscription}}
+	r := newBMCTestReconcilerWithFixture(t, &fix, subscription, host)
+
+	result, err := r.Reconcile(t.Context(), newBMCRequest(subscription))
+	require.NoError(t, err)
+	require.Equal(t, subscriptionRetryDelay, result.RequeueAfter)
+
+	updated := &metal3api.BMCEventSubscription{}
+	require.NoError(t, r.Get(t.Context(), client.ObjectKeyFromObject(subscription), updated))
+	require.Equal(t, "BMCEventSubscription is not supported by provisioner fixture", updated.Status.Error)
+	require.Empty(t, updated.Status.SubscriptionID)
+}
+
 func TestGetHTTPHeaders(t *testing.T) {
 	// NOTE: This subscription references the defaultSecretName for http headers.
 	// The secret is automatically created by newBMCTestReconciler.
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// generatedPasswordLength is the length of the BMC passwords set by a
+	// rotation. It stays below the 20 characters limit of IPMI 2.0.
+	generatedPasswordLength = 20
+
+	// credentialsRotationRetryDelay is how long a scheduled rotation waits
+	// after a failure before being attempted again.
+	credentialsRotationRetryDelay = time.Hour
+
+	rotationResultFailure = "failure"
+
+	// pendingPasswordKey holds the new password in the credentials secret
+	// while it is being set on the BMC.
+	pendingPasswordKey = "pending-password"
+)
+
+// passwordCharacterClasses are the characters generated passwords are made
+// of, with at least one from each class to meet common BMC password rules.
+// The symbols are limited to those BMC interfaces are known to accept.
+var passwordCharacterClasses = []string{
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"abcdefghijklmnopqrstuvwxyz",
+	"0123456789",
+	"-_.+=#%",
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
+
+// generatePassword returns a random password of the given length with
+// characters from all the classes.
+func generatePassword(length int) (string, error) {
+	all := ""
+	for _, class := range passwordCharacterClasses {
+		all += class
+	}
+
+	password := make([]byte, length)
+	for i := range password {
+		class := all
+		if i < len(passwordCharacterClasses) {
+			class = passwordCharacterClasses[i]
+		}
+		j, err := randomIndex(len(class))
+		if err != nil {
+			return "", err
+		}
+		password[i] = class[j]
+	}
+	// Do not leave the mandatory characters at the start.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// credentialsRotationDue returns whether the BMC password of the host
+// should be rotated now, and whether the rotation is a scheduled one rather
+// than one explicitly requested.
+func credentialsRotationDue(info *reconcileInfo, now time.Time) (due, scheduled bool) {
+	if _, requested := info.host.Annotations[metal3api.RotateCredentialsAnnotation]; requested {
+		return true, false
+	}
+
+	value, found := info.host.Annotations[metal3api.CredentialsRotationIntervalAnnotation]
+	if !found {
+		return false, false
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		info.log.Info("ignoring invalid credentials rotation interval", "interval", value)
+		return false, false
+	}
+
+	if failedAt, err := time.Parse(time.RFC3339, info.host.Annotations[metal3api.CredentialsRotationFailedAnnotation]); err == nil &&
+		now.Before(failedAt.Add(credentialsRotationRetryDelay)) {
+		return false, true
+	}
+
+	lastRotation := info.bmcCredsSecret.CreationTimestamp.Time
+	if rotatedAt, err := time.Parse(time.RFC3339, info.bmcCredsSecret.Annotations[metal3api.CredentialsRotatedAtAnnotation]); err == nil {
+		lastRotation = rotatedAt
+	}
+	return !now.Before(lastRotation.Add(interval)), true
+}
+
+// canRotateCredentials returns whether the host is in a state where its BMC
+// password can be changed without disturbing an operation in progress.
+func canRotateCredentials(info *reconcileInfo) bool {
+	switch info.host.Status.Provisioning.State {
+	case metal3api.StateAvailable, metal3api.StateProvisioned, metal3api.StateExternallyProvisioned:
+	default:
+		return false
+	}
+	return info.host.OperationalStatus() == metal3api.OperationalStatusOK &&
+		info.host.Status.GoodCredentials.Match(*info.bmcCredsSecret)
+}
+
+// rotateCredentials sets a new generated password on the BMC of the host
+// when a rotation is requested or due. The password is saved in the
+// credentials secret as pending before the BMC is changed, so that it is
+// never lost, and promoted once the BMC has it. Updating the secret makes
+// the host register again with the new password. It returns nil when there
+// is nothing to do, including for hosts without BMC credentials.
+func (r *BareMetalHostReconciler) rotateCredentials(ctx context.Context, prov provisioner.Provisioner, info *reconcileInfo) actionResult {
+	if info.bmcCredsSecret == nil {
+		return nil
+	}
+	due, scheduled := credentialsRotationDue(info, time.Now())
+	if !due || !canRotateCredentials(info) {
+		return nil
+	}
+
+	if r.CredentialsProvider != nil {
+		return r.credentialsRotationFailed(ctx, info, scheduled,
+			errors.New("credentials from an external provider cannot be rotated by the operator"))
+	}
+
+	rotator, ok := prov.(provisioner.CredentialsRotator)
+	if !ok {
+		return r.credentialsRotationFailed(ctx, info, scheduled,
+			fmt.Errorf("credentials rotation is %w by the provisioner", provisioner.ErrNotSupported))
+	}
+
+	sharedWith, err := r.hostsSharingCredentials(ctx, info.host)
+	if err != nil {
+		return actionError{fmt.Errorf("failed to list the hosts using the BMC credentials: %w", err)}
+	}
+	if sharedWith != "" {
+		return r.credentialsRotationFailed(ctx, info, scheduled,
+			fmt.Errorf("secret %s is also used by host %s", info.host.Spec.BMC.CredentialsName, sharedWith))
+	}
+
+	// Consume the request before changing anything, so that a failure
+	// past this point cannot make the rotation run twice.
+	if !scheduled {
+		delete(info.host.Annotations, metal3api.RotateCredentialsAnnotation)
+		if err := r.Update(ctx, info.host); err != nil {
+			return actionError{fmt.Errorf("failed to update the host before credentials rotation: %w", err)}
+		}
+	}
+
+	newPassword, err := generatePassword(generatedPasswordLength)
+	if err != nil {
+		return actionError{fmt.Errorf("failed to generate a BMC password: %w", err)}
+	}
+	secret := info.bmcCredsSecret.DeepCopy()
+	secret.Data[pendingPasswordKey] = []byte(newPassword)
+	// The resource version makes the update fail if the secret changed
+	// since it was read.
+	if err := r.Update(ctx, secret); err != nil {
+		return r.credentialsRotationFailed(ctx, info, scheduled, fmt.Errorf("failed to save the new password: %w", err))
+	}
+	info.bmcCredsSecret = secret
+
+	info.log.Info("changing the BMC password")
+	if err := rotator.ChangeBMCPassword(ctx, info.bmcCreds, newPassword); err != nil {
+		// The pending password is kept: the BMC may have it if restoring
+		// the previous one failed. The next reconcile finds out.
+		return r.credentialsRotationFailed(ctx, info, scheduled, err)
+	}
+	return r.promotePendingPassword(ctx, info)
+}
+
+// recoverCredentialsRotation completes or abandons a rotation interrupted
+// after its password was saved as pending, depending on which password the
+// BMC accepts. It runs before the host is registered, as registering with
+// a password the BMC no longer has would fail. It returns nil when there
+// is nothing to do.
+func (r *BareMetalHostReconciler) recoverCredentialsRotation(ctx context.Context, prov provisioner.Provisioner, info *reconcileInfo) actionResult {
+	if info.bmcCredsSecret == nil {
+		return nil
+	}
+	pending, found := info.bmcCredsSecret.Data[pendingPasswordKey]
+	if !found {
+		return nil
+	}
+
+	accepted := false
+	if rotator, ok := prov.(provisioner.CredentialsRotator); ok {
+		var err error
+		accepted, err = rotator.BMCAcceptsCredentials(ctx, bmc.Credentials{Username: info.bmcCreds.Username, Password: string(pending)})
+		// A provisioner that cannot rotate credentials never changed
+		// the password of the BMC.
+		if err != nil && !errors.Is(err, provisioner.ErrNotSupported) {
+			return actionError{fmt.Errorf("failed to check the pending BMC password: %w", err)}
+		}
+	}
+	if accepted {
+		info.log.Info("completing an interrupted BMC credentials rotation")
+		return r.promotePendingPassword(ctx, info)
+	}
+
+	info.log.Info("abandoning an interrupted BMC credentials rotation, the BMC does not have the new password")
+	secret := info.bmcCredsSecret.DeepCopy()
+	delete(secret.Data, pendingPasswordKey)
+	if err := r.Update(ctx, secret); err != nil {
+		return actionError{fmt.Errorf("failed to remove the pending password: %w", err)}
+	}
+	return actionContinue{}
+}
+
+// promotePendingPassword stores the pending password of the credentials
+// secret, which the BMC has, as its password.
+func (r *BareMetalHostReconciler) promotePendingPassword(ctx context.Context, info *reconcileInfo) actionResult {
+	secret := info.bmcCredsSecret.DeepCopy()
+	secret.Data["password"] = secret.Data[pendingPasswordKey]
+	delete(secret.Data, pendingPasswordKey)
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[metal3api.CredentialsRotatedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Update(ctx, secret); err != nil {
+		// The pending password is promoted on the next reconcile.
+		return actionError{fmt.Errorf("failed to store the new password: %w", err)}
+	}
+
+	credentialsRotations.With(prometheus.Labels{labelProbeResult: probeResultSuccess}).Inc()
+	info.publishEvent("CredentialsRotated", "BMC password changed and stored in secret "+secret.Name)
+	if _, failed := info.host.Annotations[metal3api.CredentialsRotationFailedAnnotation]; failed {
+		delete(info.host.Annotations, metal3api.CredentialsRotationFailedAnnotation)
+		if err := r.Update(ctx, info.host); err != nil {
+			return actionError{fmt.Errorf("failed to update the host after credentials rotation: %w", err)}
+		}
+	}
+	return actionContinue{}
+}
+
+// credentialsRotationFailed reports a failed rotation. The request
+// annotation is removed if still there, so that the rotation is not retried
+// in a loop, and scheduled rotations are delayed.
+func (r *BareMetalHostReconciler) credentialsRotationFailed(ctx context.Context, info *reconcileInfo, scheduled bool, err error) actionResult {
+	info.log.Info("BMC credentials rotation failed", "error", err.Error())
+	credentialsRotations.With(prometheus.Labels{labelProbeResult: rotationResultFailure}).Inc()
+	info.publishEvent("CredentialsRotationFailed", err.Error())
+
+	delete(info.host.Annotations, metal3api.RotateCredentialsAnnotation)
+	if scheduled {
+		if info.host.Annotations == nil {
+			info.host.Annotations = make(map[string]string)
+		}
+		info.host.Annotations[metal3api.CredentialsRotationFailedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+	if updateErr := r.Update(ctx, info.host); updateErr != nil {
+		return actionError{fmt.Errorf("failed to update the host after credentials rotation failure: %w", updateErr)}
+	}
+	return actionContinue{}
+}
+
+// hostsSharingCredentials returns the name of another host using the same
+// credentials secret, if any. Changing the password of a shared secret would
+// break the hosts whose BMC was not changed.
+func (r *BareMetalHostReconciler) hostsSharingCredentials(ctx context.Context, host *metal3api.BareMetalHost) (string, error) {
+	hosts := &metal3api.BareMetalHostList{}
+	if err := r.List(ctx, hosts, client.InNamespace(host.Namespace)); err != nil {
+		return "", err
+	}
+	for _, other := range hosts.Items {
+		if other.Name != host.Name && other.Spec.BMC.CredentialsName == host.Spec.BMC.CredentialsName {
+			return other.Name, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getDefaultSecret(t *testing.T, r *BareMetalHostReconciler) *corev1.Secret {
+	t.Helper()
+	secret := &corev1.Secret{}
+	err := r.Get(t.Context(), types.NamespacedName{Namespace: namespace, Name: defaultSecretName}, secret)
+	require.NoError(t, err)
+	return secret
+}
+
+// requestRotation waits for the host to be available and annotates it.
+func requestRotation(t *testing.T, r *BareMetalHostReconciler, host *metal3api.BareMetalHost) {
+	t.Helper()
+	waitForProvisioningState(t, r, host, metal3api.StateAvailable)
+	host.Annotations = map[string]string{metal3api.RotateCredentialsAnnotation: ""}
+	require.NoError(t, r.Update(t.Context(), host))
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		_, requested := host.Annotations[metal3api.RotateCredentialsAnnotation]
+		return !requested
+	})
+}
+
+func TestCredentialsRotation(t *testing.T) {
+	host := newDefaultHost(t)
+	fix := &fixture.Fixture{}
+	r := newTestReconcilerWithFixture(t, fix, host)
+
+	requestRotation(t, r, host)
+
+	secret := getDefaultSecret(t, r)
+	assert.Len(t, fix.BMCPassword, generatedPasswordLength)
+	assert.Equal(t, fix.BMCPassword, string(secret.Data["password"]))
+	assert.Contains(t, secret.Annotations, metal3api.CredentialsRotatedAtAnnotation)
+
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.GoodCredentials.Match(*secret)
+	})
+	assert.Equal(t, metal3api.StateAvailable, host.Status.Provisioning.State)
+}
+
+func TestCredentialsRotationFailure(t *testing.T) {
+	host := newDefaultHost(t)
+	fix := &fixture.Fixture{BMCPasswordChangeError: errors.New("the BMC rejected the password")}
+	r := newTestReconcilerWithFixture(t, fix, host)
+	previousPassword := string(getDefaultSecret(t, r).Data["password"])
+	fix.BMCPassword = previousPassword
+
+	requestRotation(t, r, host)
+
+	// The BMC kept its password, so the pending one is dropped.
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.GoodCredentials.Match(*getDefaultSecret(t, r))
+	})
+	secret := getDefaultSecret(t, r)
+	assert.NotContains(t, secret.Data, pendingPasswordKey)
+	assert.Equal(t, previousPassword, string(secret.Data["password"]))
+	assert.NotContains(t, secret.Annotations, metal3api.CredentialsRotatedAtAnnotation)
+	assert.NotContains(t, host.Annotations, metal3api.CredentialsRotationFailedAnnotation)
+}
+
+func TestCredentialsRotationRecovery(t *testing.T) {
+	for _, tc := range []struct {
+		Scenario      string
+		BMCHasPending bool
+	}{
+		{
+			Scenario:      "interrupted after changing the BMC",
+			BMCHasPending: true,
+		},
+		{
+			Scenario: "interrupted before changing the BMC",
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := newDefaultHost(t)
+			fix := &fixture.Fixture{}
+			r := newTestReconcilerWithFixture(t, fix, host)
+			waitForProvisioningState(t, r, host, metal3api.StateAvailable)
+
+			secret := getDefaultSecret(t, r)
+			expectedPassword := string(secret.Data["password"])
+			fix.BMCPassword = expectedPassword
+			if tc.BMCHasPending {
+				expectedPassword = "Pending"
+				fix.BMCPassword = expectedPassword
+			}
+			secret.Data[pendingPasswordKey] = []byte("Pending")
+			require.NoError(t, r.Update(t.Context(), secret))
+
+			tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+				_, pending := getDefaultSecret(t, r).Data[pendingPasswordKey]
+				return !pending
+			})
+			assert.Equal(t, expectedPassword, string(getDefaultSecret(t, r).Data["password"]))
+		})
+	}
+}
+
+func TestCredentialsRotationSharedSecret(t *testing.T) {
+	host := newDefaultHost(t)
+	other := newDefaultNamedHost(t, "other")
+	fix := &fixture.Fixture{}
+	r := newTestReconcilerWithFixture(t, fix, host, other)
+
+	requestRotation(t, r, host)
+
+	assert.Empty(t, fix.BMCPassword)
+	assert.NotContains(t, getDefaultSecret(t, r).Annotations, metal3api.CredentialsRotatedAtAnnotation)
+}
+
+func TestCredentialsRotationWithoutCredentials(t *testing.T) {
+	host := newDefaultHost(t)
+	host.Spec.BMC = metal3api.BMCDetails{}
+	host.Annotations = map[string]string{metal3api.CredentialsRotationIntervalAnnotation: "1h"}
+	fix := &fixture.Fixture{}
+	r := newTestReconcilerWithFixture(t, fix, host)
+
+	waitForProvisioningState(t, r, host, metal3api.StateUnmanaged)
+	// Reconcile again now that the host is unmanaged.
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.Provisioning.State == metal3api.StateUnmanaged
+	})
+	assert.Empty(t, fix.BMCPassword)
+}
+
+func TestCredentialsRotationDue(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	created := metav1.NewTime(now.Add(-48 * time.Hour))
+
+	for _, tc := range []struct {
+		Scenario          string
+		HostAnnotations   map[string]string
+		SecretAnnotations map[string]string
+		ExpectedDue       bool
+		ExpectedScheduled bool
+	}{
+		{
+			Scenario: "no policy",
+		},
+		{
+			Scenario:        "requested",
+			HostAnnotations: map[string]string{metal3api.RotateCredentialsAnnotation: ""},
+			ExpectedDue:     true,
+		},
+		{
+			Scenario:          "interval elapsed since creation",
+			HostAnnotations:   map[string]string{metal3api.CredentialsRotationIntervalAnnotation: "24h"},
+			ExpectedDue:       true,
+			ExpectedScheduled: true,
+		},
+		{
+			Scenario:          "rotated recently",
+			HostAnnotations:   map[string]string{metal3api.CredentialsRotationIntervalAnnotation: "24h"},
+			SecretAnnotations: map[string]string{metal3api.CredentialsRotatedAtAnnotation: now.Add(-time.Hour).Format(time.RFC3339)},
+			ExpectedScheduled: true,
+		},
+		{
+			Scenario: "failed recently",
+			HostAnnotations: map[string]string{
+				metal3api.CredentialsRotationIntervalAnnotation: "24h",
+				metal3api.CredentialsRotationFailedAnnotation:   now.Add(-time.Minute).Format(time.RFC3339),
+			},
+			ExpectedScheduled: true,
+		},
+		{
+			Scenario: "failed long ago",
+			HostAnnotations: map[string]string{
+				metal3api.CredentialsRotationIntervalAnnotation: "24h",
+				metal3api.CredentialsRotationFailedAnnotation:   now.Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+			ExpectedDue:       true,
+			ExpectedScheduled: true,
+		},
+		{
+			Scenario:        "invalid interval",
+			HostAnnotations: map[string]string{metal3api.CredentialsRotationIntervalAnnotation: "monthly"},
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := newDefaultHost(t)
+			host.Annotations = tc.HostAnnotations
+			secret := newBMCCredsSecret(defaultSecretName, "User", "Pass")
+			secret.CreationTimestamp = created
+			secret.Annotations = tc.SecretAnnotations
+			info := &reconcileInfo{host: host, bmcCredsSecret: secret, log: logf.Log.WithName("credentials_rotation")}
+
+			due, scheduled := credentialsRotationDue(info, now)
+			assert.Equal(t, tc.ExpectedDue, due)
+			assert.Equal(t, tc.ExpectedScheduled, scheduled)
+		})
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	for range 20 {
+		password, err := generatePassword(generatedPasswordLength)
+		require.NoError(t, err)
+		assert.Len(t, password, generatedPasswordLength)
+		for _, class := range passwordCharacterClasses {
+			assert.True(t, strings.ContainsAny(password, class), "%q has no character from %q", password, class)
+		}
+	}
+}
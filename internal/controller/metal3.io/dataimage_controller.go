@@ -191,6 +191,29 @@ func (r *DataImageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, fmt.Errorf("failed to create provisioner: %w", err)
 	}
 
+	// The provisioner cannot have attached anything if it does not support
+	// dataImages, report it until the dataImage is deleted
+	if err = provisioner.CheckCapability(ctx, prov, provisioner.CapabilityDataImage); err != nil {
+		if !di.DeletionTimestamp.IsZero() {
+			reqLogger.Info("dataImage deletion requested, removing finalizer", "reason", err.Error())
+			if controllerutil.RemoveFinalizer(di, metal3api.DataImageFinalizer) {
+				if err := r.Update(ctx, di); err != nil {
+					return ctrl.Result{Requeue: true, RequeueAfter: dataImageRetryDelay}, fmt.Errorf("failed to update resource after remove finalizer, %w", err)
+				}
+			}
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Info("dataImage is not supported", "Error", err.Error())
+		if di.Status.Error.Message != err.Error() {
+			di.Status.Error.Message = err.Error()
+			if err := r.updateStatus(ctx, info); err != nil {
+				return ctrl.Result{Requeue: true, RequeueAfter: dataImageRetryDelay}, fmt.Errorf("failed to update resource status, %w", err)
+			}
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: dataImageUnmanagedRetryDelay}, nil
+	}
+
 	// Check if any attach/detach action is pending or failed to attach
 	isImageAttached, vmediaGetError := prov.GetDataImageStatus(ctx)
 
@@ -5,7 +5,10 @@ import (
 
 	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner/demo"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,7 +32,7 @@ func newDemoReconciler(t *testing.T, initObjs ...runtime.Object) *BareMetalHostR
 
 	return &BareMetalHostReconciler{
 		Client:             c,
-		ProvisionerFactory: &demo.Demo{},
+		ProvisionerFactory: &demo.Demo{Client: c},
 		Log:                ctrl.Log.WithName("controller").WithName("BareMetalHost"),
 	}
 }
@@ -216,3 +219,42 @@ func TestDemoValidationError(t *testing.T) {
 		},
 	)
 }
+
+// TestDemoScenario tests that a host follows the scenario named by its
+// annotation.
+func TestDemoScenario(t *testing.T) {
+	host := newDefaultNamedHost(t, "scenario")
+	host.Annotations = map[string]string{demo.ScenarioAnnotation: "flaky"}
+	r := newDemoReconciler(t, host)
+	require.NoError(t, r.Create(t.Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "flaky", Namespace: host.Namespace},
+		Data: map[string]string{demo.ScenarioKey: `
+register:
+  failOnAttempts: [1]
+  errorMessage: BMC not responding
+hardware:
+  cpu:
+    count: 64
+health: Warning
+`},
+	}))
+
+	var sawError bool
+	tryReconcile(t, r, host,
+		func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+			t.Logf("Status: %q State: %q ErrorMessage: %q",
+				host.OperationalStatus(),
+				host.Status.Provisioning.State,
+				host.Status.ErrorMessage,
+			)
+			if host.Status.ErrorMessage == "BMC not responding" {
+				sawError = true
+			}
+			return host.Status.Provisioning.State == metal3api.StateAvailable
+		},
+	)
+
+	assert.True(t, sawError, "the first registration attempt did not fail")
+	require.NotNil(t, host.Status.HardwareDetails)
+	assert.Equal(t, 64, host.Status.HardwareDetails.CPU.Count)
+}
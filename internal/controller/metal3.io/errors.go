@@ -34,6 +34,16 @@ func (e ResolveBMCSecretRefError) Error() string {
 	return "BMC CredentialsName secret doesn't exist " + e.message
 }
 
+// BMCCABundleError is returned when the CA bundle the certificate of the
+// BMC is verified with cannot be read.
+type BMCCABundleError struct {
+	message string
+}
+
+func (e BMCCABundleError) Error() string {
+	return "BMC CA bundle cannot be read: " + e.message
+}
+
 // NoDataInSecretError is returned when host configuration
 // data were not found in referenced secret.
 type NoDataInSecretError struct {
@@ -44,3 +54,13 @@ type NoDataInSecretError struct {
 func (e NoDataInSecretError) Error() string {
 	return fmt.Sprintf("Secret %s does not contain key %s", e.secret, e.key)
 }
+
+// networkConfigError is returned when the network configuration requested
+// in Spec.NetworkInterfaces cannot be resolved.
+type networkConfigError struct {
+	message string
+}
+
+func (e networkConfigError) Error() string {
+	return e.message
+}
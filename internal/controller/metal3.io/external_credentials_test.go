@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/secretutils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapCredentialsProvider serves credentials from memory.
+type mapCredentialsProvider struct {
+	lock        sync.Mutex
+	credentials map[types.NamespacedName]secretutils.Credentials
+}
+
+func (p *mapCredentialsProvider) Credentials(_ context.Context, key types.NamespacedName) (secretutils.Credentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	creds, found := p.credentials[key]
+	if !found {
+		return secretutils.Credentials{}, secretutils.ErrCredentialsNotFound
+	}
+	return creds, nil
+}
+
+func (p *mapCredentialsProvider) set(key types.NamespacedName, creds secretutils.Credentials) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.credentials[key] = creds
+}
+
+func TestExternalCredentials(t *testing.T) {
+	host := newDefaultHost(t)
+	host.Spec.BMC.CredentialsName = "external-creds"
+	key := host.CredentialsKey()
+	provider := &mapCredentialsProvider{credentials: map[types.NamespacedName]secretutils.Credentials{}}
+	r := newTestReconciler(t, host)
+	r.CredentialsProvider = provider
+
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.ErrorType != ""
+	})
+	assert.Equal(t, metal3api.RegistrationError, host.Status.ErrorType)
+	assert.Contains(t, host.Status.ErrorMessage, "were not found by the credentials provider")
+
+	provider.set(key, secretutils.Credentials{Username: "admin", Password: "secret", Version: "1"})
+	waitForProvisioningState(t, r, host, metal3api.StateAvailable)
+	assert.Empty(t, host.Status.ErrorType)
+	assert.Equal(t, "1", host.Status.GoodCredentials.Version)
+	assert.Equal(t, key.Name, host.Status.GoodCredentials.Reference.Name)
+
+	provider.set(key, secretutils.Credentials{Username: "admin", Password: "rotated", Version: "2"})
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.GoodCredentials.Version == "2"
+	})
+	assert.Equal(t, metal3api.StateAvailable, host.Status.Provisioning.State)
+}
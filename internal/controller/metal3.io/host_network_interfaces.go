@@ -0,0 +1,141 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// attachmentKey returns the namespaced name of the HostNetworkAttachment
+// referenced by a network interface.
+func attachmentKey(host *metal3api.BareMetalHost, iface *metal3api.NetworkInterface) types.NamespacedName {
+	namespace := iface.HostNetworkAttachment.Namespace
+	if namespace == "" {
+		namespace = host.Namespace
+	}
+	return types.NamespacedName{
+		Namespace: namespace,
+		Name:      iface.HostNetworkAttachment.Name,
+	}
+}
+
+// interfaceMACAddress returns the lowercase MAC address of a network
+// interface, looking up interfaces given by name in the NICs discovered
+// during inspection. It returns an empty string if the NIC is not known.
+func interfaceMACAddress(iface *metal3api.NetworkInterface, hardwareData *metal3api.HardwareData) string {
+	if iface.MACAddress != "" {
+		return strings.ToLower(iface.MACAddress)
+	}
+	if hardwareData == nil || hardwareData.Spec.HardwareDetails == nil {
+		return ""
+	}
+	for _, nic := range hardwareData.Spec.HardwareDetails.NIC {
+		if nic.Name == iface.Name {
+			return strings.ToLower(nic.MAC)
+		}
+	}
+	return ""
+}
+
+// resolveNetworkInterfaces maps the host's Spec.NetworkInterfaces to the MAC
+// addresses of the NICs discovered during inspection. Interfaces that cannot
+// be resolved are returned by their key in unresolved.
+func resolveNetworkInterfaces(host *metal3api.BareMetalHost, hardwareData *metal3api.HardwareData) (resolved []provisioner.NetworkInterfaceData, unresolved []string) {
+	for i := range host.Spec.NetworkInterfaces {
+		iface := &host.Spec.NetworkInterfaces[i]
+
+		mac := interfaceMACAddress(iface, hardwareData)
+		if mac == "" {
+			unresolved = append(unresolved, iface.GetKey())
+			continue
+		}
+
+		resolved = append(resolved, provisioner.NetworkInterfaceData{
+			MACAddress: mac,
+			SwitchPort: iface.SwitchPort.DeepCopy(),
+		})
+	}
+
+	return resolved, unresolved
+}
+
+// getNetworkInterfaceData resolves the host's Spec.NetworkInterfaces together
+// with the switch port configuration of the referenced HostNetworkAttachment
+// resources. A networkConfigError is returned if an interface or an
+// attachment cannot be found.
+func (r *BareMetalHostReconciler) getNetworkInterfaceData(ctx context.Context, info *reconcileInfo) ([]provisioner.NetworkInterfaceData, error) {
+	resolved, unresolved := resolveNetworkInterfaces(info.host, info.hardwareData)
+	if len(unresolved) > 0 {
+		return nil, networkConfigError{
+			message: "network interfaces not found in hardware data: " + strings.Join(unresolved, ", "),
+		}
+	}
+
+	for i := range info.host.Spec.NetworkInterfaces {
+		iface := &info.host.Spec.NetworkInterfaces[i]
+		if iface.HostNetworkAttachment.Name == "" {
+			continue
+		}
+
+		key := attachmentKey(info.host, iface)
+		attachment := &metal3api.HostNetworkAttachment{}
+		if err := r.Get(ctx, key, attachment); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, networkConfigError{
+					message: fmt.Sprintf("HostNetworkAttachment %s referenced by interface %s not found", key, iface.GetKey()),
+				}
+			}
+			return nil, fmt.Errorf("failed to get HostNetworkAttachment %s: %w", key, err)
+		}
+
+		// resolveNetworkInterfaces keeps the order of the spec
+		resolved[i].Attachment = attachment.Spec.DeepCopy()
+	}
+
+	return resolved, nil
+}
+
+// setNetworkConfiguredCondition updates the NetworkConfigured condition and
+// reports whether it changed. Hosts that do not request any network
+// configuration are left without the condition.
+func setNetworkConfiguredCondition(host *metal3api.BareMetalHost, status metav1.ConditionStatus, reason, message string) (changed bool) {
+	existing := meta.FindStatusCondition(host.Status.Conditions, metal3api.NetworkConfiguredCondition)
+	if existing == nil && len(host.Spec.NetworkInterfaces) == 0 {
+		return false
+	}
+	if existing != nil && existing.Status == status && existing.Reason == reason &&
+		existing.Message == message && existing.ObservedGeneration == host.Generation {
+		return false
+	}
+
+	conditions.Set(host, metav1.Condition{
+		Type:    metal3api.NetworkConfiguredCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	return true
+}
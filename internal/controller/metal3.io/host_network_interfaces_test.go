@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newNetworkHardwareData(host *metal3api.BareMetalHost) *metal3api.HardwareData {
+	return &metal3api.HardwareData{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      host.Name,
+			Namespace: host.Namespace,
+		},
+		Spec: metal3api.HardwareDataSpec{
+			HardwareDetails: &metal3api.HardwareDetails{
+				NIC: []metal3api.NIC{
+					{Name: "eth0", MAC: "00:11:22:33:44:55"},
+					{Name: "eth1", MAC: "00:11:22:33:44:56"},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveNetworkInterfaces(t *testing.T) {
+	host := newDefaultHost(t)
+	host.Spec.NetworkInterfaces = []metal3api.NetworkInterface{
+		{Name: "eth0"},
+		{
+			MACAddress: "00:11:22:33:44:AA",
+			SwitchPort: &metal3api.SwitchPort{SwitchID: "aa:bb:cc:dd:ee:ff", PortID: "Ethernet1/1"},
+		},
+		{Name: "eth9"},
+	}
+
+	resolved, unresolved := resolveNetworkInterfaces(host, newNetworkHardwareData(host))
+	require.Len(t, resolved, 2)
+	assert.Equal(t, "00:11:22:33:44:55", resolved[0].MACAddress)
+	assert.Nil(t, resolved[0].SwitchPort)
+	assert.Equal(t, "00:11:22:33:44:aa", resolved[1].MACAddress)
+	assert.Equal(t, "Ethernet1/1", resolved[1].SwitchPort.PortID)
+	assert.Equal(t, []string{"eth9"}, unresolved)
+
+	resolved, unresolved = resolveNetworkInterfaces(host, nil)
+	assert.Len(t, resolved, 1)
+	assert.Equal(t, []string{"eth0", "eth9"}, unresolved)
+}
+
+func TestGetNetworkInterfaceData(t *testing.T) {
+	attachment := &metal3api.HostNetworkAttachment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vlan100",
+			Namespace: namespace,
+		},
+		Spec: metal3api.HostNetworkAttachmentSpec{
+			Mode:       metal3api.SwitchportModeAccess,
+			NativeVLAN: 100,
+		},
+	}
+
+	testCases := []struct {
+		Scenario          string
+		NetworkInterfaces []metal3api.NetworkInterface
+		Objects           []runtime.Object
+		ExpectConfigError bool
+		ExpectAttachments []*metal3api.HostNetworkAttachmentSpec
+	}{
+		{
+			Scenario: "attachment found",
+			NetworkInterfaces: []metal3api.NetworkInterface{
+				{Name: "eth0", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100"}},
+				{Name: "eth1"},
+			},
+			Objects:           []runtime.Object{attachment},
+			ExpectAttachments: []*metal3api.HostNetworkAttachmentSpec{&attachment.Spec, nil},
+		},
+		{
+			Scenario: "attachment missing",
+			NetworkInterfaces: []metal3api.NetworkInterface{
+				{Name: "eth0", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100"}},
+			},
+			ExpectConfigError: true,
+		},
+		{
+			Scenario: "interface missing",
+			NetworkInterfaces: []metal3api.NetworkInterface{
+				{Name: "eth9", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100"}},
+			},
+			Objects:           []runtime.Object{attachment},
+			ExpectConfigError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := newDefaultHost(t)
+			host.Spec.NetworkInterfaces = tc.NetworkInterfaces
+			r := newTestReconciler(t, tc.Objects...)
+			info := makeReconcileInfo(host)
+			info.hardwareData = newNetworkHardwareData(host)
+
+			data, err := r.getNetworkInterfaceData(t.Context(), info)
+			if tc.ExpectConfigError {
+				require.ErrorAs(t, err, &networkConfigError{})
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, data, len(tc.ExpectAttachments))
+			for i, expected := range tc.ExpectAttachments {
+				assert.Equal(t, expected, data[i].Attachment)
+			}
+		})
+	}
+}
+
+func TestSetNetworkConfiguredCondition(t *testing.T) {
+	host := newDefaultHost(t)
+	assert.False(t, setNetworkConfiguredCondition(host, metav1.ConditionFalse, metal3api.NetworkNotConfiguredReason, ""))
+	assert.Nil(t, meta.FindStatusCondition(host.Status.Conditions, metal3api.NetworkConfiguredCondition))
+
+	host.Spec.NetworkInterfaces = []metal3api.NetworkInterface{{Name: "eth0"}}
+	assert.True(t, setNetworkConfiguredCondition(host, metav1.ConditionTrue, metal3api.NetworkConfiguredReason, ""))
+	assert.False(t, setNetworkConfiguredCondition(host, metav1.ConditionTrue, metal3api.NetworkConfiguredReason, ""))
+
+	// The condition is kept up to date once the interfaces are removed
+	host.Spec.NetworkInterfaces = nil
+	assert.True(t, setNetworkConfiguredCondition(host, metav1.ConditionFalse, metal3api.NetworkNotConfiguredReason, ""))
+	cond := meta.FindStatusCondition(host.Status.Conditions, metal3api.NetworkConfiguredCondition)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+}
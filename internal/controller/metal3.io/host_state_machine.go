@@ -86,13 +86,23 @@ func recordStateEnd(info *reconcileInfo, host *metal3api.BareMetalHost, state me
 }
 
 func (hsm *hostStateMachine) ensureCapacity(ctx context.Context, info *reconcileInfo, state metal3api.ProvisioningState) actionResult {
+	limit, err := hsm.Reconciler.checkProvisioningLimits(ctx, info.host)
+	if err != nil {
+		return actionError{fmt.Errorf("failed to check provisioning limits: %w", err)}
+	}
+
+	if limit != nil {
+		return recordActionDelayed(info, state, limit.reason, limit.message)
+	}
+
 	hasCapacity, err := hsm.Provisioner.HasCapacity(ctx)
 	if err != nil {
 		return actionError{fmt.Errorf("failed to determine current provisioner capacity: %w", err)}
 	}
 
 	if !hasCapacity {
-		return recordActionDelayed(info, state)
+		return recordActionDelayed(info, state, metal3api.ProvisionerLimitReason,
+			"the provisioner has no free slot for the host")
 	}
 
 	return nil
@@ -197,11 +207,23 @@ func (hsm *hostStateMachine) ReconcileState(ctx context.Context, info *reconcile
 		return detachedResult
 	}
 
+	if hsm.haveCreds {
+		if recoverResult := hsm.Reconciler.recoverCredentialsRotation(ctx, hsm.Provisioner, info); recoverResult != nil {
+			return recoverResult
+		}
+	}
+
 	if registerResult := hsm.ensureRegistered(ctx, info); registerResult != nil {
 		hostRegistrationRequired.Inc()
 		return registerResult
 	}
 
+	if hsm.haveCreds {
+		if rotateResult := hsm.Reconciler.rotateCredentials(ctx, hsm.Provisioner, info); rotateResult != nil {
+			return rotateResult
+		}
+	}
+
 	if stateHandler, found := hsm.handlers()[initialState]; found {
 		return stateHandler(ctx, info)
 	}
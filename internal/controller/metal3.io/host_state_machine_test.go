@@ -258,6 +258,31 @@ func TestRegisterHostNotDirtyWhileWaitingForPreprovisioningImage(t *testing.T) {
 	assert.False(t, result.Dirty(), "expected no forced status update when nothing changed")
 }
 
+// shardedMockProvisioner is a mockProvisioner managing hosts in a shard.
+type shardedMockProvisioner struct {
+	*mockProvisioner
+	shard string
+}
+
+func (m shardedMockProvisioner) Shard() string {
+	return m.shard
+}
+
+func TestRegisterHostRecordsShard(t *testing.T) {
+	testHost := host(metal3api.StateRegistering).build()
+
+	reconciler := testNewReconciler(testHost)
+	prov := shardedMockProvisioner{mockProvisioner: newMockProvisioner(), shard: "east"}
+	prov.nextResults["ValidateManagementAccess"] = provisioner.Result{Dirty: true}
+
+	info := makeDefaultReconcileInfo(testHost)
+
+	result := reconciler.registerHost(t.Context(), prov, info)
+
+	assert.True(t, result.Dirty(), "expected the shard to be saved")
+	assert.Equal(t, "east", testHost.Status.Provisioning.Shard)
+}
+
 func TestDetach(t *testing.T) {
 	testCases := []struct {
 		Scenario                  string
@@ -153,6 +153,9 @@ func patchHostClaim(ctx context.Context, patchHelper *patch.Helper, claim *metal
 			metal3api.SynchronizedCondition,
 			metal3api.ProvisionedCondition,
 			metal3api.AvailableForProvisioningCondition,
+			metal3api.RebootedCondition,
+			metal3api.InspectedCondition,
+			metal3api.ReprovisionedCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
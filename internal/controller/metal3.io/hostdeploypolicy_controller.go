@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hostclaim"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// HostDeployPolicyReconciler reports the use of the BareMetalHosts of the
+// namespace of a HostDeployPolicy by HostClaims.
+type HostDeployPolicyReconciler struct {
+	client.Client
+	Log logr.Logger
+}
+
+//+kubebuilder:rbac:groups=metal3.io,resources=hostdeploypolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=hostdeploypolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch
+
+// Reconcile updates the claims and remaining quotas in the status of a
+// HostDeployPolicy.
+func (r *HostDeployPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("hostdeploypolicy", req.NamespacedName)
+
+	policy := &metal3api.HostDeployPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("could not load HostDeployPolicy: %w", err)
+	}
+
+	hosts := &metal3api.BareMetalHostList{}
+	if err := r.List(ctx, hosts, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list BareMetalHosts: %w", err)
+	}
+
+	newStatus := metal3api.HostDeployPolicyStatus{
+		Namespaces: hostclaim.HostUsage(policy, hosts.Items),
+	}
+	if equality.Semantic.DeepEqual(policy.Status, newStatus) {
+		return ctrl.Result{}, nil
+	}
+
+	policy.Status = newStatus
+	if err := r.Status().Update(ctx, policy); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update HostDeployPolicy status: %w", err)
+	}
+	logger.Info("updated status", "namespaces", len(newStatus.Namespaces))
+	return ctrl.Result{}, nil
+}
+
+// hostToHostDeployPolicies maps a BareMetalHost to the HostDeployPolicies
+// of its namespace.
+func (r *HostDeployPolicyReconciler) hostToHostDeployPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := &metal3api.HostDeployPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list HostDeployPolicies", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for i := range policies.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: policies.Items[i].Namespace,
+				Name:      policies.Items[i].Name,
+			},
+		})
+	}
+	return requests
+}
+
+// hostUsageChanged filters the updates of hosts to the ones that can change
+// the usage reported by HostDeployPolicies: quotas match the labels of hosts
+// and count the hosts bound to HostClaims.
+func hostUsageChanged(e event.UpdateEvent) bool {
+	oldHost, ok := e.ObjectOld.(*metal3api.BareMetalHost)
+	if !ok {
+		return true
+	}
+	newHost, ok := e.ObjectNew.(*metal3api.BareMetalHost)
+	if !ok {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(oldHost.Labels, newHost.Labels) ||
+		!equality.Semantic.DeepEqual(oldHost.Spec.ConsumerRef, newHost.Spec.ConsumerRef)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HostDeployPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metal3api.HostDeployPolicy{}).
+		Watches(&metal3api.BareMetalHost{}, handler.EnqueueRequestsFromMapFunc(r.hostToHostDeployPolicies),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: hostUsageChanged})).
+		Complete(r)
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestHostDeployPolicyReconcile(t *testing.T) {
+	policy := &metal3api.HostDeployPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: namespace,
+		},
+		Spec: metal3api.HostDeployPolicySpec{
+			HostClaimNamespaces: &metal3api.HostClaimNamespaces{NameMatches: "^team-"},
+			Quotas:              []metal3api.HostQuota{{Name: "all", MaxHosts: 2}},
+		},
+	}
+	claimed := newHost("claimed", &metal3api.BareMetalHostSpec{
+		ConsumerRef: &corev1.ObjectReference{
+			Kind:       "HostClaim",
+			APIVersion: metal3api.GroupVersion.String(),
+			Namespace:  "team-a",
+			Name:       "claim",
+		},
+	})
+	available := newHost("available", &metal3api.BareMetalHostSpec{})
+
+	c := fakeclient.NewClientBuilder().
+		WithObjects(policy, claimed, available).
+		WithStatusSubresource(&metal3api.HostDeployPolicy{}).
+		Build()
+	r := &HostDeployPolicyReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("controllers").WithName("HostDeployPolicy"),
+	}
+
+	key := types.NamespacedName{Name: "pool", Namespace: namespace}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	updated := &metal3api.HostDeployPolicy{}
+	require.NoError(t, c.Get(context.Background(), key, updated))
+	assert.Equal(t, []metal3api.NamespaceHostUsage{
+		{
+			Namespace:    "team-a",
+			ClaimedHosts: 1,
+			Quotas:       []metal3api.HostQuotaUsage{{Name: "all", Used: 1, Remaining: 1}},
+		},
+	}, updated.Status.Namespaces)
+
+	requests := r.hostToHostDeployPolicies(context.Background(), available)
+	assert.Equal(t, []ctrl.Request{{NamespacedName: key}}, requests)
+
+	// Hosts of other namespaces are not mapped to the policy
+	other := newHost("other", &metal3api.BareMetalHostSpec{})
+	other.Namespace = "elsewhere"
+	assert.Empty(t, r.hostToHostDeployPolicies(context.Background(), other))
+}
+
+func TestHostUsageChanged(t *testing.T) {
+	host := newHost("host", &metal3api.BareMetalHostSpec{})
+	host.Labels = map[string]string{"zone": "a"}
+
+	statusUpdate := host.DeepCopy()
+	statusUpdate.Status.Provisioning.State = metal3api.StateAvailable
+	assert.False(t, hostUsageChanged(event.UpdateEvent{ObjectOld: host, ObjectNew: statusUpdate}))
+
+	relabelled := host.DeepCopy()
+	relabelled.Labels["zone"] = "b"
+	assert.True(t, hostUsageChanged(event.UpdateEvent{ObjectOld: host, ObjectNew: relabelled}))
+
+	bound := host.DeepCopy()
+	bound.Spec.ConsumerRef = &corev1.ObjectReference{Kind: "HostClaim", Namespace: "team-a", Name: "claim"}
+	assert.True(t, hostUsageChanged(event.UpdateEvent{ObjectOld: host, ObjectNew: bound}))
+}
@@ -57,8 +57,9 @@ type rhfcInfo struct {
 type conditionReasonHFC string
 
 const (
-	reasonInvalidComponent conditionReasonHFC = "InvalidComponent"
-	reasonValidComponent   conditionReasonHFC = "OK"
+	reasonInvalidComponent      conditionReasonHFC = "InvalidComponent"
+	reasonValidComponent        conditionReasonHFC = "OK"
+	reasonNotSupportedComponent conditionReasonHFC = "NotSupported"
 )
 
 func (info *rhfcInfo) publishEvent(reason, message string) {
@@ -140,6 +141,13 @@ func (r *HostFirmwareComponentsReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, fmt.Errorf("failed to create provisioner: %w", err)
 	}
 
+	if err = provisioner.CheckCapability(ctx, prov, provisioner.CapabilityFirmwareComponents); err != nil {
+		if err = r.reportNotSupported(ctx, info, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("could not update hostfirmwarecomponents: %w", err)
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: reconcilerRequeueDelay}, nil
+	}
+
 	info.log.V(VerbosityLevelDebug).Info("retrieving firmware components and saving to resource",
 		LogFieldNode, bmh.Status.Provisioning.ID)
 	// Check ironic for the components information if possible
@@ -229,6 +237,25 @@ func (r *HostFirmwareComponentsReconciler) updateHostFirmware(ctx context.Contex
 	return nil
 }
 
+// Mark the components as not valid when the provisioner of the host cannot
+// retrieve or update them, so that the host does not wait for them.
+func (r *HostFirmwareComponentsReconciler) reportNotSupported(ctx context.Context, info *rhfcInfo, notSupported error) error {
+	info.log.Info("firmware components are not supported", LogFieldError, notSupported.Error())
+
+	generation := info.hfc.GetGeneration()
+	changed := setUpdatesCondition(generation, &info.hfc.Status, metal3api.HostFirmwareComponentsValid, metav1.ConditionFalse, reasonNotSupportedComponent, notSupported.Error())
+	if setUpdatesCondition(generation, &info.hfc.Status, metal3api.HostFirmwareComponentsChangeDetected, metav1.ConditionFalse, reasonNotSupportedComponent, "") {
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	t := metav1.Now()
+	info.hfc.Status.LastUpdated = &t
+	return r.Status().Update(ctx, info.hfc)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *HostFirmwareComponentsReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconcile int) error {
 	return ctrl.NewControllerManagedBy(mgr).
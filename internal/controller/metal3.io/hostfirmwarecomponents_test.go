@@ -10,6 +10,7 @@ import (
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -458,6 +459,47 @@ func TestHFCReconcileDeletedHFC(t *testing.T) {
 	assert.Equal(t, ctrl.Result{}, result)
 }
 
+// Test that the components are marked as not valid, without calling the
+// provisioner, when it does not support firmware components.
+func TestHFCReconcileNotSupported(t *testing.T) {
+	bmh := &metal3api.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hostName,
+			Namespace: hostNamespace,
+		},
+		Status: metal3api.BareMetalHostStatus{
+			Provisioning: metal3api.ProvisionStatus{
+				ID:    "made-up-id",
+				State: metal3api.StateAvailable,
+			},
+		},
+	}
+	hfc := getHFC(metal3api.HostFirmwareComponentsSpec{})
+	c := fakeclient.NewClientBuilder().WithRuntimeObjects(bmh, hfc).WithStatusSubresource(hfc).Build()
+
+	reconciler := &HostFirmwareComponentsReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("test_reconciler").WithName("HostFirmwareComponents"),
+		ProvisionerFactory: &fixture.Fixture{
+			Unsupported: []provisioner.Capability{provisioner.CapabilityFirmwareComponents},
+		},
+	}
+
+	request := ctrl.Request{NamespacedName: client.ObjectKey{Name: hostName, Namespace: hostNamespace}}
+	result, err := reconciler.Reconcile(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, reconcilerRequeueDelay, result.RequeueAfter)
+
+	updated := &metal3api.HostFirmwareComponents{}
+	require.NoError(t, c.Get(t.Context(), request.NamespacedName, updated))
+	valid := meta.FindStatusCondition(updated.Status.Conditions, string(metal3api.HostFirmwareComponentsValid))
+	require.NotNil(t, valid)
+	assert.Equal(t, metav1.ConditionFalse, valid.Status)
+	assert.Equal(t, string(reasonNotSupportedComponent), valid.Reason)
+	assert.Equal(t, "FirmwareComponents is not supported by provisioner fixture", valid.Message)
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, string(metal3api.HostFirmwareComponentsChangeDetected)))
+}
+
 // Test the function to validate the components in the Spec.
 func TestValidadeHostFirmwareComponents(t *testing.T) {
 	testCases := []struct {
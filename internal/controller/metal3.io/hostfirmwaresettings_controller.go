@@ -73,6 +73,7 @@ type conditionReason string
 const (
 	reasonSuccess            conditionReason = "Success"
 	reasonConfigurationError conditionReason = "ConfigurationError"
+	reasonNotSupported       conditionReason = "NotSupported"
 )
 
 func (info *rInfo) publishEvent(reason, message string) {
@@ -158,6 +159,13 @@ func (r *HostFirmwareSettingsReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, fmt.Errorf("failed to create provisioner: %w", err)
 	}
 
+	if err = provisioner.CheckCapability(ctx, prov, provisioner.CapabilityFirmwareSettings); err != nil {
+		if err = r.reportNotSupported(ctx, info, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("could not update hostFirmwareSettings: %w", err)
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: reconcilerRequeueDelay}, nil
+	}
+
 	info.log.V(VerbosityLevelDebug).Info("retrieving firmware settings and saving to resource",
 		LogFieldNode, bmh.Status.Provisioning.ID)
 
@@ -311,6 +319,36 @@ func (r *HostFirmwareSettingsReconciler) updateStatus(ctx context.Context, info
 	return nil
 }
 
+// Mark the settings as not valid when the provisioner of the host cannot
+// manage them, so that the host does not wait for them.
+func (r *HostFirmwareSettingsReconciler) reportNotSupported(ctx context.Context, info *rInfo, notSupported error) error {
+	info.log.Info("firmware settings are not supported", LogFieldError, notSupported.Error())
+
+	generation := info.hfs.GetGeneration()
+	changed := meta.SetStatusCondition(&info.hfs.Status.Conditions, metav1.Condition{
+		Type:               string(metal3api.FirmwareSettingsValid),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             string(reasonNotSupported),
+		Message:            notSupported.Error(),
+	})
+	if meta.SetStatusCondition(&info.hfs.Status.Conditions, metav1.Condition{
+		Type:               string(metal3api.FirmwareSettingsChangeDetected),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             string(reasonNotSupported),
+	}) {
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	t := metav1.Now()
+	info.hfs.Status.LastUpdated = &t
+	return r.Status().Update(ctx, info.hfs)
+}
+
 // Get a firmware schema that matches the host vendor or create one if it doesn't exist.
 func (r *HostFirmwareSettingsReconciler) getOrCreateFirmwareSchema(ctx context.Context, info *rInfo, schema map[string]metal3api.SettingSchema) (fSchema *metal3api.FirmwareSchema, err error) {
 	info.log.V(VerbosityLevelTrace).Info("getting firmwareSchema")
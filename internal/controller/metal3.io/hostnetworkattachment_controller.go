@@ -0,0 +1,211 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// HostNetworkAttachmentReconciler reconciles a HostNetworkAttachment object.
+type HostNetworkAttachmentReconciler struct {
+	client.Client
+	Log logr.Logger
+}
+
+//+kubebuilder:rbac:groups=metal3.io,resources=hostnetworkattachments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=hostnetworkattachments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=hardwaredata,verbs=get;list;watch
+
+// Reconcile publishes which BareMetalHost network interfaces use a
+// HostNetworkAttachment and whether it has been applied to their switch ports.
+func (r *HostNetworkAttachmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("hostnetworkattachment", req.NamespacedName)
+	logger.Info("start")
+
+	attachment := &metal3api.HostNetworkAttachment{}
+	if err := r.Get(ctx, req.NamespacedName, attachment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("could not load HostNetworkAttachment: %w", err)
+	}
+
+	hosts, err := r.findReferencingHosts(ctx, attachment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	newStatus := metal3api.HostNetworkAttachmentStatus{}
+	for i := range hosts {
+		refs, ports, err := r.hostReferences(ctx, &hosts[i], attachment)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		newStatus.ReferencedBy = append(newStatus.ReferencedBy, refs...)
+		newStatus.Ports = append(newStatus.Ports, ports...)
+	}
+
+	inUse := metav1.Condition{
+		Type:               string(metal3api.HostNetworkAttachmentConditionInUse),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: attachment.Generation,
+		Reason:             metal3api.HostNetworkAttachmentNotReferencedReason,
+		Message:            "Not referenced by any BareMetalHost",
+	}
+	if len(newStatus.ReferencedBy) > 0 {
+		inUse.Status = metav1.ConditionTrue
+		inUse.Reason = metal3api.HostNetworkAttachmentReferencedReason
+		inUse.Message = fmt.Sprintf("Referenced by %d BareMetalHost network interface(s)", len(newStatus.ReferencedBy))
+	}
+	newStatus.Conditions = append([]metav1.Condition(nil), attachment.Status.Conditions...)
+	meta.SetStatusCondition(&newStatus.Conditions, inUse)
+
+	if equality.Semantic.DeepEqual(attachment.Status, newStatus) {
+		logger.Info("done")
+		return ctrl.Result{}, nil
+	}
+
+	attachment.Status = newStatus
+	if err := r.Status().Update(ctx, attachment); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update HostNetworkAttachment status: %w", err)
+	}
+
+	logger.Info("updated status", "referencedBy", len(newStatus.ReferencedBy))
+	return ctrl.Result{}, nil
+}
+
+// findReferencingHosts returns the hosts that reference the attachment from
+// any of their network interfaces, sorted by namespace and name.
+func (r *HostNetworkAttachmentReconciler) findReferencingHosts(ctx context.Context, attachment *metal3api.HostNetworkAttachment) ([]metal3api.BareMetalHost, error) {
+	hostList := &metal3api.BareMetalHostList{}
+	key := attachment.Namespace + "/" + attachment.Name
+	if err := r.List(ctx, hostList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(metal3api.HostNetworkAttachmentIndexField, key),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list BareMetalHosts referencing %s: %w", key, err)
+	}
+
+	hosts := hostList.Items
+	slices.SortFunc(hosts, func(a, b metal3api.BareMetalHost) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return hosts, nil
+}
+
+// hostReferences returns the interfaces of the host that reference the
+// attachment together with the state of their switch ports.
+func (r *HostNetworkAttachmentReconciler) hostReferences(ctx context.Context, host *metal3api.BareMetalHost, attachment *metal3api.HostNetworkAttachment) (refs []metal3api.HostNetworkAttachmentReference, ports []metal3api.HostNetworkAttachmentPortStatus, err error) {
+	hardwareData := &metal3api.HardwareData{}
+	if err = r.Get(ctx, types.NamespacedName{Namespace: host.Namespace, Name: host.Name}, hardwareData); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("failed to get HardwareData for %s/%s: %w", host.Namespace, host.Name, err)
+		}
+		hardwareData = nil
+	}
+
+	state, message := switchPortState(host)
+	for i := range host.Spec.NetworkInterfaces {
+		iface := &host.Spec.NetworkInterfaces[i]
+		if iface.HostNetworkAttachment.Name == "" ||
+			attachmentKey(host, iface) != (types.NamespacedName{Namespace: attachment.Namespace, Name: attachment.Name}) {
+			continue
+		}
+
+		ref := metal3api.HostNetworkAttachmentReference{
+			Namespace: host.Namespace,
+			Name:      host.Name,
+			Interface: iface.GetKey(),
+		}
+		refs = append(refs, ref)
+
+		ports = append(ports, metal3api.HostNetworkAttachmentPortStatus{
+			HostNetworkAttachmentReference: ref,
+			MACAddress:                     interfaceMACAddress(iface, hardwareData),
+			State:                          state,
+			Message:                        message,
+		})
+	}
+
+	return refs, ports, nil
+}
+
+// switchPortState derives the state of the switch ports of a host from its
+// provisioning state and NetworkConfigured condition.
+func switchPortState(host *metal3api.BareMetalHost) (metal3api.SwitchPortState, string) {
+	switch host.Status.Provisioning.State {
+	case metal3api.StateProvisioning, metal3api.StateProvisioned:
+	default:
+		return metal3api.SwitchPortStateNotApplied, ""
+	}
+
+	cond := meta.FindStatusCondition(host.Status.Conditions, metal3api.NetworkConfiguredCondition)
+	switch {
+	case cond == nil:
+		return metal3api.SwitchPortStatePending, ""
+	case cond.Status == metav1.ConditionTrue:
+		return metal3api.SwitchPortStateApplied, ""
+	case cond.Reason == metal3api.NetworkConfigErrorReason:
+		return metal3api.SwitchPortStateFailed, cond.Message
+	default:
+		return metal3api.SwitchPortStatePending, ""
+	}
+}
+
+// hostToAttachments maps a BareMetalHost to the HostNetworkAttachments it
+// references.
+func hostToAttachments(_ context.Context, obj client.Object) []reconcile.Request {
+	host, ok := obj.(*metal3api.BareMetalHost)
+	if !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, key := range host.HostNetworkAttachmentKeys() {
+		namespace, name, _ := strings.Cut(key, "/")
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager registers the reconciler to be run by the manager.
+func (r *HostNetworkAttachmentReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconcile int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&metal3api.HostNetworkAttachment{}).
+		Watches(&metal3api.BareMetalHost{}, handler.EnqueueRequestsFromMapFunc(hostToAttachments)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconcile}).
+		Complete(r)
+}
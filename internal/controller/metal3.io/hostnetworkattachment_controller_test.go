@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestHostNetworkAttachmentReconciler(objs ...client.Object) *HostNetworkAttachmentReconciler {
+	c := fakeclient.NewClientBuilder().
+		WithObjects(objs...).
+		WithStatusSubresource(&metal3api.HostNetworkAttachment{}).
+		WithIndex(&metal3api.BareMetalHost{}, metal3api.HostNetworkAttachmentIndexField, func(obj client.Object) []string {
+			host, _ := obj.(*metal3api.BareMetalHost)
+			return host.HostNetworkAttachmentKeys()
+		}).
+		Build()
+
+	return &HostNetworkAttachmentReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("controllers").WithName("HostNetworkAttachment"),
+	}
+}
+
+func TestHostNetworkAttachmentReconcile(t *testing.T) {
+	attachment := &metal3api.HostNetworkAttachment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vlan100",
+			Namespace: namespace,
+		},
+		Spec: metal3api.HostNetworkAttachmentSpec{
+			Mode:       metal3api.SwitchportModeAccess,
+			NativeVLAN: 100,
+		},
+	}
+
+	provisioned := newHost("provisioned", &metal3api.BareMetalHostSpec{
+		NetworkInterfaces: []metal3api.NetworkInterface{
+			{Name: "eth0", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100"}},
+			{Name: "eth1"},
+		},
+	})
+	provisioned.Status.Provisioning.State = metal3api.StateProvisioned
+	provisioned.Status.Conditions = []metav1.Condition{
+		{
+			Type:   metal3api.NetworkConfiguredCondition,
+			Status: metav1.ConditionTrue,
+			Reason: metal3api.NetworkConfiguredReason,
+		},
+	}
+	hardwareData := newNetworkHardwareData(provisioned)
+
+	failed := newHost("failed", &metal3api.BareMetalHostSpec{
+		NetworkInterfaces: []metal3api.NetworkInterface{
+			{MACAddress: "00:11:22:33:44:AA", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100", Namespace: namespace}},
+		},
+	})
+	failed.Status.Provisioning.State = metal3api.StateProvisioning
+	failed.Status.Conditions = []metav1.Condition{
+		{
+			Type:    metal3api.NetworkConfiguredCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  metal3api.NetworkConfigErrorReason,
+			Message: "something went wrong",
+		},
+	}
+
+	available := newHost("available", &metal3api.BareMetalHostSpec{
+		NetworkInterfaces: []metal3api.NetworkInterface{
+			{Name: "eth0", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100"}},
+		},
+	})
+	available.Status.Provisioning.State = metal3api.StateAvailable
+
+	unrelated := newHost("unrelated", &metal3api.BareMetalHostSpec{
+		NetworkInterfaces: []metal3api.NetworkInterface{
+			{Name: "eth0", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan200"}},
+		},
+	})
+
+	r := newTestHostNetworkAttachmentReconciler(attachment, provisioned, hardwareData, failed, available, unrelated)
+	key := types.NamespacedName{Namespace: namespace, Name: "vlan100"}
+
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	result := &metal3api.HostNetworkAttachment{}
+	require.NoError(t, r.Get(t.Context(), key, result))
+
+	assert.Equal(t, []metal3api.HostNetworkAttachmentReference{
+		{Namespace: namespace, Name: "available", Interface: "eth0"},
+		{Namespace: namespace, Name: "failed", Interface: "00:11:22:33:44:AA"},
+		{Namespace: namespace, Name: "provisioned", Interface: "eth0"},
+	}, result.Status.ReferencedBy)
+
+	require.Len(t, result.Status.Ports, 3)
+	assert.Equal(t, metal3api.SwitchPortStateNotApplied, result.Status.Ports[0].State)
+	assert.Empty(t, result.Status.Ports[0].MACAddress)
+	assert.Equal(t, metal3api.SwitchPortStateFailed, result.Status.Ports[1].State)
+	assert.Equal(t, "something went wrong", result.Status.Ports[1].Message)
+	assert.Equal(t, "00:11:22:33:44:aa", result.Status.Ports[1].MACAddress)
+	assert.Equal(t, metal3api.SwitchPortStateApplied, result.Status.Ports[2].State)
+	assert.Equal(t, "00:11:22:33:44:55", result.Status.Ports[2].MACAddress)
+
+	cond := meta.FindStatusCondition(result.Status.Conditions, string(metal3api.HostNetworkAttachmentConditionInUse))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, metal3api.HostNetworkAttachmentReferencedReason, cond.Reason)
+}
+
+func TestHostNetworkAttachmentReconcileNotReferenced(t *testing.T) {
+	attachment := &metal3api.HostNetworkAttachment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vlan100",
+			Namespace: namespace,
+		},
+		Spec: metal3api.HostNetworkAttachmentSpec{
+			Mode:       metal3api.SwitchportModeAccess,
+			NativeVLAN: 100,
+		},
+		Status: metal3api.HostNetworkAttachmentStatus{
+			ReferencedBy: []metal3api.HostNetworkAttachmentReference{
+				{Namespace: namespace, Name: "gone", Interface: "eth0"},
+			},
+		},
+	}
+
+	r := newTestHostNetworkAttachmentReconciler(attachment)
+	key := types.NamespacedName{Namespace: namespace, Name: "vlan100"}
+
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	result := &metal3api.HostNetworkAttachment{}
+	require.NoError(t, r.Get(t.Context(), key, result))
+	assert.Empty(t, result.Status.ReferencedBy)
+	assert.Empty(t, result.Status.Ports)
+
+	cond := meta.FindStatusCondition(result.Status.Conditions, string(metal3api.HostNetworkAttachmentConditionInUse))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, metal3api.HostNetworkAttachmentNotReferencedReason, cond.Reason)
+}
+
+func TestHostToAttachments(t *testing.T) {
+	host := newHost("myhost", &metal3api.BareMetalHostSpec{
+		NetworkInterfaces: []metal3api.NetworkInterface{
+			{Name: "eth0", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan100"}},
+			{Name: "eth1", HostNetworkAttachment: metal3api.HostNetworkAttachmentRef{Name: "vlan200", Namespace: "shared"}},
+		},
+	})
+
+	requests := hostToAttachments(t.Context(), host)
+	require.Len(t, requests, 2)
+	assert.Equal(t, types.NamespacedName{Namespace: namespace, Name: "vlan100"}, requests[0].NamespacedName)
+	assert.Equal(t, types.NamespacedName{Namespace: "shared", Name: "vlan200"}, requests[1].NamespacedName)
+}
@@ -15,6 +15,8 @@ const (
 	labelPrevState     = "prev_state"
 	labelNewState      = "new_state"
 	labelHostDataType  = "host_data_type"
+	labelSwitchName    = "switch"
+	labelProbeResult   = "result"
 )
 
 var reconcileCounters = prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -131,6 +133,26 @@ var deleteDelayedForDetached = prometheus.NewCounter(prometheus.CounterOpts{
 	Help: "Number of times a host delete action was delayed due to the detached annotation",
 })
 
+var switchProbeCounters = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "metal3_switch_probe_total",
+	Help: "Number of times a switch has been probed, by result",
+}, []string{labelHostNamespace, labelSwitchName, labelProbeResult})
+
+var switchProbeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "metal3_switch_probe_duration_seconds",
+	Help: "Length of time per switch reachability and authentication probe",
+}, []string{labelHostNamespace, labelSwitchName})
+
+var bmcProbeCounters = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "metal3_bmc_probe_total",
+	Help: "Number of times a BMC has been probed before registration, by result",
+}, []string{labelProbeResult})
+
+var credentialsRotations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "metal3_bmc_credentials_rotation_total",
+	Help: "Number of BMC password rotations, by result",
+}, []string{labelProbeResult})
+
 func init() {
 	metrics.Registry.MustRegister(
 		reconcileCounters,
@@ -159,6 +181,12 @@ func init() {
 		deleteWithoutDeprov,
 		provisionerNotReady,
 		deleteDelayedForDetached)
+
+	metrics.Registry.MustRegister(
+		switchProbeCounters,
+		switchProbeDuration,
+		bmcProbeCounters,
+		credentialsRotations)
 }
 
 func hostMetricLabels(request ctrl.Request) prometheus.Labels {
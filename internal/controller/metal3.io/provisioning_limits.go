@@ -0,0 +1,220 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ProvisioningLimits caps the number of hosts inspecting, provisioning or
+// deprovisioning at the same time within groups of hosts. Unlike the limit
+// of the provisioner, they also apply to hosts booting from virtual media.
+// A zero limit is disabled.
+type ProvisioningLimits struct {
+	// PerNamespace applies to the hosts of each namespace.
+	PerNamespace int
+	// PerConductorGroup applies to the hosts of each Ironic conductor group.
+	PerConductorGroup int
+	// PerLabel applies to the hosts sharing the value of each label key.
+	PerLabel map[string]int
+}
+
+// LoadProvisioningLimitsFromEnv reads the limits from
+// PROVISIONING_LIMIT_PER_NAMESPACE, PROVISIONING_LIMIT_PER_CONDUCTOR_GROUP
+// and PROVISIONING_LIMIT_PER_LABEL, the latter being comma-separated
+// key=limit pairs.
+func LoadProvisioningLimitsFromEnv() (limits ProvisioningLimits, err error) {
+	limits.PerNamespace, err = provisioningLimitFromEnv("PROVISIONING_LIMIT_PER_NAMESPACE")
+	if err != nil {
+		return limits, err
+	}
+	limits.PerConductorGroup, err = provisioningLimitFromEnv("PROVISIONING_LIMIT_PER_CONDUCTOR_GROUP")
+	if err != nil {
+		return limits, err
+	}
+
+	perLabel := os.Getenv("PROVISIONING_LIMIT_PER_LABEL")
+	if perLabel == "" {
+		return limits, nil
+	}
+	limits.PerLabel = make(map[string]int)
+	for entry := range strings.SplitSeq(perLabel, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			return limits, fmt.Errorf("invalid PROVISIONING_LIMIT_PER_LABEL entry %q, expected key=limit", entry)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return limits, fmt.Errorf("invalid PROVISIONING_LIMIT_PER_LABEL key %q: %s", key, strings.Join(errs, ", "))
+		}
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return limits, fmt.Errorf("invalid PROVISIONING_LIMIT_PER_LABEL limit %q for %s", value, key)
+		}
+		limits.PerLabel[key] = limit
+	}
+	return limits, nil
+}
+
+func provisioningLimitFromEnv(name string) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid value set for variable %s=%s", name, value)
+	}
+	return limit, nil
+}
+
+func (l ProvisioningLimits) isEmpty() bool {
+	return l.PerNamespace == 0 && l.PerConductorGroup == 0 && len(l.PerLabel) == 0
+}
+
+// provisioningLimitReached describes the limit preventing a host from
+// starting to (de)provision.
+type provisioningLimitReached struct {
+	reason  string
+	message string
+}
+
+// provisioningGroup is a group of hosts sharing a limit.
+type provisioningGroup struct {
+	reason      string
+	description string
+	limit       int
+	member      func(*metal3api.BareMetalHost) bool
+}
+
+// isBusy reports whether the host counts towards the provisioning limits.
+func isBusy(host *metal3api.BareMetalHost) bool {
+	if host.Status.OperationalStatus == metal3api.OperationalStatusDelayed {
+		return false
+	}
+	switch host.Status.Provisioning.State {
+	case metal3api.StateInspecting, metal3api.StateProvisioning,
+		metal3api.StateDeprovisioning:
+		return true
+	default:
+		return false
+	}
+}
+
+func conductorGroupOf(host *metal3api.BareMetalHost) string {
+	return strings.ToLower(specOrLabel(host, host.Spec.ConductorGroup, metal3api.ConductorGroupLabel))
+}
+
+// provisioningGroups returns the groups of the host that have a limit.
+func (l ProvisioningLimits) provisioningGroups(host *metal3api.BareMetalHost) (groups []provisioningGroup) {
+	if l.PerNamespace > 0 {
+		groups = append(groups, provisioningGroup{
+			reason:      metal3api.NamespaceLimitReason,
+			description: "namespace " + host.Namespace,
+			limit:       l.PerNamespace,
+			member: func(other *metal3api.BareMetalHost) bool {
+				return other.Namespace == host.Namespace
+			},
+		})
+	}
+
+	if l.PerConductorGroup > 0 {
+		conductorGroup := conductorGroupOf(host)
+		description := "the default conductor group"
+		if conductorGroup != "" {
+			description = "conductor group " + conductorGroup
+		}
+		groups = append(groups, provisioningGroup{
+			reason:      metal3api.ConductorGroupLimitReason,
+			description: description,
+			limit:       l.PerConductorGroup,
+			member: func(other *metal3api.BareMetalHost) bool {
+				return conductorGroupOf(other) == conductorGroup
+			},
+		})
+	}
+
+	keys := make([]string, 0, len(l.PerLabel))
+	for key := range l.PerLabel {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value, ok := host.Labels[key]
+		if !ok {
+			continue
+		}
+		groups = append(groups, provisioningGroup{
+			reason:      metal3api.LabelLimitReason,
+			description: fmt.Sprintf("label %s=%s", key, value),
+			limit:       l.PerLabel[key],
+			member: func(other *metal3api.BareMetalHost) bool {
+				otherValue, ok := other.Labels[key]
+				return ok && otherValue == value
+			},
+		})
+	}
+
+	return groups
+}
+
+// checkProvisioningLimits returns the first limit that the host would
+// exceed by starting to (de)provision, or nil. Hosts already busy are never
+// held back.
+func (r *BareMetalHostReconciler) checkProvisioningLimits(ctx context.Context, host *metal3api.BareMetalHost) (*provisioningLimitReached, error) {
+	if r.ProvisioningLimits.isEmpty() || isBusy(host) {
+		return nil, nil //nolint:nilnil
+	}
+
+	groups := r.ProvisioningLimits.provisioningGroups(host)
+	if len(groups) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	hosts := &metal3api.BareMetalHostList{}
+	if err := r.List(ctx, hosts); err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %w", err)
+	}
+
+	for _, group := range groups {
+		busy := 0
+		for i := range hosts.Items {
+			other := &hosts.Items[i]
+			if other.Namespace == host.Namespace && other.Name == host.Name {
+				continue
+			}
+			if isBusy(other) && group.member(other) {
+				busy++
+			}
+		}
+		if busy >= group.limit {
+			return &provisioningLimitReached{
+				reason: group.reason,
+				message: fmt.Sprintf("%d hosts are already inspecting, provisioning or deprovisioning in %s, the limit is %d",
+					busy, group.description, group.limit),
+			}, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoadProvisioningLimitsFromEnv(t *testing.T) {
+	testCases := []struct {
+		Scenario          string
+		PerNamespace      string
+		PerConductorGroup string
+		PerLabel          string
+		Expected          ProvisioningLimits
+		ExpectedError     string
+	}{
+		{
+			Scenario: "not set",
+		},
+		{
+			Scenario:          "all set",
+			PerNamespace:      "5",
+			PerConductorGroup: "10",
+			PerLabel:          "example.com/rack=2, pdu=1",
+			Expected: ProvisioningLimits{
+				PerNamespace:      5,
+				PerConductorGroup: 10,
+				PerLabel:          map[string]int{"example.com/rack": 2, "pdu": 1},
+			},
+		},
+		{
+			Scenario:      "invalid namespace limit",
+			PerNamespace:  "many",
+			ExpectedError: "PROVISIONING_LIMIT_PER_NAMESPACE",
+		},
+		{
+			Scenario:          "negative conductor group limit",
+			PerConductorGroup: "-1",
+			ExpectedError:     "PROVISIONING_LIMIT_PER_CONDUCTOR_GROUP",
+		},
+		{
+			Scenario:      "label without limit",
+			PerLabel:      "example.com/rack",
+			ExpectedError: "expected key=limit",
+		},
+		{
+			Scenario:      "invalid label key",
+			PerLabel:      "example.com/rack/row=2",
+			ExpectedError: "invalid PROVISIONING_LIMIT_PER_LABEL key",
+		},
+		{
+			Scenario:      "zero label limit",
+			PerLabel:      "example.com/rack=0",
+			ExpectedError: "invalid PROVISIONING_LIMIT_PER_LABEL limit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			t.Setenv("PROVISIONING_LIMIT_PER_NAMESPACE", tc.PerNamespace)
+			t.Setenv("PROVISIONING_LIMIT_PER_CONDUCTOR_GROUP", tc.PerConductorGroup)
+			t.Setenv("PROVISIONING_LIMIT_PER_LABEL", tc.PerLabel)
+
+			limits, err := LoadProvisioningLimitsFromEnv()
+			if tc.ExpectedError != "" {
+				require.ErrorContains(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, limits)
+		})
+	}
+}
+
+func limitedHost(name, namespace string, state metal3api.ProvisioningState, labels map[string]string) *metal3api.BareMetalHost {
+	h := host(state).build()
+	h.Name = name
+	h.Namespace = namespace
+	h.Labels = labels
+	return h
+}
+
+func TestCheckProvisioningLimits(t *testing.T) {
+	rack1 := map[string]string{"example.com/rack": "r1"}
+	rowA := map[string]string{metal3api.ConductorGroupLabel: "Row-A"}
+
+	testCases := []struct {
+		Scenario       string
+		Limits         ProvisioningLimits
+		Host           *metal3api.BareMetalHost
+		Others         []*metal3api.BareMetalHost
+		ExpectedReason string
+	}{
+		{
+			Scenario: "no limits",
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, nil),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns1", metal3api.StateProvisioning, nil),
+			},
+		},
+		{
+			Scenario: "namespace limit reached",
+			Limits:   ProvisioningLimits{PerNamespace: 1},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, nil),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns1", metal3api.StateInspecting, nil),
+			},
+			ExpectedReason: metal3api.NamespaceLimitReason,
+		},
+		{
+			Scenario: "namespace limit in another namespace",
+			Limits:   ProvisioningLimits{PerNamespace: 1},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, nil),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns2", metal3api.StateProvisioning, nil),
+				limitedHost("idle", "ns1", metal3api.StateProvisioned, nil),
+			},
+		},
+		{
+			Scenario: "host already busy",
+			Limits:   ProvisioningLimits{PerNamespace: 1},
+			Host:     limitedHost("host", "ns1", metal3api.StateProvisioning, nil),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns1", metal3api.StateProvisioning, nil),
+			},
+		},
+		{
+			Scenario: "label limit reached",
+			Limits:   ProvisioningLimits{PerLabel: map[string]int{"example.com/rack": 1}},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, rack1),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns2", metal3api.StateDeprovisioning, rack1),
+			},
+			ExpectedReason: metal3api.LabelLimitReason,
+		},
+		{
+			Scenario: "label limit with another value",
+			Limits:   ProvisioningLimits{PerLabel: map[string]int{"example.com/rack": 1}},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, rack1),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns1", metal3api.StateProvisioning, map[string]string{"example.com/rack": "r2"}),
+			},
+		},
+		{
+			Scenario: "label limit without the label",
+			Limits:   ProvisioningLimits{PerLabel: map[string]int{"example.com/rack": 1}},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, nil),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns1", metal3api.StateProvisioning, rack1),
+			},
+		},
+		{
+			Scenario: "conductor group limit reached",
+			Limits:   ProvisioningLimits{PerConductorGroup: 1},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, rowA),
+			Others: []*metal3api.BareMetalHost{
+				limitedHost("other", "ns2", metal3api.StateProvisioning, map[string]string{metal3api.ConductorGroupLabel: "row-a"}),
+			},
+			ExpectedReason: metal3api.ConductorGroupLimitReason,
+		},
+		{
+			Scenario: "delayed hosts are not busy",
+			Limits:   ProvisioningLimits{PerNamespace: 1},
+			Host:     limitedHost("host", "ns1", metal3api.StateAvailable, nil),
+			Others: func() []*metal3api.BareMetalHost {
+				other := limitedHost("other", "ns1", metal3api.StateDeprovisioning, nil)
+				other.Status.OperationalStatus = metal3api.OperationalStatusDelayed
+				return []*metal3api.BareMetalHost{other}
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			clientBuilder := fakeclient.NewClientBuilder().WithObjects(tc.Host)
+			for _, other := range tc.Others {
+				clientBuilder = clientBuilder.WithObjects(other)
+			}
+			reconciler := &BareMetalHostReconciler{
+				Client:             clientBuilder.Build(),
+				Log:                ctrl.Log.WithName("provisioning_limits"),
+				ProvisioningLimits: tc.Limits,
+			}
+
+			limit, err := reconciler.checkProvisioningLimits(t.Context(), tc.Host)
+			require.NoError(t, err)
+			if tc.ExpectedReason == "" {
+				assert.Nil(t, limit)
+				return
+			}
+			require.NotNil(t, limit)
+			assert.Equal(t, tc.ExpectedReason, limit.reason)
+		})
+	}
+}
+
+func TestProvisioningDelayedByLimit(t *testing.T) {
+	testHost := limitedHost("host", "ns1", metal3api.StateAvailable, nil)
+	testHost.Spec.Image = &metal3api.Image{URL: "not-empty"}
+	other := limitedHost("other", "ns1", metal3api.StateProvisioning, nil)
+
+	reconciler := &BareMetalHostReconciler{
+		Client:             fakeclient.NewClientBuilder().WithObjects(testHost, other).Build(),
+		Log:                ctrl.Log.WithName("provisioning_limits"),
+		ProvisioningLimits: ProvisioningLimits{PerNamespace: 1},
+	}
+	prov := newMockProvisioner()
+	prov.setHasCapacity(true)
+	hsm := newHostStateMachine(testHost, reconciler, prov, true)
+	info := makeDefaultReconcileInfo(testHost)
+
+	result := hsm.ReconcileState(t.Context(), info)
+
+	assert.Equal(t, actionDelayed{}, result)
+	assert.Equal(t, metal3api.StateAvailable, testHost.Status.Provisioning.State)
+	assert.EqualValues(t, metal3api.OperationalStatusDelayed, testHost.Status.OperationalStatus)
+	cond := conditions.Get(testHost, metal3api.ProvisioningDelayedCondition)
+	require.NotNil(t, cond)
+	assert.Equal(t, metal3api.NamespaceLimitReason, cond.Reason)
+	assert.Equal(t, "1 hosts are already inspecting, provisioning or deprovisioning in namespace ns1, the limit is 1", cond.Message)
+
+	// The condition goes away once the host is no longer delayed
+	testHost.Status.OperationalStatus = metal3api.OperationalStatusOK
+	computeConditions(t.Context(), testHost, prov)
+	assert.Nil(t, conditions.Get(testHost, metal3api.ProvisioningDelayedCondition))
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newReplayHost(t *testing.T, name string) *metal3api.BareMetalHost {
+	t.Helper()
+	host := newDefaultNamedHost(t, name)
+	host.Spec.Image = &metal3api.Image{
+		URL:      "https://example.com/image-name",
+		Checksum: "12345",
+	}
+	host.Spec.Online = true
+	return host
+}
+
+// newReplayReconciler returns a reconciler replaying calls, and checks at
+// the end of the test that they were all made as recorded.
+func newReplayReconciler(t *testing.T, calls []replay.Call, host *metal3api.BareMetalHost) *BareMetalHostReconciler {
+	t.Helper()
+	replayer, err := replay.NewReplayer(t.Context(), "fixture", calls, ctrl.Log.WithName("provisioner").WithName("replay"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = replayer.Close()
+		assert.NoError(t, replayer.Err())
+		if remaining := replayer.Remaining(); len(remaining) > 0 {
+			t.Errorf("%d recorded calls were not made, the first one is %s", len(remaining), remaining[0].Method)
+		}
+	})
+
+	r := newTestReconciler(t, host)
+	r.ProvisionerFactory = replayer
+	return r
+}
+
+func loadRecording(t *testing.T, name string) []replay.Call {
+	t.Helper()
+	calls, err := replay.LoadFile(filepath.Join("testdata", "replay", name+".jsonl"))
+	require.NoError(t, err)
+	return calls
+}
+
+// TestReplayProvision replays the provisioning of a host by the fixture
+// provisioner.
+func TestReplayProvision(t *testing.T) {
+	host := newReplayHost(t, "provision")
+	r := newReplayReconciler(t, loadRecording(t, "provision"), host)
+
+	tryReconcile(t, r, host,
+		func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+			return host.Status.Provisioning.State == metal3api.StateProvisioned &&
+				host.Status.Provisioning.Image.URL != ""
+		},
+	)
+}
+
+// TestReplayProvisionFailure replays a recording edited so that writing
+// the image fails.
+func TestReplayProvisionFailure(t *testing.T) {
+	host := newReplayHost(t, "provision-failure")
+	r := newReplayReconciler(t, loadRecording(t, "provision-failure"), host)
+
+	tryReconcile(t, r, host,
+		func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+			return host.Status.ErrorType != ""
+		},
+	)
+
+	assert.Equal(t, metal3api.StateProvisioning, host.Status.Provisioning.State)
+	assert.Equal(t, metal3api.ProvisioningError, host.Status.ErrorType)
+	assert.Contains(t, host.Status.ErrorMessage, "checksum mismatch")
+}
+
+// TestRecordAndReplay checks that what the controller does with the
+// fixture provisioner can be replayed without it.
+func TestRecordAndReplay(t *testing.T) {
+	isProvisioned := func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.Provisioning.State == metal3api.StateProvisioned
+	}
+
+	recording := &bytes.Buffer{}
+	host := newReplayHost(t, "record")
+	r := newTestReconciler(t, host)
+	recorder, err := replay.NewRecorder(t.Context(), "fixture", &fixture.Fixture{}, recording,
+		provisioner.PluginConfig{Logger: ctrl.Log.WithName("provisioner").WithName("recorder")})
+	require.NoError(t, err)
+	r.ProvisionerFactory = recorder
+	tryReconcile(t, r, host, isProvisioned)
+	require.NoError(t, recorder.Close())
+
+	calls, err := replay.ReadCalls(recording)
+	require.NoError(t, err)
+	require.NotEmpty(t, calls)
+
+	host = newReplayHost(t, "record")
+	r = newReplayReconciler(t, calls, host)
+	tryReconcile(t, r, host, isProvisioned)
+}
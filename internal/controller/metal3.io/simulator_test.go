@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// newSimulatorReconciler returns a reconciler using the Ironic provisioner
+// against an Ironic simulator.
+func newSimulatorReconciler(t *testing.T, host *metal3api.BareMetalHost) (*BareMetalHostReconciler, *testserver.IronicSimulator) {
+	t.Helper()
+	simulator := testserver.NewIronicSimulator(t)
+	simulator.Start()
+	t.Cleanup(simulator.Stop)
+
+	t.Setenv("IRONIC_ENDPOINT", simulator.Endpoint())
+	t.Setenv("DEPLOY_KERNEL_URL", "http://deploy.test/ironic-python-agent.kernel")
+	t.Setenv("DEPLOY_RAMDISK_URL", "http://deploy.test/ironic-python-agent.initramfs")
+	factory, err := ironic.NewProvisionerFactory(ctrl.Log.WithName("provisioner"), false)
+	require.NoError(t, err)
+
+	r := newTestReconciler(t, host)
+	r.ProvisionerFactory = factory
+	return r, simulator
+}
+
+func newSimulatorHost(t *testing.T, name string) *metal3api.BareMetalHost {
+	t.Helper()
+	host := newDefaultNamedHost(t, name)
+	host.Spec.BootMACAddress = "52:54:00:12:34:56"
+	host.Spec.RootDeviceHints = nil
+	host.Spec.Online = true
+	return host
+}
+
+func hostInState(state metal3api.ProvisioningState) DoneFunc {
+	return func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.Provisioning.State == state
+	}
+}
+
+// TestSimulatorLifecycle drives a host from registration to provisioning
+// and back through the Ironic provisioner.
+func TestSimulatorLifecycle(t *testing.T) {
+	host := newSimulatorHost(t, "lifecycle")
+	r, simulator := newSimulatorReconciler(t, host)
+
+	tryReconcile(t, r, host, hostInState(metal3api.StateAvailable))
+	require.NotNil(t, host.Status.HardwareDetails)
+	assert.Equal(t, 8, host.Status.HardwareDetails.CPU.Count)
+	require.Len(t, host.Status.HardwareDetails.NIC, 1)
+	assert.Equal(t, host.Spec.BootMACAddress, host.Status.HardwareDetails.NIC[0].MAC)
+
+	node, found := simulator.GetNode(host.Status.Provisioning.ID)
+	require.True(t, found)
+	assert.Equal(t, string(nodes.Manageable), node.ProvisionState)
+	assert.Equal(t, "test-namespace~lifecycle", node.Name)
+
+	host.Spec.Image = &metal3api.Image{
+		URL:          "http://image.test/image.qcow2",
+		Checksum:     "http://image.test/image.qcow2.sha256sum",
+		ChecksumType: metal3api.AutoChecksum,
+	}
+	require.NoError(t, r.Update(t.Context(), host))
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.Provisioning.State == metal3api.StateProvisioned && host.Status.PoweredOn
+	})
+
+	node, _ = simulator.GetNode(host.Status.Provisioning.ID)
+	assert.Equal(t, string(nodes.Active), node.ProvisionState)
+	assert.Equal(t, "http://image.test/image.qcow2", node.InstanceInfo["image_source"])
+
+	host.Spec.Image = nil
+	require.NoError(t, r.Update(t.Context(), host))
+	tryReconcile(t, r, host, hostInState(metal3api.StateAvailable))
+
+	node, _ = simulator.GetNode(host.Status.Provisioning.ID)
+	assert.Equal(t, string(nodes.Available), node.ProvisionState)
+	assert.Empty(t, node.InstanceInfo)
+}
+
+// TestSimulatorDeployFailure checks that a failed deployment is reported
+// on the host.
+func TestSimulatorDeployFailure(t *testing.T) {
+	host := newSimulatorHost(t, "deploy-failure")
+	host.Spec.Image = &metal3api.Image{
+		URL:          "http://image.test/image.qcow2",
+		Checksum:     "http://image.test/image.qcow2.sha256sum",
+		ChecksumType: metal3api.AutoChecksum,
+	}
+	r, simulator := newSimulatorReconciler(t, host)
+	simulator.FailNext("test-namespace~deploy-failure", nodes.TargetActive, "no bootable device found")
+
+	tryReconcile(t, r, host, func(host *metal3api.BareMetalHost, result reconcile.Result) bool {
+		return host.Status.ErrorType != ""
+	})
+
+	assert.Equal(t, metal3api.ProvisioningError, host.Status.ErrorType)
+	assert.Contains(t, host.Status.ErrorMessage, "no bootable device found")
+}
@@ -0,0 +1,308 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SwitchPortDiscoveryReconciler correlates the LLDP data of inspected NICs
+// with BareMetalSwitch resources and publishes the result in the status of
+// the HardwareData.
+type SwitchPortDiscoveryReconciler struct {
+	client.Client
+	Log logr.Logger
+}
+
+//+kubebuilder:rbac:groups=metal3.io,resources=hardwaredata,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=hardwaredata/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=metal3.io,resources=baremetalswitches,verbs=get;list;watch
+
+// Reconcile resolves the switch port of each NIC of a host to its
+// BareMetalSwitch.
+func (r *SwitchPortDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("hardwaredata", req.NamespacedName)
+	logger.Info("start")
+
+	hardwareData := &metal3api.HardwareData{}
+	if err := r.Get(ctx, req.NamespacedName, hardwareData); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("could not load HardwareData: %w", err)
+	}
+
+	// The HardwareData has the same name as its host. It may exist before
+	// the host when inspection data is provided externally.
+	host := &metal3api.BareMetalHost{}
+	if err := r.Get(ctx, req.NamespacedName, host); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get BareMetalHost %s: %w", req.NamespacedName, err)
+		}
+		host = nil
+	}
+
+	switchList := &metal3api.BareMetalSwitchList{}
+	if err := r.List(ctx, switchList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list BareMetalSwitch resources: %w", err)
+	}
+
+	ports, mismatched := discoverSwitchPorts(hardwareData, host, newSwitchIndex(switchList.Items, req.Namespace))
+
+	newStatus := metal3api.HardwareDataStatus{
+		SwitchPorts: ports,
+		Conditions:  append([]metav1.Condition(nil), hardwareData.Status.Conditions...),
+	}
+	cond := switchPortsCondition(ports, mismatched)
+	cond.ObservedGeneration = hardwareData.Generation
+	meta.SetStatusCondition(&newStatus.Conditions, cond)
+
+	if equality.Semantic.DeepEqual(hardwareData.Status, newStatus) {
+		logger.Info("done")
+		return ctrl.Result{}, nil
+	}
+
+	hardwareData.Status = newStatus
+	if err := r.Status().Update(ctx, hardwareData); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update HardwareData status: %w", err)
+	}
+
+	logger.Info("updated status", "switchPorts", len(ports), "reason", cond.Reason)
+	return ctrl.Result{}, nil
+}
+
+// normalizeMAC returns the canonical lowercase, colon-separated form of a
+// MAC address, or an empty string if the value is not a MAC address. LLDP
+// chassis IDs may use other notations or not be MAC addresses at all.
+func normalizeMAC(value string) string {
+	mac, err := net.ParseMAC(value)
+	if err != nil {
+		return ""
+	}
+	return mac.String()
+}
+
+// switchIndex maps normalized switch MAC addresses to BareMetalSwitches.
+type switchIndex map[string]metal3api.SwitchReference
+
+// newSwitchIndex indexes switches by MAC address. If switches in several
+// namespaces share a MAC address, the one in the preferred namespace wins,
+// otherwise the first one by namespace.
+func newSwitchIndex(switches []metal3api.BareMetalSwitch, preferredNamespace string) switchIndex {
+	slices.SortFunc(switches, func(a, b metal3api.BareMetalSwitch) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+
+	index := switchIndex{}
+	for _, preferred := range []bool{true, false} {
+		for i := range switches {
+			mac := normalizeMAC(switches[i].Spec.MACAddress)
+			if _, exists := index[mac]; mac == "" || exists ||
+				(switches[i].Namespace == preferredNamespace) != preferred {
+				continue
+			}
+			index[mac] = metal3api.SwitchReference{
+				Name:      switches[i].Name,
+				Namespace: switches[i].Namespace,
+			}
+		}
+	}
+	return index
+}
+
+// hostSwitchPort returns the switch port given on the host for a NIC,
+// looking up network interfaces by MAC address or name.
+func hostSwitchPort(host *metal3api.BareMetalHost, nic *metal3api.NIC) *metal3api.SwitchPort {
+	if host == nil {
+		return nil
+	}
+	for i := range host.Spec.NetworkInterfaces {
+		iface := &host.Spec.NetworkInterfaces[i]
+		if iface.SwitchPort == nil {
+			continue
+		}
+		if (iface.MACAddress != "" && strings.EqualFold(iface.MACAddress, nic.MAC)) ||
+			(iface.MACAddress == "" && iface.Name == nic.Name) {
+			return iface.SwitchPort
+		}
+	}
+	return nil
+}
+
+// discoverSwitchPorts returns the switch port of each NIC that has LLDP data
+// or a switch port given on the host, resolved to a BareMetalSwitch. The
+// names of NICs whose switch port given on the host disagrees with LLDP are
+// returned in mismatched.
+func discoverSwitchPorts(hardwareData *metal3api.HardwareData, host *metal3api.BareMetalHost, switches switchIndex) (ports []metal3api.NICSwitchPort, mismatched []string) {
+	if hardwareData.Spec.HardwareDetails == nil {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	for i := range hardwareData.Spec.HardwareDetails.NIC {
+		nic := &hardwareData.Spec.HardwareDetails.NIC[i]
+
+		// Dual-stack hosts report a NIC once per IP address
+		mac := strings.ToLower(nic.MAC)
+		if seen[mac] {
+			continue
+		}
+		seen[mac] = true
+
+		lldp := nic.LLDP
+		if lldp != nil && lldp.SwitchID == "" && lldp.PortID == "" {
+			lldp = nil
+		}
+		override := hostSwitchPort(host, nic)
+		if lldp == nil && override == nil {
+			continue
+		}
+
+		port := metal3api.NICSwitchPort{
+			Name:       nic.Name,
+			MACAddress: mac,
+		}
+		var warnings []string
+		if override != nil {
+			port.SwitchID = override.SwitchID
+			port.PortID = override.PortID
+			port.Source = metal3api.SwitchPortSourceBareMetalHost
+			if lldp != nil && (normalizeMAC(lldp.SwitchID) != normalizeMAC(override.SwitchID) ||
+				!strings.EqualFold(lldp.PortID, override.PortID)) {
+				warnings = append(warnings, fmt.Sprintf("LLDP reports switch %s port %s",
+					lldp.SwitchID, lldp.PortID))
+				mismatched = append(mismatched, nic.Name)
+			}
+		} else {
+			port.SwitchID = lldp.SwitchID
+			port.PortID = lldp.PortID
+			port.Source = metal3api.SwitchPortSourceLLDP
+		}
+
+		if ref, found := switches[normalizeMAC(port.SwitchID)]; found {
+			port.Switch = &ref
+		} else {
+			unknown := "no BareMetalSwitch with MAC address " + port.SwitchID
+			if lldp != nil && lldp.SwitchSystemName != "" && port.Source == metal3api.SwitchPortSourceLLDP {
+				unknown += " (" + lldp.SwitchSystemName + ")"
+			}
+			// The unknown switch comes first as it prevents configuring
+			// the port at all.
+			warnings = append([]string{unknown}, warnings...)
+		}
+		port.Warning = strings.Join(warnings, "; ")
+
+		ports = append(ports, port)
+	}
+	return ports, mismatched
+}
+
+// switchPortsCondition summarizes the discovered switch ports in the
+// SwitchPortsResolved condition. A disagreement between the host and LLDP
+// is reported over an unknown switch because it most likely means the host
+// is cabled differently than configured.
+func switchPortsCondition(ports []metal3api.NICSwitchPort, mismatched []string) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    string(metal3api.SwitchPortsResolvedCondition),
+		Status:  metav1.ConditionTrue,
+		Reason:  metal3api.SwitchPortsResolvedReason,
+		Message: fmt.Sprintf("%d switch port(s) resolved", len(ports)),
+	}
+	if len(ports) == 0 {
+		cond.Reason = metal3api.SwitchPortsNotFoundReason
+		cond.Message = "No NIC has LLDP data or a switch port given on the BareMetalHost"
+		return cond
+	}
+
+	var unknown []string
+	for _, port := range ports {
+		if port.Switch == nil {
+			unknown = append(unknown, port.Name)
+		}
+	}
+
+	switch {
+	case len(mismatched) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = metal3api.SwitchPortMismatchReason
+		cond.Message = "Switch port given on the BareMetalHost disagrees with LLDP for NIC(s): " + strings.Join(mismatched, ", ")
+	case len(unknown) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = metal3api.UnknownSwitchReason
+		cond.Message = "No BareMetalSwitch found for NIC(s): " + strings.Join(unknown, ", ")
+	}
+	return cond
+}
+
+// switchToHardwareData maps a BareMetalSwitch to all HardwareData, since
+// any host may be connected to it.
+func (r *SwitchPortDiscoveryReconciler) switchToHardwareData(ctx context.Context, _ client.Object) []reconcile.Request {
+	hardwareDataList := &metal3api.HardwareDataList{}
+	if err := r.List(ctx, hardwareDataList); err != nil {
+		r.Log.Error(err, "failed to list HardwareData")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(hardwareDataList.Items))
+	for i := range hardwareDataList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: hardwareDataList.Items[i].Namespace,
+				Name:      hardwareDataList.Items[i].Name,
+			},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager registers the reconciler to be run by the manager.
+func (r *SwitchPortDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconcile int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("switchportdiscovery").
+		For(&metal3api.HardwareData{}).
+		// The HardwareData has the same name as its host. Only the network
+		// interfaces in the spec of hosts are used, status updates are
+		// ignored.
+		Watches(&metal3api.BareMetalHost{}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Status updates of switches, such as probe results, do not change
+		// the ports hosts can be connected to.
+		Watches(&metal3api.BareMetalSwitch{}, handler.EnqueueRequestsFromMapFunc(r.switchToHardwareData),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconcile}).
+		Complete(r)
+}
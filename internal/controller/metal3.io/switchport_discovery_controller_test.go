@@ -0,0 +1,282 @@
+package controllers
+
+import (
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestSwitch(name, namespace, mac string) metal3api.BareMetalSwitch {
+	return metal3api.BareMetalSwitch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: metal3api.BareMetalSwitchSpec{
+			Address:    "192.0.2.1",
+			MACAddress: mac,
+			DeviceType: "netmiko_cisco_ios",
+		},
+	}
+}
+
+func TestNewSwitchIndex(t *testing.T) {
+	index := newSwitchIndex([]metal3api.BareMetalSwitch{
+		newTestSwitch("leaf1", "other", "AA:BB:CC:DD:EE:01"),
+		newTestSwitch("leaf1", namespace, "aa:bb:cc:dd:ee:01"),
+		newTestSwitch("leaf2", "other", "aa:bb:cc:dd:ee:02"),
+		newTestSwitch("leaf2", "another", "aa:bb:cc:dd:ee:02"),
+	}, namespace)
+
+	assert.Equal(t, switchIndex{
+		"aa:bb:cc:dd:ee:01": {Name: "leaf1", Namespace: namespace},
+		"aa:bb:cc:dd:ee:02": {Name: "leaf2", Namespace: "another"},
+	}, index)
+}
+
+func TestDiscoverSwitchPorts(t *testing.T) {
+	switches := switchIndex{
+		"aa:bb:cc:dd:ee:01": {Name: "leaf1", Namespace: namespace},
+		"aa:bb:cc:dd:ee:02": {Name: "leaf2", Namespace: namespace},
+	}
+
+	testCases := []struct {
+		Scenario           string
+		NICs               []metal3api.NIC
+		NetworkInterfaces  []metal3api.NetworkInterface
+		ExpectedPorts      []metal3api.NICSwitchPort
+		ExpectedMismatched []string
+	}{
+		{
+			Scenario: "no LLDP",
+			NICs: []metal3api.NIC{
+				{Name: "eth0", MAC: "00:11:22:33:44:55"},
+				{Name: "eth1", MAC: "00:11:22:33:44:56", LLDP: &metal3api.LLDP{}},
+			},
+		},
+		{
+			Scenario: "LLDP with known switches",
+			NICs: []metal3api.NIC{
+				{Name: "eth0", MAC: "00:11:22:33:44:55", LLDP: &metal3api.LLDP{SwitchID: "AA-BB-CC-DD-EE-01", PortID: "Ethernet1/1"}},
+				{Name: "eth0", MAC: "00:11:22:33:44:55", IP: "2001:db8::1", LLDP: &metal3api.LLDP{SwitchID: "AA-BB-CC-DD-EE-01", PortID: "Ethernet1/1"}},
+				{Name: "eth1", MAC: "00:11:22:33:44:56", LLDP: &metal3api.LLDP{SwitchID: "aa:bb:cc:dd:ee:02", PortID: "Ethernet1/1"}},
+			},
+			ExpectedPorts: []metal3api.NICSwitchPort{
+				{
+					Name:       "eth0",
+					MACAddress: "00:11:22:33:44:55",
+					SwitchID:   "AA-BB-CC-DD-EE-01",
+					PortID:     "Ethernet1/1",
+					Source:     metal3api.SwitchPortSourceLLDP,
+					Switch:     &metal3api.SwitchReference{Name: "leaf1", Namespace: namespace},
+				},
+				{
+					Name:       "eth1",
+					MACAddress: "00:11:22:33:44:56",
+					SwitchID:   "aa:bb:cc:dd:ee:02",
+					PortID:     "Ethernet1/1",
+					Source:     metal3api.SwitchPortSourceLLDP,
+					Switch:     &metal3api.SwitchReference{Name: "leaf2", Namespace: namespace},
+				},
+			},
+		},
+		{
+			Scenario: "LLDP with unknown switch",
+			NICs: []metal3api.NIC{
+				{Name: "eth0", MAC: "00:11:22:33:44:55", LLDP: &metal3api.LLDP{SwitchID: "aa:bb:cc:dd:ee:99", PortID: "Ethernet1/1", SwitchSystemName: "spine1"}},
+			},
+			ExpectedPorts: []metal3api.NICSwitchPort{
+				{
+					Name:       "eth0",
+					MACAddress: "00:11:22:33:44:55",
+					SwitchID:   "aa:bb:cc:dd:ee:99",
+					PortID:     "Ethernet1/1",
+					Source:     metal3api.SwitchPortSourceLLDP,
+					Warning:    "no BareMetalSwitch with MAC address aa:bb:cc:dd:ee:99 (spine1)",
+				},
+			},
+		},
+		{
+			Scenario: "host switch port without LLDP",
+			NICs: []metal3api.NIC{
+				{Name: "eth0", MAC: "00:11:22:33:44:55"},
+			},
+			NetworkInterfaces: []metal3api.NetworkInterface{
+				{Name: "eth0", SwitchPort: &metal3api.SwitchPort{SwitchID: "aa:bb:cc:dd:ee:01", PortID: "Ethernet1/2"}},
+			},
+			ExpectedPorts: []metal3api.NICSwitchPort{
+				{
+					Name:       "eth0",
+					MACAddress: "00:11:22:33:44:55",
+					SwitchID:   "aa:bb:cc:dd:ee:01",
+					PortID:     "Ethernet1/2",
+					Source:     metal3api.SwitchPortSourceBareMetalHost,
+					Switch:     &metal3api.SwitchReference{Name: "leaf1", Namespace: namespace},
+				},
+			},
+		},
+		{
+			Scenario: "host switch port agrees with LLDP",
+			NICs: []metal3api.NIC{
+				{Name: "eth0", MAC: "00:11:22:33:44:55", LLDP: &metal3api.LLDP{SwitchID: "aa:bb:cc:dd:ee:01", PortID: "ethernet1/2"}},
+			},
+			NetworkInterfaces: []metal3api.NetworkInterface{
+				{MACAddress: "00:11:22:33:44:55", SwitchPort: &metal3api.SwitchPort{SwitchID: "AA:BB:CC:DD:EE:01", PortID: "Ethernet1/2"}},
+			},
+			ExpectedPorts: []metal3api.NICSwitchPort{
+				{
+					Name:       "eth0",
+					MACAddress: "00:11:22:33:44:55",
+					SwitchID:   "AA:BB:CC:DD:EE:01",
+					PortID:     "Ethernet1/2",
+					Source:     metal3api.SwitchPortSourceBareMetalHost,
+					Switch:     &metal3api.SwitchReference{Name: "leaf1", Namespace: namespace},
+				},
+			},
+		},
+		{
+			Scenario: "host switch port disagrees with LLDP",
+			NICs: []metal3api.NIC{
+				{Name: "eth0", MAC: "00:11:22:33:44:55", LLDP: &metal3api.LLDP{SwitchID: "aa:bb:cc:dd:ee:02", PortID: "Ethernet1/1"}},
+				{Name: "eth1", MAC: "00:11:22:33:44:56", LLDP: &metal3api.LLDP{SwitchID: "aa:bb:cc:dd:ee:01", PortID: "Ethernet1/1"}},
+			},
+			NetworkInterfaces: []metal3api.NetworkInterface{
+				{Name: "eth0", SwitchPort: &metal3api.SwitchPort{SwitchID: "aa:bb:cc:dd:ee:03", PortID: "Ethernet1/1"}},
+				{Name: "eth1", SwitchPort: &metal3api.SwitchPort{SwitchID: "aa:bb:cc:dd:ee:01", PortID: "Ethernet1/5"}},
+			},
+			ExpectedPorts: []metal3api.NICSwitchPort{
+				{
+					Name:       "eth0",
+					MACAddress: "00:11:22:33:44:55",
+					SwitchID:   "aa:bb:cc:dd:ee:03",
+					PortID:     "Ethernet1/1",
+					Source:     metal3api.SwitchPortSourceBareMetalHost,
+					Warning:    "no BareMetalSwitch with MAC address aa:bb:cc:dd:ee:03; LLDP reports switch aa:bb:cc:dd:ee:02 port Ethernet1/1",
+				},
+				{
+					Name:       "eth1",
+					MACAddress: "00:11:22:33:44:56",
+					SwitchID:   "aa:bb:cc:dd:ee:01",
+					PortID:     "Ethernet1/5",
+					Source:     metal3api.SwitchPortSourceBareMetalHost,
+					Switch:     &metal3api.SwitchReference{Name: "leaf1", Namespace: namespace},
+					Warning:    "LLDP reports switch aa:bb:cc:dd:ee:01 port Ethernet1/1",
+				},
+			},
+			ExpectedMismatched: []string{"eth0", "eth1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := newHost("myhost", &metal3api.BareMetalHostSpec{
+				NetworkInterfaces: tc.NetworkInterfaces,
+			})
+			hardwareData := newNetworkHardwareData(host)
+			hardwareData.Spec.HardwareDetails.NIC = tc.NICs
+
+			ports, mismatched := discoverSwitchPorts(hardwareData, host, switches)
+			assert.Equal(t, tc.ExpectedPorts, ports)
+			assert.Equal(t, tc.ExpectedMismatched, mismatched)
+		})
+	}
+}
+
+func TestSwitchPortsCondition(t *testing.T) {
+	resolved := metal3api.NICSwitchPort{Name: "eth0", Switch: &metal3api.SwitchReference{Name: "leaf1"}}
+	unknown := metal3api.NICSwitchPort{Name: "eth1"}
+
+	testCases := []struct {
+		Scenario       string
+		Ports          []metal3api.NICSwitchPort
+		Mismatched     []string
+		ExpectedStatus metav1.ConditionStatus
+		ExpectedReason string
+	}{
+		{
+			Scenario:       "no switch ports",
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: metal3api.SwitchPortsNotFoundReason,
+		},
+		{
+			Scenario:       "resolved",
+			Ports:          []metal3api.NICSwitchPort{resolved},
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: metal3api.SwitchPortsResolvedReason,
+		},
+		{
+			Scenario:       "unknown switch",
+			Ports:          []metal3api.NICSwitchPort{resolved, unknown},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: metal3api.UnknownSwitchReason,
+		},
+		{
+			Scenario:       "mismatch takes precedence",
+			Ports:          []metal3api.NICSwitchPort{resolved, unknown},
+			Mismatched:     []string{"eth0"},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: metal3api.SwitchPortMismatchReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			cond := switchPortsCondition(tc.Ports, tc.Mismatched)
+			assert.Equal(t, string(metal3api.SwitchPortsResolvedCondition), cond.Type)
+			assert.Equal(t, tc.ExpectedStatus, cond.Status)
+			assert.Equal(t, tc.ExpectedReason, cond.Reason)
+		})
+	}
+}
+
+func TestSwitchPortDiscoveryReconcile(t *testing.T) {
+	host := newHost("myhost", &metal3api.BareMetalHostSpec{})
+	hardwareData := newNetworkHardwareData(host)
+	hardwareData.Spec.HardwareDetails.NIC[0].LLDP = &metal3api.LLDP{SwitchID: "aa:bb:cc:dd:ee:01", PortID: "Ethernet1/1"}
+	leaf1 := newTestSwitch("leaf1", namespace, "aa:bb:cc:dd:ee:01")
+
+	c := fakeclient.NewClientBuilder().
+		WithObjects(host, hardwareData, &leaf1).
+		WithStatusSubresource(&metal3api.HardwareData{}).
+		Build()
+	r := &SwitchPortDiscoveryReconciler{
+		Client: c,
+		Log:    ctrl.Log.WithName("controllers").WithName("SwitchPortDiscovery"),
+	}
+
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hardwareData)})
+	require.NoError(t, err)
+
+	updated := &metal3api.HardwareData{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(hardwareData), updated))
+	require.Len(t, updated.Status.SwitchPorts, 1)
+	assert.Equal(t, &metal3api.SwitchReference{Name: "leaf1", Namespace: namespace}, updated.Status.SwitchPorts[0].Switch)
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, string(metal3api.SwitchPortsResolvedCondition)))
+
+	// Deleting the switch leaves the port unresolved
+	require.NoError(t, c.Delete(t.Context(), &leaf1))
+	requests := r.switchToHardwareData(t.Context(), &leaf1)
+	require.Len(t, requests, 1)
+	_, err = r.Reconcile(t.Context(), requests[0])
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(hardwareData), updated))
+	require.Len(t, updated.Status.SwitchPorts, 1)
+	assert.Nil(t, updated.Status.SwitchPorts[0].Switch)
+	cond := meta.FindStatusCondition(updated.Status.Conditions, string(metal3api.SwitchPortsResolvedCondition))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, metal3api.UnknownSwitchReason, cond.Reason)
+
+	// A HardwareData without a host is still resolved
+	require.NoError(t, c.Delete(t.Context(), host))
+	_, err = r.Reconcile(t.Context(), requests[0])
+	require.NoError(t, err)
+}
@@ -201,6 +201,11 @@ func (hb *HardwareDataBuilder) SetLabels(labels map[string]string) *HardwareData
 	return hb
 }
 
+func (hb *HardwareDataBuilder) SetHardwareDetails(details *metal3api.HardwareDetails) *HardwareDataBuilder {
+	hb.hardwareData.Spec.HardwareDetails = details
+	return hb
+}
+
 func (hb *HardwareDataBuilder) Build() *metal3api.HardwareData {
 	return &hb.hardwareData
 }
@@ -291,6 +296,16 @@ func (hb *HostClaimBuilder) SetFailureDomain(fd string) *HostClaimBuilder {
 	return hb
 }
 
+func (hb *HostClaimBuilder) SetSelectionStrategy(strategy metal3api.HostSelectionStrategy) *HostClaimBuilder {
+	hb.hostClaim.Spec.SelectionStrategy = strategy
+	return hb
+}
+
+func (hb *HostClaimBuilder) SetHardwareRequirements(requirements metal3api.HardwareRequirements) *HostClaimBuilder {
+	hb.hostClaim.Spec.HardwareRequirements = &requirements
+	return hb
+}
+
 func (hb *HostClaimBuilder) SetTargetNamespace(ns string) *HostClaimBuilder {
 	hb.hostClaim.Spec.HostSelector.InNamespace = ns
 	return hb
@@ -365,3 +380,8 @@ func (hb *HostDeployPolicyBuilder) AcceptRegexp(re string) *HostDeployPolicyBuil
 	spec.HostClaimNamespaces.NameMatches = re
 	return hb
 }
+
+func (hb *HostDeployPolicyBuilder) SetQuotas(quotas []metal3api.HostQuota) *HostDeployPolicyBuilder {
+	hb.hostDeployPolicy.Spec.Quotas = quotas
+	return hb
+}
@@ -120,6 +120,18 @@ func (webhook *BareMetalHost) validateChanges(oldObj *metal3api.BareMetalHost, n
 		errs = append(errs, errors.New("BMC address can not be changed if the BMH is not in the Registering state, or if the BMH is not detached"))
 	}
 
+	// The provisioner ID of the host is only meaningful to the provisioner,
+	// and to the shard of it, that registered the host
+	for _, label := range []string{metal3api.ProvisionerLabel, metal3api.ShardLabel} {
+		if oldObj.Labels[label] != newObj.Labels[label] &&
+			oldObj.Status.Provisioning.ID != "" &&
+			oldObj.Status.OperationalStatus != metal3api.OperationalStatusDetached &&
+			newObj.Status.OperationalStatus != metal3api.OperationalStatusDetached {
+			errs = append(errs, fmt.Errorf("label %s can not be changed once the host is registered, unless the BMH is detached",
+				label))
+		}
+	}
+
 	if oldObj.Spec.BootMACAddress != "" && !strings.EqualFold(newObj.Spec.BootMACAddress, oldObj.Spec.BootMACAddress) {
 		errs = append(errs, errors.New("bootMACAddress can not be changed once it is set"))
 	}
@@ -169,6 +181,34 @@ func validateBMCAccess(host *metal3api.BareMetalHost, bmcAccess bmc.AccessDetail
 		errs = append(errs, fmt.Errorf("BMC driver %s does not support secure boot", bmcAccess.Type()))
 	}
 
+	errs = append(errs, validateBMCCertificateVerification(host, bmcAccess)...)
+
+	return errs
+}
+
+func validateBMCCertificateVerification(host *metal3api.BareMetalHost, bmcAccess bmc.AccessDetails) []error {
+	var errs []error
+	b := host.Spec.BMC
+
+	if b.CABundle == nil && b.CertificateFingerprint == "" {
+		return nil
+	}
+
+	if b.DisableCertificateVerification {
+		errs = append(errs, errors.New("caBundle and certificateFingerprint cannot be used with disableCertificateVerification"))
+	}
+	if !bmc.SupportsTLSOptions(bmcAccess) {
+		errs = append(errs, fmt.Errorf("BMC driver %s does not support caBundle or certificateFingerprint", bmcAccess.Type()))
+	}
+	if b.CABundle != nil && (b.CABundle.ConfigMapName == "") == (b.CABundle.SecretName == "") {
+		errs = append(errs, errors.New("caBundle must reference exactly one of a config map or a secret"))
+	}
+	if b.CertificateFingerprint != "" {
+		if _, err := bmc.ParseCertificateFingerprint(b.CertificateFingerprint); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errs
 }
 
@@ -335,6 +335,98 @@ func TestValidateCreate(t *testing.T) {
 			oldBMH:    nil,
 			wantedErr: "BMC driver libvirt does not support secure boot",
 		},
+		{
+			name: "BMCCABundleAndFingerprint",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta:   tm,
+				ObjectMeta: om,
+				Spec: metal3api.BareMetalHostSpec{
+					BMC: metal3api.BMCDetails{
+						Address:                "redfish://192.168.1.1/redfish/v1/Systems/1",
+						CredentialsName:        "test1",
+						CABundle:               &metal3api.BMCCABundle{ConfigMapName: "bmc-ca", Key: "ca.crt"},
+						CertificateFingerprint: "sha256:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB",
+					},
+				}},
+			oldBMH:    nil,
+			wantedErr: "",
+		},
+		{
+			name: "BMCCABundleWithoutCertificateVerification",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta:   tm,
+				ObjectMeta: om,
+				Spec: metal3api.BareMetalHostSpec{
+					BMC: metal3api.BMCDetails{
+						Address:                        "redfish://192.168.1.1/redfish/v1/Systems/1",
+						CredentialsName:                "test1",
+						CABundle:                       &metal3api.BMCCABundle{SecretName: "bmc-ca", Key: "ca.crt"},
+						DisableCertificateVerification: true,
+					},
+				}},
+			oldBMH:    nil,
+			wantedErr: "caBundle and certificateFingerprint cannot be used with disableCertificateVerification",
+		},
+		{
+			name: "BMCCABundleWithTwoSources",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta:   tm,
+				ObjectMeta: om,
+				Spec: metal3api.BareMetalHostSpec{
+					BMC: metal3api.BMCDetails{
+						Address:         "redfish://192.168.1.1/redfish/v1/Systems/1",
+						CredentialsName: "test1",
+						CABundle:        &metal3api.BMCCABundle{ConfigMapName: "bmc-ca", SecretName: "bmc-ca", Key: "ca.crt"},
+					},
+				}},
+			oldBMH:    nil,
+			wantedErr: "caBundle must reference exactly one of a config map or a secret",
+		},
+		{
+			name: "BMCCABundleWithoutSource",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta:   tm,
+				ObjectMeta: om,
+				Spec: metal3api.BareMetalHostSpec{
+					BMC: metal3api.BMCDetails{
+						Address:         "redfish://192.168.1.1/redfish/v1/Systems/1",
+						CredentialsName: "test1",
+						CABundle:        &metal3api.BMCCABundle{Key: "ca.crt"},
+					},
+				}},
+			oldBMH:    nil,
+			wantedErr: "caBundle must reference exactly one of a config map or a secret",
+		},
+		{
+			name: "InvalidBMCCertificateFingerprint",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta:   tm,
+				ObjectMeta: om,
+				Spec: metal3api.BareMetalHostSpec{
+					BMC: metal3api.BMCDetails{
+						Address:                "redfish://192.168.1.1/redfish/v1/Systems/1",
+						CredentialsName:        "test1",
+						CertificateFingerprint: "sha256:AB:CD",
+					},
+				}},
+			oldBMH:    nil,
+			wantedErr: "certificate fingerprint \"sha256:AB:CD\" is not a SHA-256 fingerprint",
+		},
+		{
+			name: "BMCCertificateFingerprintNotSupported",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta:   tm,
+				ObjectMeta: om,
+				Spec: metal3api.BareMetalHostSpec{
+					BMC: metal3api.BMCDetails{
+						Address:                "ipmi://192.168.1.1",
+						CredentialsName:        "test1",
+						CertificateFingerprint: "sha256:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB:AB",
+					},
+				}},
+			oldBMH:    nil,
+			wantedErr: "BMC driver ipmi does not support caBundle or certificateFingerprint",
+		},
 		{
 			name: "InvalidBootMACAddress",
 			newBMH: &metal3api.BareMetalHost{
@@ -1375,6 +1467,68 @@ func TestValidateUpdate(t *testing.T) {
 				Status: metal3api.BareMetalHostStatus{Provisioning: metal3api.ProvisionStatus{State: metal3api.StateAvailable}}},
 			wantedErr: "",
 		},
+		{
+			name: "updateProvisionerLabelRegistered",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm,
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-namespace",
+					Labels: map[string]string{metal3api.ProvisionerLabel: "vendor"}},
+				Status: metal3api.BareMetalHostStatus{Provisioning: metal3api.ProvisionStatus{ID: "node-id"}}},
+			oldBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm, ObjectMeta: om,
+				Status: metal3api.BareMetalHostStatus{Provisioning: metal3api.ProvisionStatus{ID: "node-id"}}},
+			wantedErr: "label provisioner.metal3.io/name can not be changed once the host is registered, unless the BMH is detached",
+		},
+		{
+			name: "updateProvisionerLabelNotRegistered",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm,
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-namespace",
+					Labels: map[string]string{metal3api.ProvisionerLabel: "vendor"}}},
+			oldBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm, ObjectMeta: om},
+			wantedErr: "",
+		},
+		{
+			name: "updateProvisionerLabelDetached",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm,
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-namespace",
+					Labels: map[string]string{metal3api.ProvisionerLabel: "vendor"}},
+				Status: metal3api.BareMetalHostStatus{OperationalStatus: metal3api.OperationalStatusDetached,
+					Provisioning: metal3api.ProvisionStatus{ID: "node-id"}}},
+			oldBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm, ObjectMeta: om,
+				Status: metal3api.BareMetalHostStatus{OperationalStatus: metal3api.OperationalStatusDetached,
+					Provisioning: metal3api.ProvisionStatus{ID: "node-id"}}},
+			wantedErr: "",
+		},
+		{
+			name: "updateShardLabelRegistered",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm,
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-namespace",
+					Labels: map[string]string{metal3api.ShardLabel: "rack-b"}},
+				Status: metal3api.BareMetalHostStatus{Provisioning: metal3api.ProvisionStatus{ID: "node-id", Shard: "rack-a"}}},
+			oldBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm, ObjectMeta: om,
+				Status: metal3api.BareMetalHostStatus{Provisioning: metal3api.ProvisionStatus{ID: "node-id", Shard: "rack-a"}}},
+			wantedErr: "label shard.metal3.io/name can not be changed once the host is registered, unless the BMH is detached",
+		},
+		{
+			name: "updateShardLabelDetached",
+			newBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm,
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-namespace",
+					Labels: map[string]string{metal3api.ShardLabel: "rack-b"}},
+				Status: metal3api.BareMetalHostStatus{OperationalStatus: metal3api.OperationalStatusDetached,
+					Provisioning: metal3api.ProvisionStatus{ID: "node-id", Shard: "rack-a"}}},
+			oldBMH: &metal3api.BareMetalHost{
+				TypeMeta: tm, ObjectMeta: om,
+				Status: metal3api.BareMetalHostStatus{OperationalStatus: metal3api.OperationalStatusDetached,
+					Provisioning: metal3api.ProvisionStatus{ID: "node-id", Shard: "rack-a"}}},
+			wantedErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"fmt"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/switchdriver"
+)
+
+// validateSwitch validates a BareMetalSwitch against the switch drivers the
+// operator is built with.
+func (webhook *BareMetalSwitch) validateSwitch(sw *metal3api.BareMetalSwitch) []error {
+	var errs []error
+
+	// An empty driver is defaulted by the CRD
+	if sw.Spec.Driver != "" {
+		if _, err := switchdriver.NewDriver(sw.Spec.Driver); err != nil {
+			errs = append(errs, fmt.Errorf("driver: %w", err))
+		}
+	}
+
+	return errs
+}
+
+// validateChanges validates an updated BareMetalSwitch. The driver is only
+// checked when it changes, so that switches using a driver that is no longer
+// supported can still be updated to be fixed or deleted.
+func (webhook *BareMetalSwitch) validateChanges(oldSwitch, newSwitch *metal3api.BareMetalSwitch) []error {
+	if oldSwitch.Spec.Driver == newSwitch.Spec.Driver {
+		return nil
+	}
+	return webhook.validateSwitch(newSwitch)
+}
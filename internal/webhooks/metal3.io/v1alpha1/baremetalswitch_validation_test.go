@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSwitch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		driver        string
+		errorContains string
+	}{
+		{
+			name: "defaulted-driver",
+		},
+		{
+			name:   "generic-switch",
+			driver: "generic-switch",
+		},
+		{
+			name:   "netconf-openconfig",
+			driver: "netconf-openconfig",
+		},
+		{
+			name:          "unknown-driver",
+			driver:        "snmp",
+			errorContains: "unknown switch driver 'snmp'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sw := &metal3api.BareMetalSwitch{
+				Spec: metal3api.BareMetalSwitchSpec{Driver: tc.driver},
+			}
+			errs := (&BareMetalSwitch{}).validateSwitch(sw)
+			if tc.errorContains == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestValidateSwitchChanges(t *testing.T) {
+	oldSwitch := &metal3api.BareMetalSwitch{
+		Spec: metal3api.BareMetalSwitchSpec{Driver: "removed-driver"},
+	}
+
+	newSwitch := oldSwitch.DeepCopy()
+	newSwitch.Spec.Address = "192.0.2.1"
+	assert.Empty(t, (&BareMetalSwitch{}).validateChanges(oldSwitch, newSwitch),
+		"an unchanged driver is not validated")
+
+	newSwitch.Spec.Driver = "other-driver"
+	assert.NotEmpty(t, (&BareMetalSwitch{}).validateChanges(oldSwitch, newSwitch))
+
+	newSwitch.Spec.Driver = "generic-switch"
+	assert.Empty(t, (&BareMetalSwitch{}).validateChanges(oldSwitch, newSwitch))
+}
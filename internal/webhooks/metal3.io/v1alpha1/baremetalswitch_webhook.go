@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// baremetalswitchlog is for logging in this webhook.
+var baremetalswitchlog = logf.Log.WithName("webhooks").WithName("BareMetalSwitch")
+
+// SetupWebhookWithManager registers the BareMetalSwitch validation webhook with the manager.
+func (webhook *BareMetalSwitch) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &metal3api.BareMetalSwitch{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-metal3-io-v1alpha1-baremetalswitch,mutating=false,failurePolicy=fail,sideEffects=none,admissionReviewVersions=v1,groups=metal3.io,resources=baremetalswitches,versions=v1alpha1,name=baremetalswitch.metal3.io
+
+// BareMetalSwitch implements a validation webhook for BareMetalSwitch.
+type BareMetalSwitch struct{}
+
+var _ admission.Validator[*metal3api.BareMetalSwitch] = &BareMetalSwitch{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (webhook *BareMetalSwitch) ValidateCreate(_ context.Context, sw *metal3api.BareMetalSwitch) (admission.Warnings, error) {
+	baremetalswitchlog.Info("validate create", "namespace", sw.Namespace, "name", sw.Name)
+	return nil, kerrors.NewAggregate(webhook.validateSwitch(sw))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (webhook *BareMetalSwitch) ValidateUpdate(_ context.Context, oldSwitch, newSwitch *metal3api.BareMetalSwitch) (admission.Warnings, error) {
+	baremetalswitchlog.Info("validate update", "namespace", newSwitch.Namespace, "name", newSwitch.Name)
+	return nil, kerrors.NewAggregate(webhook.validateChanges(oldSwitch, newSwitch))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (webhook *BareMetalSwitch) ValidateDelete(_ context.Context, _ *metal3api.BareMetalSwitch) (admission.Warnings, error) {
+	return nil, nil
+}
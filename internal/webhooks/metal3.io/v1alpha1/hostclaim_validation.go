@@ -80,6 +80,10 @@ func (webhook *HostClaimWebhook) validateHostClaim(hostclaim *metal3api.HostClai
 		errs = append(errs, imageErrs...)
 	}
 
+	if ttl := hostclaim.Spec.ReservationTTL; ttl != nil && ttl.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("reservationTTL %s must be positive", ttl.Duration))
+	}
+
 	return errs
 }
 
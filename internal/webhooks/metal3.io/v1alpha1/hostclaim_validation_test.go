@@ -17,6 +17,7 @@ package webhooks
 import (
 	"strings"
 	"testing"
+	"time"
 
 	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -203,3 +204,42 @@ func TestValidateHostClaimImageCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHostClaimReservationTTL(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       *metav1.Duration
+		wantedErr string
+	}{
+		{
+			name: "no TTL",
+		},
+		{
+			name: "valid TTL",
+			ttl:  &metav1.Duration{Duration: time.Hour},
+		},
+		{
+			name:      "zero TTL",
+			ttl:       &metav1.Duration{},
+			wantedErr: "reservationTTL 0s must be positive",
+		},
+		{
+			name:      "negative TTL",
+			ttl:       &metav1.Duration{Duration: -time.Minute},
+			wantedErr: "reservationTTL -1m0s must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &HostClaimWebhook{}
+			hostclaim := &metal3api.HostClaim{
+				Spec: metal3api.HostClaimSpec{
+					ReservationTTL: tt.ttl,
+				},
+			}
+			if err := webhook.validateHostClaim(hostclaim); !errorArrContainsPrefix(err, tt.wantedErr) {
+				t.Errorf("metal3api.HostClaimWebhook ReservationTTL error = %v, wantErr %v", err, tt.wantedErr)
+			}
+		})
+	}
+}
@@ -17,7 +17,6 @@ package webhooks
 
 import (
 	"context"
-	"fmt"
 
 	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -31,40 +30,13 @@ import (
 var hostnetworkattachmentlog = logf.Log.WithName("webhooks").WithName("HostNetworkAttachment")
 
 // bmhNetworkAttachmentIndexField is the field index name for BMH -> HostNetworkAttachment references.
-const bmhNetworkAttachmentIndexField = ".spec.networkInterfaces.hostNetworkAttachment.name"
+// The index is registered by the manager setup since it is shared with the
+// HostNetworkAttachment controller.
+const bmhNetworkAttachmentIndexField = metal3api.HostNetworkAttachmentIndexField
 
-func (webhook *HostNetworkAttachment) SetupWebhookWithManager(ctx context.Context, mgr ctrl.Manager) error {
+func (webhook *HostNetworkAttachment) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	webhook.Client = mgr.GetClient()
 
-	// Register field indexer for efficient BMH reference lookups
-	// This allows us to quickly find all BMHs that reference a specific HostNetworkAttachment
-	if err := mgr.GetFieldIndexer().IndexField(
-		ctx,
-		&metal3api.BareMetalHost{},
-		bmhNetworkAttachmentIndexField,
-		func(obj client.Object) []string {
-			bmh, ok := obj.(*metal3api.BareMetalHost)
-			if !ok {
-				return nil
-			}
-			var attachments []string
-			for _, iface := range bmh.Spec.NetworkInterfaces {
-				if iface.HostNetworkAttachment.Name != "" {
-					// Include namespace in index key for cross-namespace support
-					ns := iface.HostNetworkAttachment.Namespace
-					if ns == "" {
-						ns = bmh.Namespace
-					}
-					key := fmt.Sprintf("%s/%s", ns, iface.HostNetworkAttachment.Name)
-					attachments = append(attachments, key)
-				}
-			}
-			return attachments
-		},
-	); err != nil {
-		return err
-	}
-
 	return ctrl.NewWebhookManagedBy(mgr, &metal3api.HostNetworkAttachment{}).
 		WithValidator(webhook).
 		Complete()
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -38,6 +39,8 @@ import (
 	"github.com/metal3-io/baremetal-operator/pkg/imageprovider"
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/replay"
 	"github.com/metal3-io/baremetal-operator/pkg/secretutils"
 	"github.com/metal3-io/baremetal-operator/pkg/version"
 	"go.uber.org/zap/zapcore"
@@ -47,6 +50,7 @@ import (
 	cliflag "k8s.io/component-base/cli/flag"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -95,8 +99,93 @@ const (
 	provisionerPluginSuffix     = "-provisioner.so"
 	// provisionerNameFixture is compiled in, so selecting it skips plugin loading.
 	provisionerNameFixture = "fixture"
+	// provisionerPluginDialTimeout bounds the wait for an out-of-process
+	// plugin to come up.
+	provisionerPluginDialTimeout = 2 * time.Minute
 )
 
+// provisionerPlugin is a provisioner plugin loaded from a .so or reached
+// over gRPC.
+type provisionerPlugin interface {
+	Name() string
+	HostConfigure(provisioner.HostConfigureInput) (provisioner.HostRequirements, error)
+	NewFactory(provisioner.PluginConfig) (provisioner.Factory, error)
+}
+
+func validateProvisionerNames(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !provisionerNameRE.MatchString(name) {
+			return fmt.Errorf("invalid provisioner name %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("provisioner %q is selected more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// provisionerPluginAddress returns the address of the out-of-process plugin
+// name, if any. PROVISIONER_PLUGIN_ADDRESS_<NAME> takes precedence over
+// PROVISIONER_PLUGIN_ADDRESS, which only applies to the default provisioner.
+func provisionerPluginAddress(name string, isDefault bool) string {
+	suffix := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	if address := os.Getenv("PROVISIONER_PLUGIN_ADDRESS_" + suffix); address != "" {
+		return address
+	}
+	if isDefault {
+		return os.Getenv("PROVISIONER_PLUGIN_ADDRESS")
+	}
+	return ""
+}
+
+// openProvisionerPlugin connects to the plugin name if it runs in another
+// process, or loads it from the plugin directory. It returns the plugin and
+// its location.
+func openProvisionerPlugin(name string, isDefault bool) (provisionerPlugin, string, error) {
+	if address := provisionerPluginAddress(name, isDefault); address != "" {
+		if err := grpcplugin.CheckAddress(address); err != nil {
+			return nil, address, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), provisionerPluginDialTimeout)
+		defer cancel()
+		p, err := grpcplugin.Dial(ctx, address, name)
+		if err != nil {
+			return nil, address, err
+		}
+		setupLog.Info("connected to provisioner plugin", "name", p.Name(), "address", p.Address())
+		return p, p.Address(), nil
+	}
+
+	pluginDir := cmp.Or(os.Getenv("PROVISIONER_PLUGIN_DIR"), defaultProvisionerPluginDir)
+	pluginPath := filepath.Join(pluginDir, name+provisionerPluginSuffix)
+	p, err := provisioner.Open(pluginPath, name)
+	if err != nil {
+		return nil, pluginPath, err
+	}
+	setupLog.Info("loaded provisioner plugin", "name", p.Name(), "path", p.Path())
+	return p, p.Path(), nil
+}
+
+// recordProvisionerCalls returns a factory recording the calls made to the
+// provisioners of factory into the file at path, appending to it.
+func recordProvisionerCalls(ctx context.Context, name string, factory provisioner.Factory, path string) (provisioner.Factory, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	recorder, err := replay.NewRecorder(ctx, name, factory, f, provisioner.PluginConfig{
+		Logger: ctrl.Log.WithName("provisioner").WithName("recorder"),
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	setupLog.Info("recording provisioner calls", "path", path)
+	return recorder, nil
+}
+
 // provisionerNameRE rejects flag values that would let filepath.Join escape
 // the plugin directory.
 var provisionerNameRE = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
@@ -127,6 +216,26 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
+// setupFieldIndexes registers the field indexes shared by the controllers
+// and the webhooks.
+func setupFieldIndexes(ctx context.Context, mgr ctrl.Manager) {
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&metal3api.BareMetalHost{},
+		metal3api.HostNetworkAttachmentIndexField,
+		func(obj client.Object) []string {
+			host, ok := obj.(*metal3api.BareMetalHost)
+			if !ok {
+				return nil
+			}
+			return host.HostNetworkAttachmentKeys()
+		},
+	); err != nil {
+		setupLog.Error(err, "unable to create field index", "field", metal3api.HostNetworkAttachmentIndexField)
+		os.Exit(1)
+	}
+}
+
 // setupWebhookReadinessCheck adds a readiness check that blocks the pod from
 // entering "Ready" state (and thus from being added to the Service's endpoints)
 // until the webhook server is actually listening. This prevents a race where
@@ -140,7 +249,7 @@ func setupWebhookReadinessCheck(mgr ctrl.Manager) {
 	}
 }
 
-func setupWebhooks(ctx context.Context, mgr ctrl.Manager) {
+func setupWebhooks(mgr ctrl.Manager) {
 	if err := (&webhooks.BareMetalHost{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "BareMetalHost")
 		os.Exit(1)
@@ -156,11 +265,16 @@ func setupWebhooks(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
-	if err := (&webhooks.HostNetworkAttachment{}).SetupWebhookWithManager(ctx, mgr); err != nil {
+	if err := (&webhooks.HostNetworkAttachment{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "HostNetworkAttachment")
 		os.Exit(1)
 	}
 
+	if err := (&webhooks.BareMetalSwitch{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "BareMetalSwitch")
+		os.Exit(1)
+	}
+
 	if err := (&webhooks.DataImage{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "DataImage")
 		os.Exit(1)
@@ -188,6 +302,12 @@ func main() {
 	var leaseDurationSeconds string
 	var renewDeadlineSeconds string
 	var retryPeriodSeconds string
+	var provisionerRecordFile string
+	var probeBMC bool
+	var credentialsProviderLocation string
+	var credentialsProviderTokenFile string
+	var credentialsProviderCAFile string
+	var credentialsCacheTTL time.Duration
 	var supportedTLSCurvesNames = make([]string, 0, len(supportedTLSCurvesPreferences))
 	for name := range supportedTLSCurvesPreferences {
 		supportedTLSCurvesNames = append(supportedTLSCurvesNames, name)
@@ -208,9 +328,11 @@ func main() {
 	flag.BoolVar(&hostClaimsEnable, "hostclaims", false, "enable HostClaims controller")
 	flag.BoolVar(&devLogging, "dev", false, "enable developer logging")
 	flag.StringVar(&provisionerName, "provisioner", defaultProvisionerName,
-		"Name of the provisioner plugin to load. Resolves to "+
+		"Comma-separated names of the provisioner plugins to load, the first one is the default "+
+			"for hosts without the "+metal3api.ProvisionerLabel+" label. Each resolves to "+
 			"$PROVISIONER_PLUGIN_DIR/<name>"+provisionerPluginSuffix+
-			" (default dir "+defaultProvisionerPluginDir+"). "+
+			" (default dir "+defaultProvisionerPluginDir+"), or is reached over gRPC at "+
+			"$PROVISIONER_PLUGIN_ADDRESS when set. "+
 			"Use "+provisionerNameFixture+" for the compiled-in fixture provisioner.")
 	flag.StringVar(&healthAddr, "health-addr", ":9440",
 		"The address the health endpoint binds to.")
@@ -244,10 +366,22 @@ func main() {
 		"Number of CRs of each type to process simultaneously")
 	flag.IntVar(&maxProvisioningRetries, "max-provisioning-retries", 5, //nolint:mnd
 		"Maximum number of provisioning retries before giving up. Set to 0 to disable the limit (infinite retries).")
+	flag.BoolVar(&probeBMC, "probe-bmc", false,
+		"Check the address and credentials of Redfish BMCs before registering hosts.")
+	flag.StringVar(&credentialsProviderLocation, "bmc-credentials-provider", os.Getenv("BMC_CREDENTIALS_PROVIDER"),
+		"Look BMC credentials up in a directory (file:///path) or a lookup service (https://...) instead of Secrets.")
+	flag.StringVar(&credentialsProviderTokenFile, "bmc-credentials-token-file", os.Getenv("BMC_CREDENTIALS_TOKEN_FILE"),
+		"File holding the bearer token sent to the BMC credentials lookup service.")
+	flag.StringVar(&credentialsProviderCAFile, "bmc-credentials-ca-file", os.Getenv("BMC_CREDENTIALS_CA_FILE"),
+		"File holding the CA certificates of the BMC credentials lookup service.")
+	flag.DurationVar(&credentialsCacheTTL, "bmc-credentials-cache-ttl", time.Minute,
+		"How long BMC credentials from the provider are cached.")
 
 	flag.StringVar(&leaseDurationSeconds, "lease-duration-seconds", os.Getenv("LEASE_DURATION_SECONDS"), "Leader election duration in seconds.")
 	flag.StringVar(&renewDeadlineSeconds, "renew-deadline-seconds", os.Getenv("RENEW_DEADLINE_SECONDS"), "Leader election renew deadline duration in seconds.")
 	flag.StringVar(&retryPeriodSeconds, "retry-period-seconds", os.Getenv("RETRY_PERIOD_SECONDS"), "Leader election retry period in seconds.")
+	flag.StringVar(&provisionerRecordFile, "record-provisioner-calls", os.Getenv("PROVISIONER_RECORD_FILE"),
+		"File to append the provisioner calls of the BareMetalHost controller to, for replaying them in tests.")
 
 	flag.Parse()
 
@@ -270,44 +404,48 @@ func main() {
 	// Default namespace for the provisioner, overridable by the plugin.
 	provisionerNamespace := cmp.Or(os.Getenv("POD_NAMESPACE"), watchNamespace)
 
-	// Open the plugin before any K8s I/O so a bad path fails fast.
-	var provisionerPlugin *provisioner.Plugin
-	var pluginRequirements provisioner.HostRequirements
-	if provisionerName != provisionerNameFixture {
-		if !provisionerNameRE.MatchString(provisionerName) {
-			setupLog.Error(fmt.Errorf("invalid provisioner name %q", provisionerName),
-				"provisioner name must match "+provisionerNameRE.String())
-			os.Exit(1)
+	// The first provisioner is the default one, the others are selected
+	// per host by the provisioner label.
+	provisionerNames := strings.Split(provisionerName, ",")
+	if err := validateProvisionerNames(provisionerNames); err != nil {
+		setupLog.Error(err, "provisioner names must be unique and match "+provisionerNameRE.String())
+		os.Exit(1)
+	}
+
+	// Open the plugins before any K8s I/O so a bad path fails fast.
+	provisionerPlugins := make(map[string]provisionerPlugin, len(provisionerNames))
+	pluginLocations := make(map[string]string, len(provisionerNames))
+	pluginCacheByObject := make(map[client.Object]cache.ByObject)
+	for i, name := range provisionerNames {
+		if name == provisionerNameFixture {
+			continue
 		}
-		pluginDir := cmp.Or(os.Getenv("PROVISIONER_PLUGIN_DIR"), defaultProvisionerPluginDir)
-		pluginPath := filepath.Join(pluginDir, provisionerName+provisionerPluginSuffix)
-		p, err := provisioner.Open(pluginPath, provisionerName)
+		p, location, err := openProvisionerPlugin(name, i == 0)
 		if err != nil {
-			setupLog.Error(err, "cannot load provisioner plugin",
-				"name", provisionerName, "dir", pluginDir, "path", pluginPath)
+			setupLog.Error(err, "cannot load provisioner plugin", "name", name)
 			os.Exit(1)
 		}
-		provisionerPlugin = p
-		setupLog.Info("loaded provisioner plugin",
-			"name", provisionerPlugin.Name(), "path", provisionerPlugin.Path())
+		provisionerPlugins[name] = p
+		pluginLocations[name] = location
 
-		pluginRequirements, err = provisionerPlugin.HostConfigure(provisioner.HostConfigureInput{
+		pluginRequirements, err := p.HostConfigure(provisioner.HostConfigureInput{
 			Logger:               setupLog,
 			Features:             hostFeatures,
 			ProvisionerNamespace: provisionerNamespace,
 		})
 		if err != nil {
 			setupLog.Error(err, "plugin HostConfigure failed",
-				"name", provisionerPlugin.Name(), "path", provisionerPlugin.Path())
+				"name", p.Name(), "location", location)
 			os.Exit(1)
 		}
 		if pluginRequirements.AddToScheme != nil {
 			if err := pluginRequirements.AddToScheme(scheme); err != nil {
 				setupLog.Error(err, "plugin AddToScheme failed",
-					"name", provisionerPlugin.Name())
+					"name", p.Name())
 				os.Exit(1)
 			}
 		}
+		maps.Copy(pluginCacheByObject, pluginRequirements.CacheByObject)
 	}
 
 	enableWebhook := webhookPort != 0
@@ -358,7 +496,7 @@ func main() {
 		LeaderElectionReleaseOnCancel: true,
 		HealthProbeBindAddress:        healthAddr,
 		Cache: cache.Options{
-			ByObject:          secretutils.AddSecretSelector(pluginRequirements.CacheByObject),
+			ByObject:          secretutils.AddSecretSelector(pluginCacheByObject),
 			DefaultNamespaces: watchNamespaces,
 		},
 	}
@@ -404,13 +542,15 @@ func main() {
 		os.Exit(1)
 	}
 
-	var provisionerFactory provisioner.Factory
-	if provisionerName == provisionerNameFixture {
-		ctrl.Log.Info("using fixture provisioner")
-		provisionerFactory = &fixture.Fixture{}
-	} else {
+	factories := make(map[string]provisioner.Factory, len(provisionerNames))
+	for _, name := range provisionerNames {
+		if name == provisionerNameFixture {
+			ctrl.Log.Info("using fixture provisioner")
+			factories[name] = &fixture.Fixture{}
+			continue
+		}
 		provLog := zap.New(zap.UseFlagOptions(&logOpts)).WithName("provisioner")
-		provisionerFactory, err = provisionerPlugin.NewFactory(provisioner.PluginConfig{
+		factories[name], err = provisionerPlugins[name].NewFactory(provisioner.PluginConfig{
 			Logger:               provLog,
 			Features:             hostFeatures,
 			K8sClient:            mgr.GetClient(),
@@ -418,11 +558,16 @@ func main() {
 			ProvisionerNamespace: provisionerNamespace,
 		})
 		if err != nil {
-			setupLog.Error(err, "cannot initialize provisioner plugin", "path", provisionerPlugin.Path())
+			setupLog.Error(err, "cannot initialize provisioner plugin", "location", pluginLocations[name])
 			os.Exit(1)
 		}
 	}
 
+	provisionerFactory := factories[provisionerNames[0]]
+	if len(factories) > 1 {
+		provisionerFactory = provisioner.NewRouter(provisionerNames[0], factories)
+	}
+
 	maxConcurrency, err := getMaxConcurrentReconciles(controllerConcurrency)
 	if err != nil {
 		setupLog.Error(err, "unable to create controllers")
@@ -435,12 +580,44 @@ func main() {
 		os.Exit(1)
 	}
 
+	provisioningLimits, err := metal3iocontroller.LoadProvisioningLimitsFromEnv()
+	if err != nil {
+		setupLog.Error(err, "invalid provisioning limits")
+		os.Exit(1)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
+	setupFieldIndexes(ctx, mgr)
+
+	hostProvisionerFactory := provisionerFactory
+	if provisionerRecordFile != "" {
+		hostProvisionerFactory, err = recordProvisionerCalls(ctx, provisionerNames[0], provisionerFactory, provisionerRecordFile)
+		if err != nil {
+			setupLog.Error(err, "cannot record provisioner calls", "path", provisionerRecordFile)
+			os.Exit(1)
+		}
+	}
+
+	var credentialsProvider secretutils.CredentialsProvider
+	if credentialsProviderLocation != "" {
+		provider, err := secretutils.NewCredentialsProvider(credentialsProviderLocation, credentialsProviderTokenFile, credentialsProviderCAFile)
+		if err != nil {
+			setupLog.Error(err, "cannot set up the BMC credentials provider")
+			os.Exit(1)
+		}
+		credentialsProvider = secretutils.NewCachingCredentialsProvider(provider, credentialsCacheTTL)
+	}
+
 	if err = (&metal3iocontroller.BareMetalHostReconciler{
 		Client:                 mgr.GetClient(),
 		Log:                    ctrl.Log.WithName("controllers").WithName("BareMetalHost"),
-		ProvisionerFactory:     provisionerFactory,
+		ProvisionerFactory:     hostProvisionerFactory,
 		APIReader:              mgr.GetAPIReader(),
 		MaxProvisioningRetries: maxProvisioningRetries,
+		ProvisioningLimits:     provisioningLimits,
+		ProbeBMC:               probeBMC,
+		CredentialsProvider:    credentialsProvider,
 	}).SetupWithManager(mgr, preprovImgEnable, maxConcurrency); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BareMetalHost")
 		os.Exit(1)
@@ -473,6 +650,13 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "HostClaim")
 			os.Exit(1)
 		}
+		if err = (&metal3iocontroller.HostDeployPolicyReconciler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controllers").WithName("HostDeployPolicy"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HostDeployPolicy")
+			os.Exit(1)
+		}
 	}
 
 	// +kubebuilder:scaffold:builder
@@ -513,6 +697,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&metal3iocontroller.HostNetworkAttachmentReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("HostNetworkAttachment"),
+	}).SetupWithManager(mgr, maxConcurrency); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HostNetworkAttachment")
+		os.Exit(1)
+	}
+
 	networkingEnabledValue := os.Getenv("IRONIC_NETWORKING_ENABLED")
 	networkingEnabled, err := strconv.ParseBool(networkingEnabledValue)
 	if err != nil && networkingEnabledValue != "" {
@@ -538,6 +730,18 @@ func main() {
 			os.Exit(1)
 		}
 
+		var switchProbeInterval time.Duration
+		if value := os.Getenv("IRONIC_SWITCH_PROBE_INTERVAL"); value != "" {
+			switchProbeInterval, err = time.ParseDuration(value)
+			if err == nil && switchProbeInterval < 0 {
+				err = errors.New("probe interval must not be negative")
+			}
+			if err != nil {
+				setupLog.Error(err, "invalid environment variable value", "name", "IRONIC_SWITCH_PROBE_INTERVAL", "value", value)
+				os.Exit(1)
+			}
+		}
+
 		if err = (&metal3iocontroller.BareMetalSwitchReconciler{
 			Client:                     mgr.GetClient(),
 			Log:                        ctrl.Log.WithName("controllers").WithName("BareMetalSwitch"),
@@ -545,19 +749,26 @@ func main() {
 			SwitchConfigsSecretName:    switchConfigsSecretName,
 			SwitchCredentialSecretName: switchCredentialSecretName,
 			SwitchCredentialPath:       switchCredentialPath,
+			ProbeInterval:              switchProbeInterval,
 		}).SetupWithManager(mgr, maxConcurrency); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "BareMetalSwitch")
 			os.Exit(1)
 		}
+
+		if err = (&metal3iocontroller.SwitchPortDiscoveryReconciler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("controllers").WithName("SwitchPortDiscovery"),
+		}).SetupWithManager(mgr, maxConcurrency); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SwitchPortDiscovery")
+			os.Exit(1)
+		}
 	}
 
 	setupChecks(mgr)
 
-	ctx := ctrl.SetupSignalHandler()
-
 	if enableWebhook {
 		setupWebhookReadinessCheck(mgr)
-		setupWebhooks(ctx, mgr)
+		setupWebhooks(mgr)
 	}
 
 	setupLog.Info("starting manager")
@@ -1,6 +1,7 @@
 package bmc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -174,3 +175,20 @@ func checkDNSValid(address string) error {
 	}
 	return nil
 }
+
+// Prober is implemented by the AccessDetails of BMCs the operator can
+// contact itself, to check the address and credentials before the host is
+// registered with the provisioner.
+type Prober interface {
+	// Probe checks that the BMC answers, accepts the credentials and
+	// manages the system. It returns the path of the system on the BMC.
+	Probe(ctx context.Context, bmcCreds Credentials) (systemPath string, err error)
+}
+
+// PasswordChanger is implemented by the AccessDetails of BMCs on which the
+// operator can change the password of an account itself.
+type PasswordChanger interface {
+	// ChangePassword sets a new password for the account of the
+	// credentials, and checks that the BMC accepts it.
+	ChangePassword(ctx context.Context, bmcCreds Credentials, newPassword string) error
+}
@@ -25,3 +25,29 @@ type CredentialsValidationError struct {
 func (e CredentialsValidationError) Error() string {
 	return "Validation error with BMC credentials: " + e.message
 }
+
+// ProbeFailure classifies the reason a BMC failed a probe.
+type ProbeFailure string
+
+const (
+	// ProbeUnreachable is used when the BMC does not answer, or not as
+	// expected.
+	ProbeUnreachable ProbeFailure = "Unreachable"
+	// ProbeUnauthorized is used when the BMC rejects the credentials.
+	ProbeUnauthorized ProbeFailure = "Unauthorized"
+	// ProbeSystemNotFound is used when the system to manage does not exist.
+	ProbeSystemNotFound ProbeFailure = "SystemNotFound"
+	// ProbeSystemNotUnique is used when the BMC manages several systems and
+	// the address does not say which one to use.
+	ProbeSystemNotUnique ProbeFailure = "SystemNotUnique"
+)
+
+// ProbeError is returned when the BMC failed a probe.
+type ProbeError struct {
+	Failure ProbeFailure
+	message string
+}
+
+func (e ProbeError) Error() string {
+	return "BMC probe failed: " + e.message
+}
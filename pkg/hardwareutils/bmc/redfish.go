@@ -36,6 +36,7 @@ type redfishAccessDetails struct {
 	host                           string
 	path                           string
 	disableCertificateVerification bool
+	tlsOptions                     TLSOptions
 }
 
 type redfishiDracAccessDetails struct {
@@ -97,8 +98,8 @@ func (a *redfishAccessDetails) DriverInfo(bmcCreds Credentials) map[string]inter
 		result["redfish_system_id"] = a.path
 	}
 
-	if a.disableCertificateVerification {
-		result["redfish_verify_ca"] = false
+	if verifyCA := verifyCAValue(a.tlsOptions, a.disableCertificateVerification); verifyCA != nil {
+		result["redfish_verify_ca"] = verifyCA
 	}
 
 	return result
@@ -0,0 +1,121 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type redfishServiceRootAccounts struct {
+	AccountService redfishLink `json:"AccountService"`
+}
+
+type redfishAccountService struct {
+	Accounts redfishLink `json:"Accounts"`
+}
+
+type redfishAccount struct {
+	UserName string `json:"UserName"`
+	ETag     string `json:"@odata.etag"`
+}
+
+// findAccount returns the path and ETag of the account of the user.
+func (a *redfishAccessDetails) findAccount(ctx context.Context, client *http.Client, bmcCreds Credentials) (path, etag string, err error) {
+	var root redfishServiceRootAccounts
+	if _, err := a.redfishGet(ctx, client, redfishServiceRoot, nil, &root); err != nil {
+		return "", "", &ProbeError{Failure: ProbeUnreachable, message: err.Error()}
+	}
+	if root.AccountService.ID == "" {
+		return "", "", errors.New("the Redfish service does not have an account service")
+	}
+	var service redfishAccountService
+	if code, err := a.redfishGet(ctx, client, root.AccountService.ID, &bmcCreds, &service); err != nil {
+		return "", "", resourceError(code, err, ProbeUnreachable)
+	}
+	var accounts redfishCollection
+	if code, err := a.redfishGet(ctx, client, service.Accounts.ID, &bmcCreds, &accounts); err != nil {
+		return "", "", resourceError(code, err, ProbeUnreachable)
+	}
+	for _, member := range accounts.Members {
+		var account redfishAccount
+		if code, err := a.redfishGet(ctx, client, member.ID, &bmcCreds, &account); err != nil {
+			return "", "", resourceError(code, err, ProbeUnreachable)
+		}
+		if account.UserName == bmcCreds.Username {
+			return member.ID, account.ETag, nil
+		}
+	}
+	return "", "", fmt.Errorf("no account of user %s found on the BMC", bmcCreds.Username)
+}
+
+func (a *redfishAccessDetails) setPassword(ctx context.Context, client *http.Client, path, etag string, bmcCreds Credentials, password string) error {
+	body, err := json.Marshal(map[string]string{"Password": password})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, getRedfishAddress(a.bmcType, a.host)+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(bmcCreds.Username, bmcCreds.Password)
+	// Some BMCs refuse changes to accounts without the ETag.
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+		return nil
+	default:
+		return resourceError(resp.StatusCode, fmt.Errorf("PATCH %s returned %s", path, resp.Status), ProbeUnreachable)
+	}
+}
+
+// ChangePassword sets the password of the account of the credentials
+// through the Redfish account service, and checks that the BMC accepts the
+// new password. If it does not, the previous password is restored.
+func (a *redfishAccessDetails) ChangePassword(ctx context.Context, bmcCreds Credentials, newPassword string) error {
+	// Finding the account takes a request per account, bound each request
+	// rather than the whole change.
+	client := a.probeClient()
+	client.Timeout = redfishProbeTimeout
+
+	path, etag, err := a.findAccount(ctx, client, bmcCreds)
+	if err != nil {
+		return err
+	}
+	if err := a.setPassword(ctx, client, path, etag, bmcCreds, newPassword); err != nil {
+		return fmt.Errorf("failed to change the password: %w", err)
+	}
+
+	newCreds := Credentials{Username: bmcCreds.Username, Password: newPassword}
+	var account redfishAccount
+	code, err := a.redfishGet(ctx, client, path, &newCreds, &account)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("the BMC does not accept the new password: %w", resourceError(code, err, ProbeUnreachable))
+
+	// The previous password must be restored even if the caller gave up in
+	// the meantime. Depending on where it failed, either password may be in
+	// use now.
+	restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), redfishProbeTimeout)
+	defer cancel()
+	for _, creds := range []Credentials{bmcCreds, newCreds} {
+		if restoreErr := a.setPassword(restoreCtx, client, path, "", creds, bmcCreds.Password); restoreErr == nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%w, and restoring the previous password failed", err)
+}
@@ -0,0 +1,142 @@
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// accountService is a Redfish service with a single "admin" account, whose
+// password can be changed.
+type accountService struct {
+	lock     sync.Mutex
+	password string
+	// ignorePatch accepts password changes without applying them.
+	ignorePatch bool
+	patches     int
+	// afterPatch is called on the requests following a password change.
+	afterPatch func()
+}
+
+func (s *accountService) start(t *testing.T) string {
+	t.Helper()
+	link := func(path string) map[string]string {
+		return map[string]string{"@odata.id": path}
+	}
+	resources := map[string]any{
+		"/redfish/v1/":                          map[string]any{"AccountService": link("/redfish/v1/AccountService")},
+		"/redfish/v1/AccountService":            map[string]any{"Accounts": link("/redfish/v1/AccountService/Accounts")},
+		"/redfish/v1/AccountService/Accounts":   map[string]any{"Members": []map[string]string{link("/redfish/v1/AccountService/Accounts/1"), link("/redfish/v1/AccountService/Accounts/2")}},
+		"/redfish/v1/AccountService/Accounts/1": map[string]any{"UserName": "operator"},
+		"/redfish/v1/AccountService/Accounts/2": map[string]any{"UserName": "admin", "@odata.etag": `W/"1"`},
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.patches > 0 && s.afterPatch != nil {
+			s.afterPatch()
+		}
+		resource, ok := resources[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path != "/redfish/v1/" {
+			if username, password, _ := r.BasicAuth(); username != "admin" || password != s.password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(resource)
+		case http.MethodPatch:
+			var patch struct{ Password string }
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || r.URL.Path != "/redfish/v1/AccountService/Accounts/2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.patches++
+			if !s.ignorePatch {
+				s.password = patch.Password
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newPasswordChanger(t *testing.T, serviceURL string) PasswordChanger {
+	t.Helper()
+	acc, err := NewAccessDetails("redfish+"+serviceURL+"/redfish/v1/Systems/1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changer, ok := acc.(PasswordChanger)
+	if !ok {
+		t.Fatal("redfish access details do not implement PasswordChanger")
+	}
+	return changer
+}
+
+func TestRedfishChangePassword(t *testing.T) {
+	service := &accountService{password: "old"}
+	changer := newPasswordChanger(t, service.start(t))
+
+	err := changer.ChangePassword(t.Context(), Credentials{Username: "admin", Password: "old"}, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.password != "new" {
+		t.Errorf("expected the password to be changed, got %q", service.password)
+	}
+}
+
+func TestRedfishChangePasswordWrongCredentials(t *testing.T) {
+	service := &accountService{password: "old"}
+	changer := newPasswordChanger(t, service.start(t))
+
+	err := changer.ChangePassword(t.Context(), Credentials{Username: "admin", Password: "wrong"}, "new")
+	if err == nil || !strings.Contains(err.Error(), "rejected the credentials") {
+		t.Errorf("expected the credentials to be rejected, got %v", err)
+	}
+	if service.patches != 0 || service.password != "old" {
+		t.Errorf("expected the password to be left alone, got %q after %d changes", service.password, service.patches)
+	}
+}
+
+func TestRedfishChangePasswordNotApplied(t *testing.T) {
+	service := &accountService{password: "old", ignorePatch: true}
+	changer := newPasswordChanger(t, service.start(t))
+
+	err := changer.ChangePassword(t.Context(), Credentials{Username: "admin", Password: "old"}, "new")
+	if err == nil || !strings.Contains(err.Error(), "does not accept the new password") {
+		t.Errorf("expected the new password to be refused, got %v", err)
+	}
+	if service.patches != 2 || service.password != "old" {
+		t.Errorf("expected the previous password to be restored, got %q after %d changes", service.password, service.patches)
+	}
+}
+
+func TestRedfishChangePasswordRestoredAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	service := &accountService{password: "old", ignorePatch: true, afterPatch: cancel}
+	changer := newPasswordChanger(t, service.start(t))
+
+	err := changer.ChangePassword(ctx, Credentials{Username: "admin", Password: "old"}, "new")
+	if err == nil {
+		t.Error("expected the change to fail")
+	}
+	if service.patches != 2 || service.password != "old" {
+		t.Errorf("expected the previous password to be restored, got %q after %d changes", service.password, service.patches)
+	}
+}
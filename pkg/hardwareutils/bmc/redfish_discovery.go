@@ -0,0 +1,176 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// redfishDiscoveryTimeout bounds all the requests of a discovery, which
+// reads every system and network interface of the service.
+const redfishDiscoveryTimeout = time.Minute
+
+// RedfishSystem describes a computer system managed by a Redfish service.
+type RedfishSystem struct {
+	// Path is the path of the System resource on the BMC.
+	Path         string
+	ID           string
+	Name         string
+	Manufacturer string
+	Model        string
+	SerialNumber string
+	UUID         string
+	// MACAddresses are the lower case MAC addresses of the network
+	// interfaces of the system.
+	MACAddresses []string
+}
+
+type redfishSystemResource struct {
+	ID                 string      `json:"Id"`
+	Name               string      `json:"Name"`
+	Manufacturer       string      `json:"Manufacturer"`
+	Model              string      `json:"Model"`
+	SerialNumber       string      `json:"SerialNumber"`
+	UUID               string      `json:"UUID"`
+	EthernetInterfaces redfishLink `json:"EthernetInterfaces"`
+}
+
+type redfishEthernetInterfaceResource struct {
+	MACAddress          string `json:"MACAddress"`
+	PermanentMACAddress string `json:"PermanentMACAddress"`
+}
+
+// redfishAccess is implemented by all the access details using Redfish.
+type redfishAccess interface {
+	redfishAccess() *redfishAccessDetails
+}
+
+func (a *redfishAccessDetails) redfishAccess() *redfishAccessDetails {
+	return a
+}
+
+// Address returns the BMC address of the system, based on the address of
+// the Redfish service it was discovered from.
+func (s RedfishSystem) Address(serviceAddress string) (string, error) {
+	parsedURL, err := GetParsedURL(serviceAddress)
+	if err != nil {
+		return "", err
+	}
+	parsedURL.Path = s.Path
+	parsedURL.RawPath = ""
+	parsedURL.RawQuery = ""
+	parsedURL.Fragment = ""
+	return parsedURL.String(), nil
+}
+
+// HasMACAddress returns whether one of the network interfaces of the system
+// has the MAC address.
+func (s RedfishSystem) HasMACAddress(mac string) bool {
+	return slices.Contains(s.MACAddresses, strings.ToLower(mac))
+}
+
+// DiscoverRedfishSystems lists the systems managed by the Redfish service
+// of the BMC at the address, which may be the address of any of them.
+func DiscoverRedfishSystems(ctx context.Context, address string, disableCertificateVerification bool, bmcCreds Credentials) ([]RedfishSystem, error) {
+	accessDetails, err := NewAccessDetails(address, disableCertificateVerification)
+	if err != nil {
+		return nil, err
+	}
+	access, ok := accessDetails.(redfishAccess)
+	if !ok {
+		return nil, fmt.Errorf("BMC type %s does not use Redfish", accessDetails.Type())
+	}
+	return access.redfishAccess().discoverSystems(ctx, bmcCreds)
+}
+
+// FindRedfishSystem returns the system with a network interface having the
+// MAC address, among the systems managed by the Redfish service of the BMC
+// at the address.
+func FindRedfishSystem(ctx context.Context, address string, disableCertificateVerification bool, bmcCreds Credentials, mac string) (*RedfishSystem, error) {
+	systems, err := DiscoverRedfishSystems(ctx, address, disableCertificateVerification, bmcCreds)
+	if err != nil {
+		return nil, err
+	}
+	for _, system := range systems {
+		if system.HasMACAddress(mac) {
+			return &system, nil
+		}
+	}
+	return nil, &ProbeError{
+		Failure: ProbeSystemNotFound,
+		message: fmt.Sprintf("none of the %d systems of the Redfish service has MAC address %s", len(systems), mac),
+	}
+}
+
+func (a *redfishAccessDetails) discoverSystems(ctx context.Context, bmcCreds Credentials) ([]RedfishSystem, error) {
+	ctx, cancel := context.WithTimeout(ctx, redfishDiscoveryTimeout)
+	defer cancel()
+	client := a.probeClient()
+
+	var root redfishServiceRootResource
+	if _, err := a.redfishGet(ctx, client, redfishServiceRoot, nil, &root); err != nil {
+		return nil, &ProbeError{Failure: ProbeUnreachable, message: err.Error()}
+	}
+	if root.Systems.ID == "" {
+		return nil, nil
+	}
+	var collection redfishCollection
+	if code, err := a.redfishGet(ctx, client, root.Systems.ID, &bmcCreds, &collection); err != nil {
+		return nil, resourceError(code, err, ProbeSystemNotFound)
+	}
+
+	systems := make([]RedfishSystem, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		var resource redfishSystemResource
+		if code, err := a.redfishGet(ctx, client, member.ID, &bmcCreds, &resource); err != nil {
+			return nil, resourceError(code, err, ProbeSystemNotFound)
+		}
+		system := RedfishSystem{
+			Path:         member.ID,
+			ID:           resource.ID,
+			Name:         resource.Name,
+			Manufacturer: resource.Manufacturer,
+			Model:        resource.Model,
+			SerialNumber: resource.SerialNumber,
+			UUID:         resource.UUID,
+		}
+		macs, err := a.systemMACAddresses(ctx, client, resource.EthernetInterfaces, bmcCreds)
+		if err != nil {
+			return nil, err
+		}
+		system.MACAddresses = macs
+		systems = append(systems, system)
+	}
+	return systems, nil
+}
+
+// systemMACAddresses returns the MAC addresses of the network interfaces of
+// a system. The permanent address of an interface is listed along with the
+// current one when they differ.
+func (a *redfishAccessDetails) systemMACAddresses(ctx context.Context, client *http.Client, interfaces redfishLink, bmcCreds Credentials) ([]string, error) {
+	if interfaces.ID == "" {
+		return nil, nil
+	}
+	var collection redfishCollection
+	if code, err := a.redfishGet(ctx, client, interfaces.ID, &bmcCreds, &collection); err != nil {
+		return nil, resourceError(code, err, ProbeUnreachable)
+	}
+
+	var macs []string
+	for _, member := range collection.Members {
+		var resource redfishEthernetInterfaceResource
+		if code, err := a.redfishGet(ctx, client, member.ID, &bmcCreds, &resource); err != nil {
+			return nil, resourceError(code, err, ProbeUnreachable)
+		}
+		for _, mac := range []string{resource.MACAddress, resource.PermanentMACAddress} {
+			mac = strings.ToLower(mac)
+			if mac != "" && !slices.Contains(macs, mac) {
+				macs = append(macs, mac)
+			}
+		}
+	}
+	return macs, nil
+}
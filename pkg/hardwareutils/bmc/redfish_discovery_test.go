@@ -0,0 +1,120 @@
+package bmc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newMultiSystemService(t *testing.T) string {
+	t.Helper()
+	link := func(path string) map[string]string {
+		return map[string]string{"@odata.id": path}
+	}
+	server := newRedfishService(t, map[string]any{
+		"/redfish/v1/": map[string]any{"Systems": link("/redfish/v1/Systems")},
+		"/redfish/v1/Systems": map[string]any{
+			"Members": []map[string]string{link("/redfish/v1/Systems/sled1"), link("/redfish/v1/Systems/sled2")},
+		},
+		"/redfish/v1/Systems/sled1": map[string]any{
+			"Id":                 "sled1",
+			"Name":               "Sled 1",
+			"SerialNumber":       "SN0001",
+			"UUID":               "4c4c4544-0001",
+			"EthernetInterfaces": link("/redfish/v1/Systems/sled1/EthernetInterfaces"),
+		},
+		"/redfish/v1/Systems/sled1/EthernetInterfaces": map[string]any{
+			"Members": []map[string]string{link("/redfish/v1/Systems/sled1/EthernetInterfaces/nic1")},
+		},
+		"/redfish/v1/Systems/sled1/EthernetInterfaces/nic1": map[string]any{
+			"MACAddress":          "52:54:00:AA:00:01",
+			"PermanentMACAddress": "52:54:00:AA:00:01",
+		},
+		"/redfish/v1/Systems/sled2": map[string]any{
+			"Id":                 "sled2",
+			"Name":               "Sled 2",
+			"SerialNumber":       "SN0002",
+			"UUID":               "4c4c4544-0002",
+			"EthernetInterfaces": link("/redfish/v1/Systems/sled2/EthernetInterfaces"),
+		},
+		"/redfish/v1/Systems/sled2/EthernetInterfaces": map[string]any{
+			"Members": []map[string]string{
+				link("/redfish/v1/Systems/sled2/EthernetInterfaces/nic1"),
+				link("/redfish/v1/Systems/sled2/EthernetInterfaces/nic2"),
+			},
+		},
+		"/redfish/v1/Systems/sled2/EthernetInterfaces/nic1": map[string]any{
+			"MACAddress":          "52:54:00:aa:00:02",
+			"PermanentMACAddress": "52:54:00:aa:00:12",
+		},
+		"/redfish/v1/Systems/sled2/EthernetInterfaces/nic2": map[string]any{
+			"MACAddress": "52:54:00:aa:00:03",
+		},
+	})
+	return server.URL
+}
+
+func TestDiscoverRedfishSystems(t *testing.T) {
+	serviceURL := newMultiSystemService(t)
+	address := "redfish-virtualmedia+" + serviceURL + "/redfish/v1"
+	creds := Credentials{Username: "admin", Password: "secret"}
+
+	systems, err := DiscoverRedfishSystems(t.Context(), address, true, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(systems) != 2 {
+		t.Fatalf("expected 2 systems, got %+v", systems)
+	}
+	expected := RedfishSystem{
+		Path:         "/redfish/v1/Systems/sled2",
+		ID:           "sled2",
+		Name:         "Sled 2",
+		SerialNumber: "SN0002",
+		UUID:         "4c4c4544-0002",
+		MACAddresses: []string{"52:54:00:aa:00:02", "52:54:00:aa:00:12", "52:54:00:aa:00:03"},
+	}
+	if !reflect.DeepEqual(systems[1], expected) {
+		t.Errorf("expected %+v, got %+v", expected, systems[1])
+	}
+	if !reflect.DeepEqual(systems[0].MACAddresses, []string{"52:54:00:aa:00:01"}) {
+		t.Errorf("expected a single lower case MAC address, got %v", systems[0].MACAddresses)
+	}
+
+	systemAddress, err := systems[1].Address(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if systemAddress != "redfish-virtualmedia+"+serviceURL+"/redfish/v1/Systems/sled2" {
+		t.Errorf("unexpected system address %s", systemAddress)
+	}
+
+	if _, err := DiscoverRedfishSystems(t.Context(), address, true, Credentials{Username: "admin", Password: "wrong"}); err == nil {
+		t.Error("expected the credentials to be rejected")
+	}
+}
+
+func TestFindRedfishSystem(t *testing.T) {
+	address := "redfish+" + newMultiSystemService(t)
+	creds := Credentials{Username: "admin", Password: "secret"}
+
+	system, err := FindRedfishSystem(t.Context(), address, true, creds, "52:54:00:AA:00:12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if system.ID != "sled2" {
+		t.Errorf("expected sled2, got %s", system.ID)
+	}
+
+	_, err = FindRedfishSystem(t.Context(), address, true, creds, "52:54:00:aa:00:99")
+	probeErr := &ProbeError{}
+	if !errors.As(err, &probeErr) || probeErr.Failure != ProbeSystemNotFound {
+		t.Errorf("expected no system to be found, got %v", err)
+	}
+}
+
+func TestDiscoverRedfishSystemsNotRedfish(t *testing.T) {
+	if _, err := DiscoverRedfishSystems(t.Context(), "ipmi://192.168.122.1", false, Credentials{}); err == nil {
+		t.Error("expected IPMI addresses to be refused")
+	}
+}
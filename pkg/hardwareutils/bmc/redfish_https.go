@@ -23,6 +23,7 @@ type redfishHTTPBootMediaAccessDetails struct {
 	host                           string
 	path                           string
 	disableCertificateVerification bool
+	tlsOptions                     TLSOptions
 }
 
 func (a *redfishHTTPBootMediaAccessDetails) Type() string {
@@ -59,8 +60,8 @@ func (a *redfishHTTPBootMediaAccessDetails) DriverInfo(bmcCreds Credentials) map
 		"redfish_address":   getRedfishAddress(a.bmcType, a.host),
 	}
 
-	if a.disableCertificateVerification {
-		result["redfish_verify_ca"] = false
+	if verifyCA := verifyCAValue(a.tlsOptions, a.disableCertificateVerification); verifyCA != nil {
+		result["redfish_verify_ca"] = verifyCA
 	}
 
 	return result
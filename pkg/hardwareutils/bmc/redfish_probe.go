@@ -0,0 +1,128 @@
+package bmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redfishProbeTimeout bounds all the requests of a single probe.
+const redfishProbeTimeout = 10 * time.Second
+
+// redfishServiceRoot is the path of the Redfish service root, which is
+// fixed by the specification.
+const redfishServiceRoot = "/redfish/v1/"
+
+// redfishLink is a reference to another Redfish resource.
+type redfishLink struct {
+	ID string `json:"@odata.id"`
+}
+
+type redfishServiceRootResource struct {
+	Systems redfishLink `json:"Systems"`
+}
+
+type redfishCollection struct {
+	Members []redfishLink `json:"Members"`
+}
+
+// systemPath returns the path of the System resource given in the BMC
+// address, if any.
+func (a *redfishAccessDetails) systemPath() string {
+	trimmedPath := strings.Trim(a.path, "/")
+	if trimmedPath == "" || trimmedPath == "redfish/v1" {
+		return ""
+	}
+	return a.path
+}
+
+func (a *redfishAccessDetails) probeClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.TLSClientConfig = tlsConfig(a.tlsOptions, a.disableCertificateVerification)
+	return &http.Client{Transport: transport}
+}
+
+// redfishGet fetches a Redfish resource into v. Credentials are only sent
+// when given, as the service root must be readable without them.
+func (a *redfishAccessDetails) redfishGet(ctx context.Context, client *http.Client, path string, bmcCreds *Credentials, v any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getRedfishAddress(a.bmcType, a.host)+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bmcCreds != nil {
+		req.SetBasicAuth(bmcCreds.Username, bmcCreds.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, fmt.Errorf("GET %s returned %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp.StatusCode, fmt.Errorf("GET %s returned an invalid document: %w", path, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// resourceError classifies the failure to read a resource requiring
+// authentication.
+func resourceError(code int, err error, notFound ProbeFailure) error {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &ProbeError{Failure: ProbeUnauthorized, message: "the BMC rejected the credentials: " + err.Error()}
+	case http.StatusNotFound:
+		return &ProbeError{Failure: notFound, message: err.Error()}
+	default:
+		return &ProbeError{Failure: ProbeUnreachable, message: err.Error()}
+	}
+}
+
+// Probe checks that the Redfish service answers, accepts the credentials
+// and that the System resource exists. When the address does not include
+// the path of the system, the service must manage exactly one.
+func (a *redfishAccessDetails) Probe(ctx context.Context, bmcCreds Credentials) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, redfishProbeTimeout)
+	defer cancel()
+	client := a.probeClient()
+
+	var root redfishServiceRootResource
+	if _, err := a.redfishGet(ctx, client, redfishServiceRoot, nil, &root); err != nil {
+		return "", &ProbeError{Failure: ProbeUnreachable, message: err.Error()}
+	}
+
+	if systemPath := a.systemPath(); systemPath != "" {
+		var system map[string]any
+		if code, err := a.redfishGet(ctx, client, systemPath, &bmcCreds, &system); err != nil {
+			return "", resourceError(code, err, ProbeSystemNotFound)
+		}
+		return systemPath, nil
+	}
+
+	if root.Systems.ID == "" {
+		return "", &ProbeError{Failure: ProbeSystemNotFound, message: "the Redfish service does not expose any systems"}
+	}
+	var systems redfishCollection
+	if code, err := a.redfishGet(ctx, client, root.Systems.ID, &bmcCreds, &systems); err != nil {
+		return "", resourceError(code, err, ProbeSystemNotFound)
+	}
+	switch len(systems.Members) {
+	case 0:
+		return "", &ProbeError{Failure: ProbeSystemNotFound, message: "the Redfish service does not manage any system"}
+	case 1:
+		return systems.Members[0].ID, nil
+	default:
+		return "", &ProbeError{
+			Failure: ProbeSystemNotUnique,
+			message: fmt.Sprintf("the Redfish service manages %d systems, the BMC address must include the path of one of them", len(systems.Members)),
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package bmc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newRedfishStub serves a Redfish service root and a systems collection
+// with the given members.
+func newRedfishStub(t *testing.T, members ...string) *httptest.Server {
+	t.Helper()
+	resources := map[string]any{
+		"/redfish/v1/": map[string]any{
+			"Systems": map[string]string{"@odata.id": "/redfish/v1/Systems"},
+		},
+	}
+	collection := []map[string]string{}
+	for _, member := range members {
+		collection = append(collection, map[string]string{"@odata.id": member})
+		resources[member] = map[string]string{"@odata.id": member}
+	}
+	resources["/redfish/v1/Systems"] = map[string]any{"Members": collection}
+	return newRedfishService(t, resources)
+}
+
+// newRedfishService serves the resources by path, requiring the "admin"
+// user with password "secret" for everything but the service root.
+func newRedfishService(t *testing.T, resources map[string]any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, ok := resources[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path != "/redfish/v1/" {
+			if username, password, _ := r.BasicAuth(); username != "admin" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resource)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRedfishProbe(t *testing.T) {
+	for _, tc := range []struct {
+		Scenario        string
+		Members         []string
+		Path            string
+		Type            string
+		Credentials     Credentials
+		SkipVerify      bool
+		ExpectedPath    string
+		ExpectedFailure ProbeFailure
+	}{
+		{
+			Scenario:     "single system",
+			Members:      []string{"/redfish/v1/Systems/1"},
+			ExpectedPath: "/redfish/v1/Systems/1",
+		},
+		{
+			Scenario:     "explicit system",
+			Members:      []string{"/redfish/v1/Systems/1", "/redfish/v1/Systems/2"},
+			Path:         "/redfish/v1/Systems/2",
+			ExpectedPath: "/redfish/v1/Systems/2",
+		},
+		{
+			Scenario:     "vendor type",
+			Members:      []string{"/redfish/v1/Systems/System.Embedded.1"},
+			Type:         "idrac-virtualmedia",
+			ExpectedPath: "/redfish/v1/Systems/System.Embedded.1",
+		},
+		{
+			Scenario:        "bad credentials",
+			Members:         []string{"/redfish/v1/Systems/1"},
+			Credentials:     Credentials{Username: "admin", Password: "wrong"},
+			ExpectedFailure: ProbeUnauthorized,
+		},
+		{
+			Scenario:        "missing system",
+			Members:         []string{"/redfish/v1/Systems/1"},
+			Path:            "/redfish/v1/Systems/2",
+			ExpectedFailure: ProbeSystemNotFound,
+		},
+		{
+			Scenario:        "no systems",
+			ExpectedFailure: ProbeSystemNotFound,
+		},
+		{
+			Scenario:        "several systems",
+			Members:         []string{"/redfish/v1/Systems/1", "/redfish/v1/Systems/2"},
+			ExpectedFailure: ProbeSystemNotUnique,
+		},
+		{
+			Scenario:        "certificate not trusted",
+			Members:         []string{"/redfish/v1/Systems/1"},
+			SkipVerify:      false,
+			ExpectedFailure: ProbeUnreachable,
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			server := newRedfishStub(t, tc.Members...)
+			bmcType := tc.Type
+			if bmcType == "" {
+				bmcType = "redfish"
+			}
+			creds := tc.Credentials
+			if creds == (Credentials{}) {
+				creds = Credentials{Username: "admin", Password: "secret"}
+			}
+			skipVerify := tc.SkipVerify || tc.ExpectedFailure != ProbeUnreachable
+
+			address := bmcType + "+" + strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + tc.Path
+			acc, err := NewAccessDetails(address, skipVerify)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prober, ok := acc.(Prober)
+			if !ok {
+				t.Fatalf("%s access details do not implement Prober", bmcType)
+			}
+
+			systemPath, err := prober.Probe(t.Context(), creds)
+			if tc.ExpectedFailure == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if systemPath != tc.ExpectedPath {
+					t.Errorf("expected system %q, got %q", tc.ExpectedPath, systemPath)
+				}
+				return
+			}
+			probeErr := &ProbeError{}
+			if !errors.As(err, &probeErr) {
+				t.Fatalf("expected a probe error, got %v", err)
+			}
+			if probeErr.Failure != tc.ExpectedFailure {
+				t.Errorf("expected failure %s, got %s: %v", tc.ExpectedFailure, probeErr.Failure, err)
+			}
+		})
+	}
+}
+
+func TestRedfishProbeUnreachable(t *testing.T) {
+	server := newRedfishStub(t)
+	address := "redfish+" + server.URL
+	server.Close()
+
+	acc, err := NewAccessDetails(address, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = acc.(Prober).Probe(t.Context(), Credentials{Username: "admin", Password: "secret"}) //nolint:forcetypeassert
+	probeErr := &ProbeError{}
+	if !errors.As(err, &probeErr) || probeErr.Failure != ProbeUnreachable {
+		t.Errorf("expected the BMC to be unreachable, got %v", err)
+	}
+}
+
+func TestIPMIIsNotProbed(t *testing.T) {
+	acc, err := NewAccessDetails("ipmi://192.168.122.1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := acc.(Prober); ok {
+		t.Error("IPMI access details are not expected to implement Prober")
+	}
+}
@@ -0,0 +1,225 @@
+package bmc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// TLSOptions customize the verification of the certificate of a BMC, in
+// place of the system CA certificates.
+type TLSOptions struct {
+	// CABundle holds the PEM encoded CA certificates the certificate of
+	// the BMC is verified with.
+	CABundle []byte
+	// CABundlePath is where the provisioner finds the CA bundle, passed to
+	// it in the driver info.
+	CABundlePath string
+	// CertificateFingerprint is the SHA-256 fingerprint the certificate of
+	// the BMC must have.
+	CertificateFingerprint string
+}
+
+// tlsConfigurable is implemented by the AccessDetails of BMCs reached over
+// HTTPS.
+type tlsConfigurable interface {
+	setTLSOptions(options TLSOptions)
+}
+
+func (a *redfishAccessDetails) setTLSOptions(options TLSOptions) {
+	a.tlsOptions = options
+}
+
+func (a *redfishHTTPBootMediaAccessDetails) setTLSOptions(options TLSOptions) {
+	a.tlsOptions = options
+}
+
+// SupportsTLSOptions returns whether the certificate verification of the
+// BMC can be customized with TLSOptions.
+func SupportsTLSOptions(accessDetails AccessDetails) bool {
+	_, ok := accessDetails.(tlsConfigurable)
+	return ok
+}
+
+// ConfigureTLS sets how the certificate of the BMC is verified.
+func ConfigureTLS(accessDetails AccessDetails, options TLSOptions) error {
+	configurable, ok := accessDetails.(tlsConfigurable)
+	if !ok {
+		return fmt.Errorf("BMC type %s does not support custom certificate verification", accessDetails.Type())
+	}
+	if len(options.CABundle) > 0 {
+		if _, err := ParseCABundle(options.CABundle); err != nil {
+			return err
+		}
+	}
+	if options.CertificateFingerprint != "" {
+		if _, err := ParseCertificateFingerprint(options.CertificateFingerprint); err != nil {
+			return err
+		}
+	}
+	configurable.setTLSOptions(options)
+	return nil
+}
+
+// ParseCABundle returns the pool of the PEM encoded certificates of the
+// bundle.
+func ParseCABundle(bundle []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("the CA bundle has no PEM encoded certificate")
+	}
+	return pool, nil
+}
+
+// ParseCertificateFingerprint decodes a SHA-256 fingerprint given as
+// hexadecimal digits, optionally separated by colons and prefixed with
+// "sha256:".
+func ParseCertificateFingerprint(fingerprint string) ([]byte, error) {
+	digits := strings.ReplaceAll(strings.TrimPrefix(strings.ToLower(fingerprint), "sha256:"), ":", "")
+	sum, err := hex.DecodeString(digits)
+	if err != nil || len(sum) != sha256.Size {
+		return nil, fmt.Errorf("certificate fingerprint %q is not a SHA-256 fingerprint", fingerprint)
+	}
+	return sum, nil
+}
+
+// verifyFingerprint returns a function checking that the certificate of
+// the peer has the fingerprint, for tls.Config.VerifyPeerCertificate.
+func verifyFingerprint(sum []byte) func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("the BMC presented no certificate")
+		}
+		actual := sha256.Sum256(rawCerts[0])
+		if subtle.ConstantTimeCompare(actual[:], sum) != 1 {
+			return fmt.Errorf("the certificate of the BMC has fingerprint %s, not the pinned one", hex.EncodeToString(actual[:]))
+		}
+		return nil
+	}
+}
+
+// tlsConfig returns the TLS configuration for requests of the operator to
+// the BMC.
+func tlsConfig(options TLSOptions, disableCertificateVerification bool) *tls.Config {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if disableCertificateVerification {
+		config.InsecureSkipVerify = true //nolint:gosec
+		return config
+	}
+	if pool, err := ParseCABundle(options.CABundle); err == nil {
+		config.RootCAs = pool
+	}
+	if sum, err := ParseCertificateFingerprint(options.CertificateFingerprint); err == nil {
+		// A pinned certificate is trusted on its own, unless a CA bundle is
+		// given as well.
+		config.InsecureSkipVerify = config.RootCAs == nil //nolint:gosec // verified against the fingerprint
+		config.VerifyPeerCertificate = verifyFingerprint(sum)
+	}
+	return config
+}
+
+// verifyCAValue returns the value of the driver info field enabling or
+// disabling the verification of the certificate of the BMC, or nil to leave
+// the default.
+func verifyCAValue(options TLSOptions, disableCertificateVerification bool) any {
+	switch {
+	case disableCertificateVerification:
+		return false
+	case options.CABundlePath != "":
+		return options.CABundlePath
+	default:
+		return nil
+	}
+}
+
+// FetchPinnedCertificate connects to the BMC at the address and returns its
+// certificate, PEM encoded, if it has the fingerprint. The certificate can
+// then be used as a CA bundle by clients unable to pin certificates, which
+// still check the host name: it is refused unless it is self-signed and
+// valid for the host of the address.
+func FetchPinnedCertificate(ctx context.Context, address, fingerprint string) ([]byte, error) {
+	sum, err := ParseCertificateFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	parsedURL, err := GetParsedURL(address)
+	if err != nil {
+		return nil, err
+	}
+	hostPort, err := httpsHostPort(parsedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaf []byte
+	dialer := &tls.Dialer{Config: &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, //nolint:gosec // verified against the fingerprint
+		VerifyPeerCertificate: func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+			if err := verifyFingerprint(sum)(rawCerts, chains); err != nil {
+				return err
+			}
+			leaf = rawCerts[0]
+			return nil
+		},
+	}}
+	ctx, cancel := context.WithTimeout(ctx, redfishProbeTimeout)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the certificate of the BMC: %w", err)
+	}
+	conn.Close()
+
+	if err := checkUsableAsCA(leaf, parsedURL.Hostname()); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: leaf}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// checkUsableAsCA returns an error if the certificate cannot verify itself
+// as a CA bundle when connecting to the host.
+func checkUsableAsCA(raw []byte, host string) error {
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse the certificate of the BMC: %w", err)
+	}
+	if err := cert.CheckSignatureFrom(cert); err != nil {
+		return fmt.Errorf("the pinned certificate of the BMC is not self-signed, use a CA bundle instead: %w", err)
+	}
+	if err := cert.VerifyHostname(host); err != nil {
+		return fmt.Errorf("the pinned certificate of the BMC cannot be used to verify it: %w", err)
+	}
+	return nil
+}
+
+// httpsHostPort returns the host and port to connect to for a BMC address
+// using HTTPS, such as redfish+https://bmc/redfish/v1/Systems/1.
+func httpsHostPort(parsedURL *url.URL) (string, error) {
+	scheme := redfishDefaultScheme
+	if _, transport, found := strings.Cut(parsedURL.Scheme, "+"); found {
+		scheme = transport
+	}
+	if scheme != "https" {
+		return "", fmt.Errorf("BMC address %s does not use HTTPS", parsedURL.Redacted())
+	}
+	if parsedURL.Port() != "" {
+		return parsedURL.Host, nil
+	}
+	return net.JoinHostPort(parsedURL.Hostname(), "443"), nil
+}
@@ -0,0 +1,162 @@
+package bmc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func certificatePEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+func certificateFingerprint(server *httptest.Server) string {
+	sum := sha256.Sum256(server.Certificate().Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParseCertificateFingerprint(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	colons := strings.TrimSuffix(strings.Repeat("AB:", sha256.Size), ":")
+	for _, tc := range []struct {
+		Fingerprint string
+		Valid       bool
+	}{
+		{Fingerprint: sum, Valid: true},
+		{Fingerprint: colons, Valid: true},
+		{Fingerprint: "sha256:" + colons, Valid: true},
+		{Fingerprint: sum[2:]},
+		{Fingerprint: "sha1:" + sum},
+		{Fingerprint: strings.Repeat("zz", sha256.Size)},
+	} {
+		t.Run(tc.Fingerprint, func(t *testing.T) {
+			_, err := ParseCertificateFingerprint(tc.Fingerprint)
+			if tc.Valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRedfishProbeTLSOptions(t *testing.T) {
+	server := newRedfishStub(t, "/redfish/v1/Systems/1")
+	wrongFingerprint := strings.Repeat("00", sha256.Size)
+
+	for _, tc := range []struct {
+		Scenario        string
+		Options         TLSOptions
+		ExpectedFailure bool
+	}{
+		{
+			Scenario: "CA bundle",
+			Options:  TLSOptions{CABundle: certificatePEM(server)},
+		},
+		{
+			Scenario: "pinned certificate",
+			Options:  TLSOptions{CertificateFingerprint: certificateFingerprint(server)},
+		},
+		{
+			Scenario: "CA bundle and pinned certificate",
+			Options:  TLSOptions{CABundle: certificatePEM(server), CertificateFingerprint: certificateFingerprint(server)},
+		},
+		{
+			Scenario:        "other pinned certificate",
+			Options:         TLSOptions{CertificateFingerprint: wrongFingerprint},
+			ExpectedFailure: true,
+		},
+		{
+			Scenario:        "CA bundle and other pinned certificate",
+			Options:         TLSOptions{CABundle: certificatePEM(server), CertificateFingerprint: wrongFingerprint},
+			ExpectedFailure: true,
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			acc, err := NewAccessDetails("redfish+"+server.URL+"/redfish/v1/Systems/1", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ConfigureTLS(acc, tc.Options); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = acc.(Prober).Probe(t.Context(), Credentials{Username: "admin", Password: "secret"}) //nolint:forcetypeassert
+			if !tc.ExpectedFailure {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			probeErr := &ProbeError{}
+			if !errors.As(err, &probeErr) || probeErr.Failure != ProbeUnreachable {
+				t.Errorf("expected the certificate to be refused, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTLSOptionsDriverInfo(t *testing.T) {
+	for _, address := range []string{"redfish://bmc/redfish/v1/Systems/1", "idrac-virtualmedia://bmc/redfish/v1/Systems/1", "redfish-uefihttp://bmc/redfish/v1/Systems/1"} {
+		t.Run(address, func(t *testing.T) {
+			acc, err := NewAccessDetails(address, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, found := acc.DriverInfo(Credentials{})["redfish_verify_ca"]; found {
+				t.Error("expected the default certificate verification")
+			}
+			if err := ConfigureTLS(acc, TLSOptions{CABundlePath: "/certs/bmc.pem"}); err != nil {
+				t.Fatal(err)
+			}
+			if verifyCA := acc.DriverInfo(Credentials{})["redfish_verify_ca"]; verifyCA != "/certs/bmc.pem" {
+				t.Errorf("expected the CA bundle to be used, got %v", verifyCA)
+			}
+		})
+	}
+}
+
+func TestConfigureTLSNotSupported(t *testing.T) {
+	acc, err := NewAccessDetails("ipmi://192.168.122.1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if SupportsTLSOptions(acc) {
+		t.Error("IPMI access details are not expected to support TLS options")
+	}
+	if err := ConfigureTLS(acc, TLSOptions{CABundlePath: "/certs/bmc.pem"}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestFetchPinnedCertificate(t *testing.T) {
+	server := newRedfishStub(t)
+	address := "redfish+" + server.URL + "/redfish/v1/Systems/1"
+
+	certificate, err := FetchPinnedCertificate(t.Context(), address, certificateFingerprint(server))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(certificate) != string(certificatePEM(server)) {
+		t.Errorf("unexpected certificate %s", certificate)
+	}
+
+	if _, err := FetchPinnedCertificate(t.Context(), address, strings.Repeat("00", sha256.Size)); err == nil {
+		t.Error("expected a certificate with another fingerprint to be refused")
+	}
+	if _, err := FetchPinnedCertificate(t.Context(), "redfish+http://bmc/redfish/v1/Systems/1", certificateFingerprint(server)); err == nil {
+		t.Error("expected an HTTP address to be refused")
+	}
+
+	// The certificate of the test server is not valid for localhost.
+	localhost := strings.Replace(address, "127.0.0.1", "localhost", 1)
+	_, err = FetchPinnedCertificate(t.Context(), localhost, certificateFingerprint(server))
+	if err == nil || !strings.Contains(err.Error(), "cannot be used to verify it") {
+		t.Errorf("expected a certificate not valid for the host name to be refused, got %v", err)
+	}
+}
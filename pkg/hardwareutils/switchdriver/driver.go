@@ -0,0 +1,81 @@
+package switchdriver
+
+import (
+	"slices"
+)
+
+// Credentials holds the contents of a switch credentials secret.
+type Credentials map[string][]byte
+
+// Switch contains the details of a switch needed to render its
+// configuration.
+type Switch struct {
+	// Name uniquely identifies the switch in the configuration.
+	Name string
+	// Address is the IP address or host name of the switch.
+	Address string
+	// MACAddress is the MAC address of the switch management interface.
+	MACAddress string
+	// Port is the management port, nil for the driver default.
+	Port *int32
+	// DeviceType is the driver-specific type of the device.
+	DeviceType string
+	// DisableCertificateVerification disables TLS or host key
+	// verification when connecting to the switch.
+	DisableCertificateVerification bool
+}
+
+// Config is the rendered configuration of a single switch.
+type Config struct {
+	// Entry is the configuration section for the switch.
+	Entry []byte
+	// Files maps file names to contents that must be made available
+	// to the networking service in the credentials directory, such as
+	// SSH private keys.
+	Files map[string][]byte
+}
+
+// Driver renders the configuration of switches managed by one of the
+// networking service drivers.
+type Driver interface {
+	// ValidateCredentials checks that the credentials contain the keys
+	// required by the driver. It returns a CredentialsValidationError
+	// otherwise.
+	ValidateCredentials(credentials Credentials) error
+
+	// RenderConfig returns the configuration of the switch in the format
+	// expected by the driver. Files in the returned Config are referenced
+	// relative to credentialsPath, the directory they are mounted in.
+	RenderConfig(sw Switch, credentials Credentials, credentialsPath string) (Config, error)
+
+	// DefaultPort returns the management port the driver connects to
+	// when the switch does not specify one.
+	DefaultPort() int32
+}
+
+var drivers = map[string]Driver{}
+
+// RegisterDriver maps a driver name, as used in the Driver field of a
+// BareMetalSwitch, to its implementation.
+func RegisterDriver(name string, driver Driver) {
+	drivers[name] = driver
+}
+
+// NewDriver returns the driver registered under the given name.
+func NewDriver(name string) (Driver, error) {
+	driver, ok := drivers[name]
+	if !ok {
+		return nil, UnknownDriverError{driver: name}
+	}
+	return driver, nil
+}
+
+// Names returns the sorted names of all registered drivers.
+func Names() []string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
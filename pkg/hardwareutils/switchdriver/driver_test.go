@@ -0,0 +1,234 @@
+package switchdriver
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewDriver(t *testing.T) {
+	for _, name := range []string{GenericSwitch, NetconfOpenConfig} {
+		if _, err := NewDriver(name); err != nil {
+			t.Errorf("driver %s is not registered: %s", name, err)
+		}
+	}
+
+	_, err := NewDriver("no-such-driver")
+	if !errors.As(err, &UnknownDriverError{}) {
+		t.Fatalf("expected UnknownDriverError, got %v", err)
+	}
+	expected := "unknown switch driver 'no-such-driver', supported drivers are: generic-switch, netconf-openconfig"
+	if err.Error() != expected {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestDefaultPort(t *testing.T) {
+	for name, expected := range map[string]int32{
+		GenericSwitch:     22,
+		NetconfOpenConfig: 830,
+	} {
+		driver, err := NewDriver(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port := driver.DefaultPort(); port != expected {
+			t.Errorf("driver %s: expected default port %d, got %d", name, expected, port)
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	for _, tc := range []struct {
+		Scenario    string
+		Credentials Credentials
+		ExpectError bool
+	}{
+		{
+			Scenario: "password",
+			Credentials: Credentials{
+				"username": []byte("admin"),
+				"password": []byte("secret"),
+			},
+		},
+		{
+			Scenario: "private key",
+			Credentials: Credentials{
+				"username":       []byte("admin"),
+				"ssh-privatekey": []byte("key"),
+			},
+		},
+		{
+			Scenario: "missing username",
+			Credentials: Credentials{
+				"password": []byte("secret"),
+			},
+			ExpectError: true,
+		},
+		{
+			Scenario: "missing password",
+			Credentials: Credentials{
+				"username": []byte("admin"),
+			},
+			ExpectError: true,
+		},
+	} {
+		for _, name := range Names() {
+			t.Run(name+" "+tc.Scenario, func(t *testing.T) {
+				driver, err := NewDriver(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = driver.ValidateCredentials(tc.Credentials)
+				if tc.ExpectError {
+					if !errors.As(err, &CredentialsValidationError{}) {
+						t.Errorf("expected CredentialsValidationError, got %v", err)
+					}
+				} else if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			})
+		}
+	}
+}
+
+func TestNetconfRenderConfig(t *testing.T) {
+	port := int32(830)
+	sw := Switch{
+		Name:                           "leaf1",
+		Address:                        "192.168.1.1",
+		MACAddress:                     "aa:bb:cc:dd:ee:ff",
+		Port:                           &port,
+		DeviceType:                     "nexus",
+		DisableCertificateVerification: true,
+	}
+
+	for _, tc := range []struct {
+		Scenario    string
+		Credentials Credentials
+		Expected    string
+		Files       map[string][]byte
+	}{
+		{
+			Scenario: "password",
+			Credentials: Credentials{
+				"username": []byte("admin"),
+				"password": []byte("secret"),
+			},
+			Expected: `[switch:leaf1]
+address=192.168.1.1
+mac_address=aa:bb:cc:dd:ee:ff
+port=830
+driver_type=netconf-openconfig
+device_type=nexus
+insecure=true
+username=admin
+password=secret
+
+`,
+			Files: map[string][]byte{},
+		},
+		{
+			Scenario: "private key",
+			Credentials: Credentials{
+				"username":       []byte("admin"),
+				"ssh-privatekey": []byte("key"),
+			},
+			Expected: `[switch:leaf1]
+address=192.168.1.1
+mac_address=aa:bb:cc:dd:ee:ff
+port=830
+driver_type=netconf-openconfig
+device_type=nexus
+insecure=true
+username=admin
+key_file=/etc/creds/aa-bb-cc-dd-ee-ff.key
+
+`,
+			Files: map[string][]byte{
+				"aa-bb-cc-dd-ee-ff.key": []byte("key"),
+			},
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			config, err := netconfDriver{}.RenderConfig(sw, tc.Credentials, "/etc/creds")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(config.Entry) != tc.Expected {
+				t.Errorf("unexpected config:\n%s", config.Entry)
+			}
+			if !reflect.DeepEqual(config.Files, tc.Files) {
+				t.Errorf("unexpected files: %v", config.Files)
+			}
+		})
+	}
+}
+
+// TestRenderConfigSample renders the switches of a fabric mixing both
+// drivers and compares the result with a sample configuration file of the
+// networking service.
+func TestRenderConfigSample(t *testing.T) {
+	port := int32(830)
+	switches := []struct {
+		Driver      string
+		Switch      Switch
+		Credentials Credentials
+	}{
+		{
+			Driver: GenericSwitch,
+			Switch: Switch{
+				Name:       "tor1",
+				Address:    "192.0.2.10",
+				MACAddress: "00:00:5e:00:53:10",
+				DeviceType: "netmiko_dell_os10",
+			},
+			Credentials: Credentials{
+				"username":       []byte("admin"),
+				"password":       []byte("secret"),
+				"admin-password": []byte("enable"),
+			},
+		},
+		{
+			Driver: NetconfOpenConfig,
+			Switch: Switch{
+				Name:                           "leaf1",
+				Address:                        "leaf1.example.com",
+				MACAddress:                     "00:00:5e:00:53:20",
+				Port:                           &port,
+				DeviceType:                     "nexus",
+				DisableCertificateVerification: true,
+			},
+			Credentials: Credentials{
+				"username":       []byte("netconf"),
+				"ssh-privatekey": []byte("key"),
+				"admin-password": []byte("ignored"),
+			},
+		},
+	}
+
+	var rendered bytes.Buffer
+	rendered.WriteString("# This file is managed by the Baremetal Operator\n\n")
+	for _, sw := range switches {
+		driver, err := NewDriver(sw.Driver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config, err := driver.RenderConfig(sw.Switch, sw.Credentials, "/etc/ironic/switch-credentials")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rendered.Write(config.Entry)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "switch-configs.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered.String() != string(expected) {
+		t.Errorf("rendered config does not match testdata/switch-configs.conf:\n%s", rendered.String())
+	}
+}
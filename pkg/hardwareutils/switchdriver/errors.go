@@ -0,0 +1,27 @@
+package switchdriver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownDriverError is returned when no driver is registered under the
+// requested name.
+type UnknownDriverError struct {
+	driver string
+}
+
+func (e UnknownDriverError) Error() string {
+	return fmt.Sprintf("unknown switch driver '%s', supported drivers are: %s",
+		e.driver, strings.Join(Names(), ", "))
+}
+
+// CredentialsValidationError is returned when the switch credentials do
+// not contain the keys required by the driver.
+type CredentialsValidationError struct {
+	message string
+}
+
+func (e CredentialsValidationError) Error() string {
+	return e.message
+}
@@ -0,0 +1,130 @@
+package switchdriver
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// GenericSwitch is the name of the networking-generic-switch driver.
+const GenericSwitch = "generic-switch"
+
+func init() {
+	RegisterDriver(GenericSwitch, genericSwitchDriver{})
+}
+
+// genericSwitchDriver renders the INI configuration consumed by
+// networking-generic-switch. It supports password and SSH public key
+// authentication with an optional enable secret.
+type genericSwitchDriver struct{}
+
+type genericSwitchData struct {
+	Name          string
+	Address       string
+	MACAddress    string
+	Port          *int32
+	Driver        string
+	DeviceType    string
+	Insecure      bool
+	Username      string
+	Password      string //nolint: gosec
+	KeyFile       string
+	AdminPassword string
+}
+
+// genericSwitchTemplate is the INI-format template for a single switch config section.
+var genericSwitchTemplate = template.Must(template.New("genericSwitch").Parse(
+	`[switch:{{.Name}}]
+address={{.Address}}
+mac_address={{.MACAddress}}
+{{- if .Port}}
+port={{.Port}}
+{{- end}}
+driver_type={{.Driver}}
+device_type={{.DeviceType}}
+{{- if .Insecure}}
+insecure={{.Insecure}}
+{{- end}}
+username={{.Username}}
+{{- if .KeyFile}}
+key_file={{.KeyFile}}
+{{- else}}
+password={{.Password}}
+{{- end}}
+{{- if .AdminPassword}}
+enable_secret={{.AdminPassword}}
+{{- end}}
+
+`))
+
+// validateSSHCredentials checks for a username and either a password or an
+// SSH private key, as used by the SSH-based drivers.
+func validateSSHCredentials(credentials Credentials) error {
+	if _, ok := credentials["username"]; !ok {
+		return CredentialsValidationError{message: "missing 'username' key"}
+	}
+	_, hasPrivateKey := credentials["ssh-privatekey"]
+	_, hasPassword := credentials["password"]
+	if !hasPrivateKey && !hasPassword {
+		return CredentialsValidationError{message: "missing 'password' or 'ssh-privatekey' key"}
+	}
+	return nil
+}
+
+// keyFileName returns the name of the file holding the SSH private key of
+// a switch. Colons are replaced with dashes because they are not valid in
+// Kubernetes secret data keys.
+func keyFileName(sw Switch) string {
+	return strings.ReplaceAll(sw.MACAddress, ":", "-") + ".key"
+}
+
+func (genericSwitchDriver) ValidateCredentials(credentials Credentials) error {
+	return validateSSHCredentials(credentials)
+}
+
+// DefaultPort returns the SSH port used by netmiko.
+func (genericSwitchDriver) DefaultPort() int32 {
+	return 22
+}
+
+func (d genericSwitchDriver) RenderConfig(sw Switch, credentials Credentials, credentialsPath string) (Config, error) {
+	if err := d.ValidateCredentials(credentials); err != nil {
+		return Config{}, err
+	}
+	return renderSwitchSection(sw, GenericSwitch, credentials, string(credentials["admin-password"]), credentialsPath)
+}
+
+// renderSwitchSection renders the section of a switch in the configuration
+// of the networking service. The keys are the same for every driver, which
+// is selected by driver_type.
+func renderSwitchSection(sw Switch, driver string, credentials Credentials, adminPassword, credentialsPath string) (Config, error) {
+	data := genericSwitchData{
+		Name:          sw.Name,
+		Address:       sw.Address,
+		MACAddress:    sw.MACAddress,
+		Port:          sw.Port,
+		Driver:        driver,
+		DeviceType:    sw.DeviceType,
+		Insecure:      sw.DisableCertificateVerification,
+		Username:      string(credentials["username"]),
+		AdminPassword: adminPassword,
+	}
+
+	files := map[string][]byte{}
+	if privateKey, ok := credentials["ssh-privatekey"]; ok {
+		name := keyFileName(sw)
+		files[name] = privateKey
+		data.KeyFile = filepath.Join(credentialsPath, name)
+	} else {
+		data.Password = string(credentials["password"])
+	}
+
+	var buf bytes.Buffer
+	if err := genericSwitchTemplate.Execute(&buf, data); err != nil {
+		return Config{}, fmt.Errorf("failed to render switch config template for %s: %w", sw.Name, err)
+	}
+
+	return Config{Entry: buf.Bytes(), Files: files}, nil
+}
@@ -0,0 +1,32 @@
+package switchdriver
+
+// NetconfOpenConfig is the name of the networking-baremetal NETCONF driver
+// for devices implementing the OpenConfig YANG models.
+const NetconfOpenConfig = "netconf-openconfig"
+
+func init() {
+	RegisterDriver(NetconfOpenConfig, netconfDriver{})
+}
+
+// netconfDriver renders the switch sections of the netconf-openconfig
+// driver, with the same keys as the generic-switch ones. The device type is
+// the ncclient device handler. NETCONF runs over SSH, so the same
+// credentials as for networking-generic-switch are accepted, except for the
+// enable secret which has no equivalent.
+type netconfDriver struct{}
+
+func (netconfDriver) ValidateCredentials(credentials Credentials) error {
+	return validateSSHCredentials(credentials)
+}
+
+// DefaultPort returns the port assigned to NETCONF over SSH (RFC 6242).
+func (netconfDriver) DefaultPort() int32 {
+	return 830
+}
+
+func (d netconfDriver) RenderConfig(sw Switch, credentials Credentials, credentialsPath string) (Config, error) {
+	if err := d.ValidateCredentials(credentials); err != nil {
+		return Config{}, err
+	}
+	return renderSwitchSection(sw, NetconfOpenConfig, credentials, "", credentialsPath)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+)
+
+// maxReportedRejections bounds the number of rejected hosts listed in the
+// condition of a HostClaim.
+const maxReportedRejections = 10
+
+// HostsRejectedError is returned when no host is available because all the
+// hosts otherwise suitable for a claim were rejected. It matches
+// ErrNoAvailableBMH with errors.Is.
+type HostsRejectedError struct {
+	// Rejections explain why each host was rejected.
+	Rejections []string
+}
+
+func (e HostsRejectedError) Error() string {
+	return fmt.Sprintf("%s, rejected hosts: %s", ErrNoAvailableBMH, e.Summary())
+}
+
+func (e HostsRejectedError) Is(target error) bool {
+	return target == ErrNoAvailableBMH
+}
+
+// Summary lists the first rejections.
+func (e HostsRejectedError) Summary() string {
+	if len(e.Rejections) <= maxReportedRejections {
+		return strings.Join(e.Rejections, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(e.Rejections[:maxReportedRejections], "; "),
+		len(e.Rejections)-maxReportedRejections)
+}
+
+// checkHardwareRequirements returns why the hardware of a host does not
+// satisfy the requirements, or an empty string if it does.
+func checkHardwareRequirements(requirements *metal3api.HardwareRequirements, hardwareData *metal3api.HardwareData) string {
+	if requirements == nil {
+		return ""
+	}
+	if hardwareData == nil || hardwareData.Spec.HardwareDetails == nil {
+		return "no HardwareData"
+	}
+	details := hardwareData.Spec.HardwareDetails
+
+	var unmet []string
+	if cpu := requirements.CPU; cpu != nil {
+		if details.CPU.Count < cpu.MinCount {
+			unmet = append(unmet, fmt.Sprintf("%d CPUs, %d required", details.CPU.Count, cpu.MinCount))
+		}
+		if cpu.Arch != "" && details.CPU.Arch != cpu.Arch {
+			unmet = append(unmet, fmt.Sprintf("CPU architecture %q, %q required", details.CPU.Arch, cpu.Arch))
+		}
+		var missing []string
+		for _, flag := range cpu.Flags {
+			if !slices.Contains(details.CPU.Flags, flag) {
+				missing = append(missing, flag)
+			}
+		}
+		if len(missing) > 0 {
+			unmet = append(unmet, "missing CPU flags "+strings.Join(missing, ","))
+		}
+	}
+
+	if details.RAMMebibytes < requirements.MinRAMMebibytes {
+		unmet = append(unmet, fmt.Sprintf("%d MiB of RAM, %d required",
+			details.RAMMebibytes, requirements.MinRAMMebibytes))
+	}
+
+	if disks := requirements.Disks; disks != nil {
+		count := 0
+		for _, disk := range details.Storage {
+			if disk.SizeBytes >= disks.MinSizeBytes && (disks.Type == "" || disk.Type == disks.Type) {
+				count++
+			}
+		}
+		if minCount := max(disks.MinCount, 1); count < minCount {
+			unmet = append(unmet, fmt.Sprintf("%d matching disks, %d required", count, minCount))
+		}
+	}
+
+	if nics := requirements.NICs; nics != nil {
+		// Dual-stack hosts report a NIC once per IP address
+		matching := map[string]bool{}
+		for _, nic := range details.NIC {
+			if nic.SpeedGbps >= nics.MinSpeedGbps {
+				matching[strings.ToLower(nic.MAC)] = true
+			}
+		}
+		if minCount := max(nics.MinCount, 1); len(matching) < minCount {
+			unmet = append(unmet, fmt.Sprintf("%d matching NICs, %d required", len(matching), minCount))
+		}
+	}
+
+	return strings.Join(unmet, ", ")
+}
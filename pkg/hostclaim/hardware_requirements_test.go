@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"errors"
+	"fmt"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hardware requirements", func() {
+
+	details := &metal3api.HardwareDetails{
+		CPU: metal3api.CPU{Arch: "x86_64", Count: 16, Flags: []string{"sse", "vmx"}},
+		// 64 GiB
+		RAMMebibytes: 65536,
+		Storage: []metal3api.Storage{
+			{Name: "/dev/sda", Type: metal3api.HDD, SizeBytes: 4 * metal3api.TeraByte},
+			{Name: "/dev/nvme0n1", Type: metal3api.NVME, SizeBytes: 500 * metal3api.GigaByte},
+		},
+		NIC: []metal3api.NIC{
+			{Name: "eth0", MAC: "00:11:22:33:44:55", SpeedGbps: 25, IP: "192.0.2.10"},
+			{Name: "eth0", MAC: "00:11:22:33:44:55", SpeedGbps: 25, IP: "2001:db8::10"},
+			{Name: "eth1", MAC: "00:11:22:33:44:56", SpeedGbps: 1},
+		},
+	}
+
+	type testCaseRequirements struct {
+		Requirements   *metal3api.HardwareRequirements
+		HardwareData   *metal3api.HardwareData
+		ExpectedReason string
+	}
+
+	DescribeTable("Test checkHardwareRequirements",
+		func(tc testCaseRequirements) {
+			hardwareData := tc.HardwareData
+			if hardwareData == nil {
+				hardwareData = &metal3api.HardwareData{Spec: metal3api.HardwareDataSpec{HardwareDetails: details}}
+			}
+			Expect(checkHardwareRequirements(tc.Requirements, hardwareData)).To(Equal(tc.ExpectedReason))
+		},
+		Entry("no requirements", testCaseRequirements{}),
+		Entry("all satisfied", testCaseRequirements{
+			Requirements: &metal3api.HardwareRequirements{
+				CPU:             &metal3api.CPURequirements{MinCount: 16, Arch: "x86_64", Flags: []string{"vmx"}},
+				MinRAMMebibytes: 65536,
+				Disks:           &metal3api.DiskRequirements{MinCount: 2, MinSizeBytes: 100 * metal3api.GigaByte},
+				NICs:            &metal3api.NICRequirements{MinCount: 1, MinSpeedGbps: 10},
+			},
+		}),
+		Entry("no HardwareData", testCaseRequirements{
+			Requirements:   &metal3api.HardwareRequirements{},
+			HardwareData:   &metal3api.HardwareData{},
+			ExpectedReason: "no HardwareData",
+		}),
+		Entry("CPU", testCaseRequirements{
+			Requirements: &metal3api.HardwareRequirements{
+				CPU: &metal3api.CPURequirements{MinCount: 32, Arch: "aarch64", Flags: []string{"vmx", "avx512f", "svm"}},
+			},
+			ExpectedReason: `16 CPUs, 32 required, CPU architecture "x86_64", "aarch64" required, missing CPU flags avx512f,svm`,
+		}),
+		Entry("RAM", testCaseRequirements{
+			Requirements:   &metal3api.HardwareRequirements{MinRAMMebibytes: 131072},
+			ExpectedReason: "65536 MiB of RAM, 131072 required",
+		}),
+		Entry("disk type", testCaseRequirements{
+			Requirements:   &metal3api.HardwareRequirements{Disks: &metal3api.DiskRequirements{Type: metal3api.SSD}},
+			ExpectedReason: "0 matching disks, 1 required",
+		}),
+		Entry("disk size and type", testCaseRequirements{
+			Requirements: &metal3api.HardwareRequirements{
+				Disks: &metal3api.DiskRequirements{Type: metal3api.NVME, MinSizeBytes: metal3api.TeraByte},
+			},
+			ExpectedReason: "0 matching disks, 1 required",
+		}),
+		Entry("NICs counted once per MAC", testCaseRequirements{
+			Requirements:   &metal3api.HardwareRequirements{NICs: &metal3api.NICRequirements{MinCount: 2, MinSpeedGbps: 10}},
+			ExpectedReason: "1 matching NICs, 2 required",
+		}),
+	)
+
+	It("summarizes rejections", func() {
+		var rejections []string
+		for i := range 12 {
+			rejections = append(rejections, fmt.Sprintf("ns/bmh%02d: no HardwareData", i))
+		}
+		err := error(HostsRejectedError{Rejections: rejections})
+		Expect(errors.Is(err, ErrNoAvailableBMH)).To(BeTrue())
+		Expect(err.Error()).To(HavePrefix("no available BareMetalHost, rejected hosts: ns/bmh00: no HardwareData; "))
+		Expect(err.Error()).To(HaveSuffix("ns/bmh09: no HardwareData; and 2 more"))
+	})
+})
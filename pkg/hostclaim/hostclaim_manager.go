@@ -18,9 +18,7 @@ package hostclaim
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
-	"math/big"
 	"regexp"
 	"slices"
 	"strings"
@@ -147,6 +145,12 @@ func (m *Manager) Associate(ctx context.Context) error {
 		return nil
 	}
 
+	if m.reservationExpired() {
+		m.Log.Info("Reservation expired, not associating host")
+		m.setReservationExpiredCondition()
+		return nil
+	}
+
 	bmh, err := m.chooseBMH(ctx)
 	if err != nil {
 		if ok, _ := IsRequeueAfterError(err); !ok {
@@ -156,9 +160,13 @@ func (m *Manager) Associate(ctx context.Context) error {
 		}
 		if errors.Is(err, ErrNoAvailableBMH) {
 			m.Log.Info("No available host found. Requeuing.")
+			message := "No available host found: requeuing."
+			var rejectedError HostsRejectedError
+			if errors.As(err, &rejectedError) {
+				message = "No available host found, rejected hosts: " + rejectedError.Summary() + ". Requeuing."
+			}
 			m.SetConditionHostToFalse(
-				metal3api.AssociatedCondition, metal3api.NoBareMetalHostReason,
-				"No available host found: requeuing.")
+				metal3api.AssociatedCondition, metal3api.NoBareMetalHostReason, message)
 			return RequeueAfterError{RequeueAfter: HostClaimRequeueDelay}
 		}
 		return err
@@ -211,6 +219,7 @@ func (m *Manager) Delete(ctx context.Context) error {
 	}
 	if bmh.Spec.ConsumerRef != nil && consumerRefMatches(bmh.Spec.ConsumerRef, m.HostClaim) {
 		bmh.Spec.ConsumerRef = nil
+		clearOperations(bmh)
 		if err := m.client.Update(ctx, bmh); err != nil {
 			return hideConflictError(err)
 		}
@@ -219,7 +228,19 @@ func (m *Manager) Delete(ctx context.Context) error {
 	return nil
 }
 
-func (m *Manager) Update(_ context.Context) error {
+// Update synchronizes the HostClaim with its associated BareMetalHost. Operations requested on the HostClaim
+// are passed to the host. A host that is only reserved is not provisioned.
+func (m *Manager) Update(ctx context.Context) error {
+	if !m.IsAssociated() {
+		return nil
+	}
+	if err := m.updateOperations(ctx); err != nil {
+		return err
+	}
+	if m.isReservation() {
+		return m.updateReservation(ctx)
+	}
+	m.HostClaim.Status.ReservationExpirationTime = nil
 	return nil
 }
 
@@ -254,15 +275,9 @@ func consumerRefMatches(consumer *corev1.ObjectReference, claim *metal3api.HostC
 	return true
 }
 
-func (m *Manager) selectBMH(
-	availableHosts []*metal3api.BareMetalHost,
-) (*metal3api.BareMetalHost, error) {
+func (m *Manager) selectBMH(input *ScoringInput) (*metal3api.BareMetalHost, error) {
 	// choose a host.
-	var err error
-	var chosenHost *metal3api.BareMetalHost
-
-	// If there are hosts with nodeReuseLabelName:
-	chosenHost, err = m.pickHost(availableHosts)
+	chosenHost, err := m.pickHost(input)
 	if err != nil {
 		m.Log.Error(err, "Failed to choose host, not choosing host")
 		return nil, err
@@ -272,17 +287,20 @@ func (m *Manager) selectBMH(
 }
 
 // Picks host from list of available hosts, if failureDomain is set, tries to choose from hosts in failureDomain.
-// When none available in failureDomain it chooses from all available hosts.
-func (m *Manager) pickHost(availableHosts []*metal3api.BareMetalHost) (*metal3api.BareMetalHost, error) {
-	var chosenHost *metal3api.BareMetalHost
-	var availableHostsInFailureDomain []*metal3api.BareMetalHost
+// When none available in failureDomain it chooses from all available hosts. The host with the best score for
+// the selection strategy of the claim is chosen.
+func (m *Manager) pickHost(input *ScoringInput) (*metal3api.BareMetalHost, error) {
+	scorers, err := scorersFor(m.HostClaim)
+	if err != nil {
+		return nil, err
+	}
 
-	// When failureDomain is set, create a list from available hosts in failureDomain
+	// When failureDomain is set, restrict the candidates to the available hosts in failureDomain
 	if m.HostClaim.Spec.FailureDomain != "" {
 		labelSelector := labels.NewSelector()
 		var reqs labels.Requirements
 		var r *labels.Requirement
-		r, err := labels.NewRequirement(FailureDomainLabelName, selection.Equals, []string{m.HostClaim.Spec.FailureDomain})
+		r, err = labels.NewRequirement(FailureDomainLabelName, selection.Equals, []string{m.HostClaim.Spec.FailureDomain})
 
 		if err != nil {
 			m.Log.Error(err, "Failed to create FailureDomain MatchLabel requirement, not choosing host")
@@ -291,25 +309,23 @@ func (m *Manager) pickHost(availableHosts []*metal3api.BareMetalHost) (*metal3ap
 		reqs = append(reqs, *r)
 		labelSelector = labelSelector.Add(reqs...)
 
-		for _, host := range availableHosts {
-			if labelSelector.Matches(labels.Set(host.ObjectMeta.Labels)) {
-				availableHostsInFailureDomain = append(availableHostsInFailureDomain, host)
+		var candidatesInFailureDomain []Candidate
+		for _, candidate := range input.Candidates {
+			if labelSelector.Matches(labels.Set(candidate.Host.ObjectMeta.Labels)) {
+				candidatesInFailureDomain = append(candidatesInFailureDomain, candidate)
 			}
 		}
-		if len(availableHostsInFailureDomain) == 0 {
+		if len(candidatesInFailureDomain) == 0 {
 			m.Log.Info("No available hosts in FailureDomain", m.HostClaim.Spec.FailureDomain, "choosing from other available hosts")
+		} else {
+			input = &ScoringInput{Claim: input.Claim, Candidates: candidatesInFailureDomain, Claimed: input.Claimed}
 		}
 	}
 
-	if len(availableHostsInFailureDomain) > 0 {
-		rHost, _ := rand.Int(rand.Reader, big.NewInt(int64(len(availableHostsInFailureDomain))))
-		randomHost := rHost.Int64()
-		chosenHost = availableHostsInFailureDomain[randomHost]
-	} else {
-		rHost, _ := rand.Int(rand.Reader, big.NewInt(int64(len(availableHosts))))
-		randomHost := rHost.Int64()
-		chosenHost = availableHosts[randomHost]
-	}
+	best, totals := bestCandidate(input, scorers)
+	chosenHost := input.Candidates[best].Host
+	m.Log.Info("Chose host with the best score", "bmh", chosenHost.Name, "bmhNamespace", chosenHost.Namespace,
+		"score", totals[best], "candidates", len(input.Candidates))
 
 	return chosenHost, nil
 }
@@ -321,7 +337,8 @@ func (m *Manager) pickHost(availableHosts []*metal3api.BareMetalHost) (*metal3ap
 // match the namespace of the claim. If the namespace argument is not empty,
 // HostDeployPolicies are only listed in that namespace and the result is either
 // an empty set or a singleton containing that namespace.
-func (m *Manager) acceptableNamespaces(ctx context.Context, namespace string) (Set[string], error) {
+// The quotas of the HostDeployPolicies accepting the claim are returned by namespace.
+func (m *Manager) acceptableNamespaces(ctx context.Context, namespace string) (Set[string], map[string][]metal3api.HostQuota, error) {
 	m.Log.V(1).Info("Searching for suitable namespaces")
 	hostdeploypolicies := metal3api.HostDeployPolicyList{}
 	options := []client.ListOption{}
@@ -331,24 +348,25 @@ func (m *Manager) acceptableNamespaces(ctx context.Context, namespace string) (S
 	err := m.client.List(ctx, &hostdeploypolicies, options...)
 	if err != nil {
 		m.Log.Error(err, "cannot list the HostDeployPolicies")
-		return nil, err
+		return nil, nil, err
 	}
 	hostNs := m.HostClaim.Namespace
 	hostNsResource := &corev1.Namespace{}
 	err = m.client.Get(ctx, client.ObjectKey{Name: hostNs}, hostNsResource)
 	if err != nil {
 		m.Log.Error(err, "cannot access the namespace of the claim")
-		return nil, err
+		return nil, nil, err
 	}
 	nsLabels := hostNsResource.Labels
 	if nsLabels == nil {
 		nsLabels = map[string]string{}
 	}
 	namespaces := NewSet[string]()
+	quotas := map[string][]metal3api.HostQuota{}
 LOOP_POLICY:
 	for _, hostDeployPolicy := range hostdeploypolicies.Items {
 		log := m.Log.WithValues("policyNamespace", hostDeployPolicy.Namespace, "policyName", hostDeployPolicy.Name)
-		if SetContains(namespaces, hostDeployPolicy.Namespace) {
+		if SetContains(namespaces, hostDeployPolicy.Namespace) && len(hostDeployPolicy.Spec.Quotas) == 0 {
 			// namespace already added, no reason to check this hdp.
 			continue
 		}
@@ -367,7 +385,7 @@ LOOP_POLICY:
 				log.Error(
 					err, "Error during regexp matching on HostClaim namespace (bad regexp)",
 					"regexp", constraints.NameMatches)
-				return nil, err
+				return nil, nil, err
 			}
 			if !b {
 				log.V(1).Info("Ignoring HostDeployPolicy because claim namespace does not match regex")
@@ -392,9 +410,14 @@ LOOP_POLICY:
 		}
 		log.V(1).Info("Accepting namespace because of HostDeployPolicy", "namespace", hostDeployPolicy.Namespace)
 		AddSet(namespaces, hostDeployPolicy.Namespace)
+		for _, quota := range hostDeployPolicy.Spec.Quotas {
+			if quotaAppliesTo(&quota, hostNs) {
+				quotas[hostDeployPolicy.Namespace] = append(quotas[hostDeployPolicy.Namespace], quota)
+			}
+		}
 	}
 	m.Log.Info("Acceptable namespaces", "namespaces", namespaces)
-	return namespaces, nil
+	return namespaces, quotas, nil
 }
 
 func (m *Manager) hostLabelSelectorForHostClaim() (labels.Selector, error) {
@@ -425,7 +448,7 @@ func (m *Manager) hostLabelSelectorForHostClaim() (labels.Selector, error) {
 // associated with the HostClaim. It searches all hosts in case one already has an
 // association with this HostClaim.
 func (m *Manager) chooseBMH(ctx context.Context) (*metal3api.BareMetalHost, error) {
-	namespaces, err := m.acceptableNamespaces(ctx, m.HostClaim.Spec.HostSelector.InNamespace)
+	namespaces, quotas, err := m.acceptableNamespaces(ctx, m.HostClaim.Spec.HostSelector.InNamespace)
 	if err != nil {
 		return nil, err
 	}
@@ -440,7 +463,8 @@ func (m *Manager) chooseBMH(ctx context.Context) (*metal3api.BareMetalHost, erro
 
 	// Different from M3M: We do not restrict to a single namespace (namespace of Metal3Machine)
 
-	availableHosts := []*metal3api.BareMetalHost{}
+	input := &ScoringInput{Claim: m.HostClaim}
+	var rejections []string
 
 	for namespace := range namespaces {
 		bmhs := metal3api.BareMetalHostList{}
@@ -449,6 +473,14 @@ func (m *Manager) chooseBMH(ctx context.Context) (*metal3api.BareMetalHost, erro
 		if err != nil {
 			return nil, err
 		}
+		var hardwareData map[string]*metal3api.HardwareData
+		var quotaUsed []int
+		if len(quotas[namespace]) > 0 {
+			quotaUsed, err = m.quotaUse(ctx, namespace, quotas[namespace])
+			if err != nil {
+				return nil, err
+			}
+		}
 		for i, bmh := range bmhs.Items {
 			if bmh.Spec.ConsumerRef != nil && consumerRefMatches(bmh.Spec.ConsumerRef, m.HostClaim) {
 				m.Log.Info("Found host with existing ConsumerRef", "bmh", bmh.Name, "bmhNamespace", bmh.Namespace)
@@ -456,6 +488,7 @@ func (m *Manager) chooseBMH(ctx context.Context) (*metal3api.BareMetalHost, erro
 			}
 
 			if bmh.Spec.ConsumerRef != nil {
+				input.Claimed = append(input.Claimed, &bmhs.Items[i])
 				continue
 			}
 			if bmh.GetDeletionTimestamp() != nil {
@@ -481,18 +514,45 @@ func (m *Manager) chooseBMH(ctx context.Context) (*metal3api.BareMetalHost, erro
 				continue
 			}
 
+			if hardwareData == nil {
+				hardwareData, err = m.listHardwareData(ctx, namespace)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if reason := checkHardwareRequirements(m.HostClaim.Spec.HardwareRequirements, hardwareData[bmh.Name]); reason != "" {
+				m.Log.Info("Host does not satisfy the hardware requirements",
+					"bmh", bmh.Name, "bmhNamespace", bmh.Namespace, "reason", reason)
+				rejections = append(rejections, bmh.Namespace+"/"+bmh.Name+": "+reason)
+				continue
+			}
+
+			if reason := quotaRejection(quotas[namespace], quotaUsed, m.HostClaim.Namespace, &bmh); reason != "" {
+				m.Log.Info("Host would exceed a quota", "bmh", bmh.Name, "bmhNamespace", bmh.Namespace, "reason", reason)
+				rejections = append(rejections, bmh.Namespace+"/"+bmh.Name+": "+reason)
+				continue
+			}
+
 			m.Log.Info("Host matched hostSelector for Host, adding it to availableHosts list",
 				"bmh", bmh.Name, "bmhNamespace", bmh.Namespace)
-			availableHosts = append(availableHosts, &bmhs.Items[i])
+			input.Candidates = append(input.Candidates, Candidate{
+				Host:         &bmhs.Items[i],
+				HardwareData: hardwareData[bmh.Name],
+			})
 		}
 	}
 
-	m.Log.Info("Host count available while choosing host for HostClaim", "hostcount", len(availableHosts))
-	if len(availableHosts) == 0 {
+	m.Log.Info("Host count available while choosing host for HostClaim", "hostcount", len(input.Candidates))
+	if len(input.Candidates) == 0 {
+		if len(rejections) > 0 {
+			slices.Sort(rejections)
+			return nil, HostsRejectedError{Rejections: rejections}
+		}
 		return nil, ErrNoAvailableBMH
 	}
 
-	chosenHost, err := m.selectBMH(availableHosts)
+	chosenHost, err := m.selectBMH(input)
 	if err != nil {
 		m.Log.Error(err, "Failed to select a Host")
 		return nil, err
@@ -501,6 +561,32 @@ func (m *Manager) chooseBMH(ctx context.Context) (*metal3api.BareMetalHost, erro
 	return chosenHost, err
 }
 
+// quotaUse counts the hosts of a namespace bound to HostClaims of the namespace of the claim for each quota.
+// All the hosts are counted, not only the ones matching the selector of the claim. The hosts are read from the
+// API server: HostClaims are reconciled one at a time, but the cache may not have seen the host bound by the
+// previous reconcile yet, which would let a quota be exceeded.
+func (m *Manager) quotaUse(ctx context.Context, namespace string, quotas []metal3api.HostQuota) ([]int, error) {
+	bmhs := metal3api.BareMetalHostList{}
+	if err := m.APIReader.List(ctx, &bmhs, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return quotaUse(quotas, m.HostClaim.Namespace, bmhs.Items), nil
+}
+
+// listHardwareData returns the HardwareData of a namespace by name, which is
+// the name of their BareMetalHost.
+func (m *Manager) listHardwareData(ctx context.Context, namespace string) (map[string]*metal3api.HardwareData, error) {
+	hardwareDataList := metal3api.HardwareDataList{}
+	if err := m.client.List(ctx, &hardwareDataList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	hardwareData := make(map[string]*metal3api.HardwareData, len(hardwareDataList.Items))
+	for i := range hardwareDataList.Items {
+		hardwareData[hardwareDataList.Items[i].Name] = &hardwareDataList.Items[i]
+	}
+	return hardwareData, nil
+}
+
 type Set[T comparable] = map[T]struct{}
 
 func NewSet[T comparable]() Set[T] {
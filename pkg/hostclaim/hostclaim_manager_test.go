@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -324,6 +325,45 @@ var _ = Describe("HostClaim manager", func() {
 			BareMetalHosts:  []*metal3api.BareMetalHost{bmhns1, bmh2ns1FailureDomain},
 			ExpectedBmhName: "bmh2",
 		}),
+		Entry("with quota (not reached)", testCaseChooseBMH{
+			HostClaim:  NewHostclaim(HostclaimName).SetLabelSelector(defaultBmhLabels).Build(),
+			Namespaces: []*corev1.Namespace{hcNs, ns1},
+			HostDeployPolicies: []*metal3api.HostDeployPolicy{
+				NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).
+					SetQuotas([]metal3api.HostQuota{{Name: "all", MaxHosts: 2}}).Build()},
+			BareMetalHosts:  []*metal3api.BareMetalHost{bmhns1, bmhns1ConsOther},
+			ExpectedBmhName: "bmh1",
+		}),
+		Entry("with quota (reached)", testCaseChooseBMH{
+			HostClaim:  NewHostclaim(HostclaimName).SetLabelSelector(defaultBmhLabels).Build(),
+			Namespaces: []*corev1.Namespace{hcNs, ns1},
+			HostDeployPolicies: []*metal3api.HostDeployPolicy{
+				NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).Build(),
+				NewHostdeploypolicy("hdp-quota", "ns1").AcceptNames([]string{HostclaimNamespace}).
+					SetQuotas([]metal3api.HostQuota{{Name: "all", MaxHosts: 1}}).Build()},
+			BareMetalHosts:  []*metal3api.BareMetalHost{bmhns1, bmhns1ConsOther},
+			ExpectedBmhName: "",
+		}),
+		Entry("with quota on another class of hosts", testCaseChooseBMH{
+			HostClaim:  NewHostclaim(HostclaimName).SetLabelSelector(defaultBmhLabels).Build(),
+			Namespaces: []*corev1.Namespace{hcNs, ns1},
+			HostDeployPolicies: []*metal3api.HostDeployPolicy{
+				NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).
+					SetQuotas([]metal3api.HostQuota{{
+						Name: "zone", MaxHosts: 0,
+						MatchLabels: map[string]string{FailureDomainLabelName: "zone"}}}).Build()},
+			BareMetalHosts:  []*metal3api.BareMetalHost{bmhns1, bmh2ns1FailureDomain},
+			ExpectedBmhName: "bmh1",
+		}),
+		Entry("with quota for another namespace", testCaseChooseBMH{
+			HostClaim:  NewHostclaim(HostclaimName).SetLabelSelector(defaultBmhLabels).Build(),
+			Namespaces: []*corev1.Namespace{hcNs, ns1},
+			HostDeployPolicies: []*metal3api.HostDeployPolicy{
+				NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).
+					SetQuotas([]metal3api.HostQuota{{Name: "other", MaxHosts: 0, Namespaces: []string{"other"}}}).Build()},
+			BareMetalHosts:  []*metal3api.BareMetalHost{bmhns1},
+			ExpectedBmhName: "bmh1",
+		}),
 		Entry("with Failure Domain (no available bmh in zone)", testCaseChooseBMH{
 			HostClaim: NewHostclaim(HostclaimName).
 				SetLabelSelector(map[string]string{"default-selector": "default-value"}).
@@ -413,6 +453,43 @@ var _ = Describe("HostClaim manager", func() {
 			ExpectRequeue: true,
 		}),
 	)
+
+	It("reports hosts rejected by hardware requirements", func() {
+		hostClaim := NewHostclaim(HostclaimName).
+			SetHardwareRequirements(metal3api.HardwareRequirements{MinRAMMebibytes: 1024}).Build()
+		bmh := NewBaremetalhost("bmh", "ns1", metal3api.StateAvailable).Build()
+		objects := []client.Object{
+			hostClaim, bmh, NewHardwareData(bmh).Build(), hcNs, ns1,
+			NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).Build(),
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).WithStatusSubresource(hostClaim).Build()
+		hostMgr := NewManager(fakeClient, GinkgoLogr, hostClaim, fakeClient)
+		err := hostMgr.Associate(context.TODO())
+		var requeueAfterError RequeueAfterError
+		Expect(errors.As(err, &requeueAfterError)).To(BeTrue())
+		cond := conditions.Get(hostClaim, metal3api.AssociatedCondition)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal(metal3api.NoBareMetalHostReason))
+		Expect(cond.Message).To(ContainSubstring("ns1/bmh: 0 MiB of RAM, 1024 required"))
+	})
+
+	It("counts the hosts bound but not yet in the cache against quotas", func() {
+		hostClaim := NewHostclaim(HostclaimName).SetLabelSelector(defaultBmhLabels).Build()
+		objects := []client.Object{
+			hostClaim, bmhns1, NewHardwareData(bmhns1).Build(), hcNs, ns1,
+			NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).
+				SetQuotas([]metal3api.HostQuota{{Name: "all", MaxHosts: 1}}).Build(),
+		}
+		cacheClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+		apiReader := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(append(objects, bmhns1ConsOther)...).Build()
+		hostMgr, ok := NewManager(cacheClient, GinkgoLogr, hostClaim, apiReader).(*Manager)
+		Expect(ok).To(BeTrue())
+		bmh, err := hostMgr.chooseBMH(context.TODO())
+		Expect(bmh).To(BeNil())
+		var rejectedError HostsRejectedError
+		Expect(errors.As(err, &rejectedError)).To(BeTrue())
+		Expect(rejectedError.Summary()).To(ContainSubstring("quota all reached (1/1)"))
+	})
 })
 
 func TestManagers(t *testing.T) {
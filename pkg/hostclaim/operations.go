@@ -0,0 +1,263 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// claimRebootPrefix replaces the reboot.metal3.io/ prefix of the suffixed reboot annotations passed from a
+	// HostClaim to its BareMetalHost, so that they are not mixed up with the ones set directly on the host.
+	claimRebootPrefix = metal3api.RebootAnnotationPrefix + "/hostclaim-"
+	// reprovisionSettingsAnnotation keeps on a BareMetalHost being reprovisioned the settings to provision it
+	// with again.
+	reprovisionSettingsAnnotation = metal3api.ReprovisionAnnotation + "/settings"
+)
+
+// provisioningSettings are the settings of a BareMetalHost saved while it is reprovisioned.
+type provisioningSettings struct {
+	Image        *metal3api.Image        `json:"image,omitempty"`
+	CustomDeploy *metal3api.CustomDeploy `json:"customDeploy,omitempty"`
+}
+
+func (m *Manager) setOperationCondition(t string, status metav1.ConditionStatus, reason, message string) {
+	conditions.Set(m.HostClaim, metav1.Condition{Type: t, Status: status, Reason: reason, Message: message})
+}
+
+// operationReason returns the reason of an operation condition, or an empty string if no operation was
+// requested.
+func (m *Manager) operationReason(t string) string {
+	if cond := conditions.Get(m.HostClaim, t); cond != nil {
+		return cond.Reason
+	}
+	return ""
+}
+
+// updateOperations passes the operations requested by annotations on the HostClaim to the bound
+// BareMetalHost and reports their progress in conditions of the HostClaim. The annotations of one-shot
+// operations are removed from the HostClaim once passed.
+func (m *Manager) updateOperations(ctx context.Context) error {
+	ref := m.HostClaim.Status.BareMetalHost
+	bmh := &metal3api.BareMetalHost{}
+	if err := m.client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, bmh); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	original := bmh.DeepCopy()
+	// The HostClaim is saved whatever happens to the host. Its requests and
+	// conditions are restored unless they reached the host, so that they are
+	// not reported as passed or completed.
+	claimAnnotations := maps.Clone(m.HostClaim.GetAnnotations())
+	claimConditions := slices.Clone(m.HostClaim.GetConditions())
+	restoreClaim := func() {
+		m.HostClaim.SetAnnotations(claimAnnotations)
+		m.HostClaim.SetConditions(claimConditions)
+	}
+
+	m.passReboot(bmh)
+	m.passInspect(bmh)
+	if err := m.passReprovision(bmh); err != nil {
+		restoreClaim()
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(original, bmh) {
+		return nil
+	}
+	m.Log.Info("Passing operations to host", "bmh", bmh.Name, "bmhNamespace", bmh.Namespace)
+	if err := m.client.Update(ctx, bmh); err != nil {
+		restoreClaim()
+		return hideConflictError(err)
+	}
+	return nil
+}
+
+// clearOperations removes from a BareMetalHost released by its HostClaim the operations passed by the
+// claim, so that they do not apply to the next claim bound to the host.
+func clearOperations(bmh *metal3api.BareMetalHost) {
+	for annotation := range bmh.Annotations {
+		if strings.HasPrefix(annotation, claimRebootPrefix) || annotation == reprovisionSettingsAnnotation {
+			delete(bmh.Annotations, annotation)
+		}
+	}
+}
+
+func hostIsProvisioned(bmh *metal3api.BareMetalHost) bool {
+	switch bmh.Status.Provisioning.State {
+	case metal3api.StateProvisioned, metal3api.StateExternallyProvisioned:
+		return true
+	default:
+		return false
+	}
+}
+
+// passReboot passes the reboot annotations. The suffixless annotation requests a single reboot, the suffixed
+// ones keep the host powered off until they are removed from the HostClaim.
+func (m *Manager) passReboot(bmh *metal3api.BareMetalHost) {
+	claimAnnotations := m.HostClaim.GetAnnotations()
+
+	if value, requested := claimAnnotations[metal3api.RebootAnnotationPrefix]; requested {
+		delete(claimAnnotations, metal3api.RebootAnnotationPrefix)
+		if !hostIsProvisioned(bmh) {
+			m.setOperationCondition(metal3api.RebootedCondition, metav1.ConditionFalse,
+				metal3api.OperationRejectedReason,
+				fmt.Sprintf("The BareMetalHost cannot be rebooted in state %s", bmh.Status.Provisioning.State))
+		} else {
+			metav1.SetMetaDataAnnotation(&bmh.ObjectMeta, metal3api.RebootAnnotationPrefix, value)
+			m.setOperationCondition(metal3api.RebootedCondition, metav1.ConditionFalse,
+				metal3api.OperationPendingReason, "")
+		}
+	} else if m.operationReason(metal3api.RebootedCondition) == metal3api.OperationPendingReason {
+		// The host removes the annotation once it has rebooted
+		if _, pending := bmh.Annotations[metal3api.RebootAnnotationPrefix]; !pending {
+			m.setOperationCondition(metal3api.RebootedCondition, metav1.ConditionTrue,
+				metal3api.OperationCompletedReason, "")
+		}
+	}
+
+	for annotation := range bmh.Annotations {
+		if suffix, ok := strings.CutPrefix(annotation, claimRebootPrefix); ok {
+			if _, kept := claimAnnotations[metal3api.RebootAnnotationPrefix+"/"+suffix]; !kept {
+				delete(bmh.Annotations, annotation)
+			}
+		}
+	}
+	for annotation, value := range claimAnnotations {
+		if suffix, ok := strings.CutPrefix(annotation, metal3api.RebootAnnotationPrefix+"/"); ok {
+			metav1.SetMetaDataAnnotation(&bmh.ObjectMeta, claimRebootPrefix+suffix, value)
+		}
+	}
+}
+
+// passInspect passes the inspect annotation requesting a new inspection. Only available hosts are
+// inspected again.
+func (m *Manager) passInspect(bmh *metal3api.BareMetalHost) {
+	claimAnnotations := m.HostClaim.GetAnnotations()
+
+	if _, requested := claimAnnotations[metal3api.InspectAnnotationPrefix]; requested {
+		delete(claimAnnotations, metal3api.InspectAnnotationPrefix)
+		if bmh.Status.Provisioning.State != metal3api.StateAvailable {
+			m.setOperationCondition(metal3api.InspectedCondition, metav1.ConditionFalse,
+				metal3api.OperationRejectedReason,
+				fmt.Sprintf("The BareMetalHost cannot be inspected in state %s", bmh.Status.Provisioning.State))
+			return
+		}
+		metav1.SetMetaDataAnnotation(&bmh.ObjectMeta, metal3api.InspectAnnotationPrefix, "")
+		m.setOperationCondition(metal3api.InspectedCondition, metav1.ConditionFalse,
+			metal3api.OperationPendingReason, "")
+		return
+	}
+
+	switch m.operationReason(metal3api.InspectedCondition) {
+	case metal3api.OperationPendingReason, metal3api.OperationInProgressReason:
+	default:
+		return
+	}
+	// The host removes the annotation when the inspection starts. It may
+	// be over before the state of the host is observed.
+	_, pending := bmh.Annotations[metal3api.InspectAnnotationPrefix]
+	switch {
+	case bmh.Status.ErrorType == metal3api.InspectionError:
+		m.setOperationCondition(metal3api.InspectedCondition, metav1.ConditionFalse,
+			metal3api.OperationFailedReason, bmh.Status.ErrorMessage)
+	case bmh.Status.Provisioning.State == metal3api.StateInspecting:
+		m.setOperationCondition(metal3api.InspectedCondition, metav1.ConditionFalse,
+			metal3api.OperationInProgressReason, "")
+	case !pending:
+		m.setOperationCondition(metal3api.InspectedCondition, metav1.ConditionTrue,
+			metal3api.OperationCompletedReason, "")
+	}
+}
+
+// passReprovision deprovisions the host and provisions it again with the same image or custom deploy. The
+// settings are kept in an annotation of the host while it is deprovisioned.
+func (m *Manager) passReprovision(bmh *metal3api.BareMetalHost) error {
+	claimAnnotations := m.HostClaim.GetAnnotations()
+	saved, deprovisioning := bmh.Annotations[reprovisionSettingsAnnotation]
+
+	if _, requested := claimAnnotations[metal3api.ReprovisionAnnotation]; requested {
+		delete(claimAnnotations, metal3api.ReprovisionAnnotation)
+		switch {
+		case deprovisioning:
+			// Already requested
+		case bmh.Status.Provisioning.State != metal3api.StateProvisioned:
+			m.setOperationCondition(metal3api.ReprovisionedCondition, metav1.ConditionFalse,
+				metal3api.OperationRejectedReason,
+				fmt.Sprintf("The BareMetalHost cannot be reprovisioned in state %s", bmh.Status.Provisioning.State))
+		default:
+			settings, err := json.Marshal(provisioningSettings{Image: bmh.Spec.Image, CustomDeploy: bmh.Spec.CustomDeploy})
+			if err != nil {
+				return fmt.Errorf("failed to save the provisioning settings of the host: %w", err)
+			}
+			metav1.SetMetaDataAnnotation(&bmh.ObjectMeta, reprovisionSettingsAnnotation, string(settings))
+			bmh.Spec.Image = nil
+			bmh.Spec.CustomDeploy = nil
+			m.setOperationCondition(metal3api.ReprovisionedCondition, metav1.ConditionFalse,
+				metal3api.OperationInProgressReason, "Deprovisioning the BareMetalHost")
+		}
+		return nil
+	}
+
+	if deprovisioning {
+		switch bmh.Status.Provisioning.State {
+		case metal3api.StateAvailable:
+			settings := provisioningSettings{}
+			if err := json.Unmarshal([]byte(saved), &settings); err != nil {
+				return fmt.Errorf("failed to restore the provisioning settings of the host: %w", err)
+			}
+			bmh.Spec.Image = settings.Image
+			bmh.Spec.CustomDeploy = settings.CustomDeploy
+			delete(bmh.Annotations, reprovisionSettingsAnnotation)
+			m.setOperationCondition(metal3api.ReprovisionedCondition, metav1.ConditionFalse,
+				metal3api.OperationInProgressReason, "Provisioning the BareMetalHost")
+		case metal3api.StateDeprovisioning:
+			if bmh.Status.ErrorMessage != "" {
+				m.setOperationCondition(metal3api.ReprovisionedCondition, metav1.ConditionFalse,
+					metal3api.OperationFailedReason, bmh.Status.ErrorMessage)
+			}
+		}
+		return nil
+	}
+
+	if m.operationReason(metal3api.ReprovisionedCondition) != metal3api.OperationInProgressReason {
+		return nil
+	}
+	switch {
+	case bmh.Status.ErrorType == metal3api.ProvisioningError:
+		m.setOperationCondition(metal3api.ReprovisionedCondition, metav1.ConditionFalse,
+			metal3api.OperationFailedReason, bmh.Status.ErrorMessage)
+	case bmh.Status.Provisioning.State == metal3api.StateProvisioned:
+		m.setOperationCondition(metal3api.ReprovisionedCondition, metav1.ConditionTrue,
+			metal3api.OperationCompletedReason, "")
+	}
+	return nil
+}
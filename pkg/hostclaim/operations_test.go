@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/metal3-io/baremetal-operator/internal/testutil"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("HostClaim operations", func() {
+
+	var (
+		image       = metal3api.Image{URL: "url"}
+		consumerRef = corev1.ObjectReference{Kind: HostClaimKind, Namespace: HostclaimNamespace,
+			APIVersion: metal3api.GroupVersion.String(), Name: HostclaimName}
+		savedImage = `{"image":{"url":"url"}}`
+	)
+
+	type testCaseOperation struct {
+		ClaimAnnotations    map[string]string
+		Condition           *metav1.Condition
+		HostState           metal3api.ProvisioningState
+		HostAnnotations     map[string]string
+		HostImage           *metal3api.Image
+		HostError           metal3api.ErrorType
+		ExpectedCondition   *metav1.Condition
+		ExpectedAnnotations map[string]string
+		ExpectedImage       *metal3api.Image
+		KeptAnnotations     map[string]string
+	}
+
+	DescribeTable("Test updateOperations",
+		func(tc testCaseOperation) {
+			ctx := context.TODO()
+			hostClaim := NewHostclaim(HostclaimName).SetAnnotations(tc.ClaimAnnotations).
+				SetAssociatedBMH("ns1", "bmh").SetImage(image).Build()
+			if tc.Condition != nil {
+				conditions.Set(hostClaim, *tc.Condition)
+			}
+			bmh := NewBaremetalhost("bmh", "ns1", tc.HostState).SetConsumerRef(consumerRef).
+				SetAnnotations(tc.HostAnnotations).Build()
+			bmh.Spec.Image = tc.HostImage
+			bmh.Status.ErrorType = tc.HostError
+			if tc.HostError != "" {
+				bmh.Status.ErrorMessage = "boom"
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(hostClaim, bmh).Build()
+			hostMgr, ok := NewManager(fakeClient, GinkgoLogr, hostClaim, fakeClient).(*Manager)
+			Expect(ok).To(BeTrue())
+
+			Expect(hostMgr.updateOperations(ctx)).To(Succeed())
+
+			if tc.KeptAnnotations == nil {
+				Expect(hostClaim.Annotations).To(BeEmpty())
+			} else {
+				Expect(hostClaim.Annotations).To(Equal(tc.KeptAnnotations))
+			}
+			updatedBmh := &metal3api.BareMetalHost{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(bmh), updatedBmh)).To(Succeed())
+			if tc.ExpectedAnnotations == nil {
+				Expect(updatedBmh.Annotations).To(BeEmpty())
+			} else {
+				Expect(updatedBmh.Annotations).To(Equal(tc.ExpectedAnnotations))
+			}
+			Expect(updatedBmh.Spec.Image).To(Equal(tc.ExpectedImage))
+			if tc.ExpectedCondition != nil {
+				cond := conditions.Get(hostClaim, tc.ExpectedCondition.Type)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(tc.ExpectedCondition.Status))
+				Expect(cond.Reason).To(Equal(tc.ExpectedCondition.Reason))
+			}
+		},
+		Entry("nothing requested", testCaseOperation{
+			HostState:     metal3api.StateProvisioned,
+			HostImage:     &image,
+			ExpectedImage: &image,
+		}),
+		Entry("reboot requested", testCaseOperation{
+			ClaimAnnotations:    map[string]string{metal3api.RebootAnnotationPrefix: `{"mode":"hard"}`},
+			HostState:           metal3api.StateProvisioned,
+			ExpectedAnnotations: map[string]string{metal3api.RebootAnnotationPrefix: `{"mode":"hard"}`},
+			ExpectedCondition: &metav1.Condition{Type: metal3api.RebootedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationPendingReason},
+		}),
+		Entry("reboot of an available host", testCaseOperation{
+			ClaimAnnotations: map[string]string{metal3api.RebootAnnotationPrefix: ""},
+			HostState:        metal3api.StateAvailable,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.RebootedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationRejectedReason},
+		}),
+		Entry("reboot completed", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.RebootedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationPendingReason},
+			HostState: metal3api.StateProvisioned,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.RebootedCondition,
+				Status: metav1.ConditionTrue, Reason: metal3api.OperationCompletedReason},
+		}),
+		Entry("suffixed reboot annotations are mirrored", testCaseOperation{
+			ClaimAnnotations: map[string]string{metal3api.RebootAnnotationPrefix + "/maintenance": ""},
+			HostState:        metal3api.StateProvisioned,
+			HostAnnotations: map[string]string{
+				metal3api.RebootAnnotationPrefix + "/hostclaim-old": "",
+				metal3api.RebootAnnotationPrefix + "/owner":         "",
+			},
+			ExpectedAnnotations: map[string]string{
+				metal3api.RebootAnnotationPrefix + "/hostclaim-maintenance": "",
+				metal3api.RebootAnnotationPrefix + "/owner":                 "",
+			},
+			KeptAnnotations: map[string]string{metal3api.RebootAnnotationPrefix + "/maintenance": ""},
+		}),
+		Entry("inspection requested", testCaseOperation{
+			ClaimAnnotations:    map[string]string{metal3api.InspectAnnotationPrefix: ""},
+			HostState:           metal3api.StateAvailable,
+			ExpectedAnnotations: map[string]string{metal3api.InspectAnnotationPrefix: ""},
+			ExpectedCondition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationPendingReason},
+		}),
+		Entry("inspection of a provisioned host", testCaseOperation{
+			ClaimAnnotations: map[string]string{metal3api.InspectAnnotationPrefix: ""},
+			HostState:        metal3api.StateProvisioned,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationRejectedReason},
+		}),
+		Entry("inspection in progress", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationPendingReason},
+			HostState: metal3api.StateInspecting,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+		}),
+		Entry("inspection failed", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+			HostState: metal3api.StateInspecting,
+			HostError: metal3api.InspectionError,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationFailedReason},
+		}),
+		Entry("inspection completed", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationPendingReason},
+			HostState: metal3api.StateAvailable,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.InspectedCondition,
+				Status: metav1.ConditionTrue, Reason: metal3api.OperationCompletedReason},
+		}),
+		Entry("reprovisioning requested", testCaseOperation{
+			ClaimAnnotations:    map[string]string{metal3api.ReprovisionAnnotation: ""},
+			HostState:           metal3api.StateProvisioned,
+			HostImage:           &image,
+			ExpectedAnnotations: map[string]string{reprovisionSettingsAnnotation: savedImage},
+			ExpectedCondition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+		}),
+		Entry("reprovisioning of an available host", testCaseOperation{
+			ClaimAnnotations: map[string]string{metal3api.ReprovisionAnnotation: ""},
+			HostState:        metal3api.StateAvailable,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationRejectedReason},
+		}),
+		Entry("reprovisioning while deprovisioning", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+			HostState:           metal3api.StateDeprovisioning,
+			HostAnnotations:     map[string]string{reprovisionSettingsAnnotation: savedImage},
+			ExpectedAnnotations: map[string]string{reprovisionSettingsAnnotation: savedImage},
+			ExpectedCondition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+		}),
+		Entry("reprovisioning restores the image", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+			HostState:       metal3api.StateAvailable,
+			HostAnnotations: map[string]string{reprovisionSettingsAnnotation: savedImage},
+			ExpectedImage:   &image,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+		}),
+		Entry("reprovisioning completed", testCaseOperation{
+			Condition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionFalse, Reason: metal3api.OperationInProgressReason},
+			HostState:     metal3api.StateProvisioned,
+			HostImage:     &image,
+			ExpectedImage: &image,
+			ExpectedCondition: &metav1.Condition{Type: metal3api.ReprovisionedCondition,
+				Status: metav1.ConditionTrue, Reason: metal3api.OperationCompletedReason},
+		}),
+	)
+
+	It("clears the operations of a claim releasing the host", func() {
+		ctx := context.TODO()
+		hostClaim := NewHostclaim(HostclaimName).SetAssociatedBMH("ns1", "bmh").SetImage(image).Build()
+		bmh := NewBaremetalhost("bmh", "ns1", metal3api.StateAvailable).SetConsumerRef(consumerRef).
+			SetAnnotations(map[string]string{
+				claimRebootPrefix + "tenant":                "",
+				reprovisionSettingsAnnotation:               savedImage,
+				metal3api.RebootAnnotationPrefix + "/admin": "",
+			}).Build()
+		nextClaim := NewHostclaim("next-claim").SetImage(image).Build()
+		objects := []client.Object{
+			hostClaim, nextClaim, bmh, NewNamespace(HostclaimNamespace).Build(), NewNamespace("ns1").Build(),
+			NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).Build(),
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+		hostMgr, ok := NewManager(fakeClient, GinkgoLogr, hostClaim, fakeClient).(*Manager)
+		Expect(ok).To(BeTrue())
+
+		Expect(hostMgr.Delete(ctx)).To(Succeed())
+
+		nextMgr, ok := NewManager(fakeClient, GinkgoLogr, nextClaim, fakeClient).(*Manager)
+		Expect(ok).To(BeTrue())
+		Expect(nextMgr.Associate(ctx)).To(Succeed())
+		Expect(nextClaim.Status.BareMetalHost).NotTo(BeNil())
+		Expect(nextMgr.Update(ctx)).To(Succeed())
+
+		updatedBmh := &metal3api.BareMetalHost{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(bmh), updatedBmh)).To(Succeed())
+		Expect(updatedBmh.Spec.ConsumerRef.Name).To(Equal("next-claim"))
+		Expect(updatedBmh.Annotations).To(Equal(map[string]string{metal3api.RebootAnnotationPrefix + "/admin": ""}))
+		Expect(conditions.Get(nextClaim, metal3api.ReprovisionedCondition)).To(BeNil())
+	})
+
+	It("keeps the requests when the host cannot be updated", func() {
+		ctx := context.TODO()
+		requests := map[string]string{
+			metal3api.RebootAnnotationPrefix:  "",
+			metal3api.ReprovisionAnnotation:   "",
+			metal3api.InspectAnnotationPrefix: "",
+		}
+		hostClaim := NewHostclaim(HostclaimName).SetAnnotations(maps.Clone(requests)).
+			SetAssociatedBMH("ns1", "bmh").SetImage(image).Build()
+		bmh := NewBaremetalhost("bmh", "ns1", metal3api.StateProvisioned).SetConsumerRef(consumerRef).Build()
+		bmh.Spec.Image = &image
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(hostClaim, bmh).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(context.Context, client.WithWatch, client.Object, ...client.UpdateOption) error {
+					return errors.New("update failed")
+				},
+			}).Build()
+		hostMgr, ok := NewManager(fakeClient, GinkgoLogr, hostClaim, fakeClient).(*Manager)
+		Expect(ok).To(BeTrue())
+
+		Expect(hostMgr.updateOperations(ctx)).NotTo(Succeed())
+
+		Expect(hostClaim.Annotations).To(Equal(requests))
+		Expect(conditions.Get(hostClaim, metal3api.RebootedCondition)).To(BeNil())
+		Expect(conditions.Get(hostClaim, metal3api.ReprovisionedCondition)).To(BeNil())
+	})
+})
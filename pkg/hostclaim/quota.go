@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// quotaAppliesTo checks whether a quota applies to the HostClaims of a namespace.
+func quotaAppliesTo(quota *metal3api.HostQuota, claimNamespace string) bool {
+	return len(quota.Namespaces) == 0 || slices.Contains(quota.Namespaces, claimNamespace)
+}
+
+// quotaMatchesHost checks whether a host is counted against a quota.
+func quotaMatchesHost(quota *metal3api.HostQuota, host *metal3api.BareMetalHost) bool {
+	return labels.SelectorFromSet(quota.MatchLabels).Matches(labels.Set(host.Labels))
+}
+
+// boundClaimNamespace returns the namespace of the HostClaim bound to a host, or an empty string if the host
+// is not bound to a HostClaim.
+func boundClaimNamespace(host *metal3api.BareMetalHost) string {
+	ref := host.Spec.ConsumerRef
+	if ref == nil || ref.Kind != HostClaimKind || !strings.HasPrefix(ref.APIVersion, metal3api.GroupVersion.Group+"/") {
+		return ""
+	}
+	return ref.Namespace
+}
+
+// quotaUse counts, for each quota, the hosts bound to the HostClaims of a namespace.
+func quotaUse(quotas []metal3api.HostQuota, claimNamespace string, hosts []metal3api.BareMetalHost) []int {
+	used := make([]int, len(quotas))
+	for i := range hosts {
+		if boundClaimNamespace(&hosts[i]) != claimNamespace {
+			continue
+		}
+		for q := range quotas {
+			if quotaMatchesHost(&quotas[q], &hosts[i]) {
+				used[q]++
+			}
+		}
+	}
+	return used
+}
+
+// quotaRejection returns why binding a host would exceed one of the quotas applying to the HostClaims of a
+// namespace, or an empty string if it would not. used is the result of quotaUse.
+func quotaRejection(quotas []metal3api.HostQuota, used []int, claimNamespace string, host *metal3api.BareMetalHost) string {
+	var exceeded []string
+	for q := range quotas {
+		quota := &quotas[q]
+		if quotaAppliesTo(quota, claimNamespace) && quotaMatchesHost(quota, host) && used[q] >= quota.MaxHosts {
+			exceeded = append(exceeded, fmt.Sprintf("quota %s reached (%d/%d)", quota.Name, used[q], quota.MaxHosts))
+		}
+	}
+	return strings.Join(exceeded, ", ")
+}
+
+// HostUsage computes the use of the hosts by the HostClaims of each namespace, for the status of a
+// HostDeployPolicy. The hosts are the BareMetalHosts in the namespace of the policy. Namespaces are reported
+// when they have bound hosts or are named by a quota.
+func HostUsage(policy *metal3api.HostDeployPolicy, hosts []metal3api.BareMetalHost) []metal3api.NamespaceHostUsage {
+	claimed := map[string]int{}
+	for i := range hosts {
+		if namespace := boundClaimNamespace(&hosts[i]); namespace != "" {
+			claimed[namespace]++
+		}
+	}
+	for _, quota := range policy.Spec.Quotas {
+		for _, namespace := range quota.Namespaces {
+			if _, ok := claimed[namespace]; !ok {
+				claimed[namespace] = 0
+			}
+		}
+	}
+
+	usage := make([]metal3api.NamespaceHostUsage, 0, len(claimed))
+	for namespace, count := range claimed {
+		nsUsage := metal3api.NamespaceHostUsage{Namespace: namespace, ClaimedHosts: count}
+		used := quotaUse(policy.Spec.Quotas, namespace, hosts)
+		for q, quota := range policy.Spec.Quotas {
+			if !quotaAppliesTo(&quota, namespace) {
+				continue
+			}
+			nsUsage.Quotas = append(nsUsage.Quotas, metal3api.HostQuotaUsage{
+				Name:      quota.Name,
+				Used:      used[q],
+				Remaining: max(0, quota.MaxHosts-used[q]),
+			})
+		}
+		usage = append(usage, nsUsage)
+	}
+	slices.SortFunc(usage, func(a, b metal3api.NamespaceHostUsage) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	return usage
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/metal3-io/baremetal-operator/internal/testutil"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("HostDeployPolicy quotas", func() {
+
+	gpu := map[string]string{"class": "gpu"}
+	claimedBy := func(name, namespace string, hostLabels map[string]string) metal3api.BareMetalHost {
+		return *NewBaremetalhost(name, "pool", metal3api.StateProvisioned).SetLabels(hostLabels).
+			SetConsumerRef(corev1.ObjectReference{Kind: HostClaimKind, Namespace: namespace,
+				APIVersion: metal3api.GroupVersion.String(), Name: name}).Build()
+	}
+	hosts := []metal3api.BareMetalHost{
+		claimedBy("h1", "team-a", gpu),
+		claimedBy("h2", "team-a", nil),
+		claimedBy("h3", "team-b", nil),
+		*NewBaremetalhost("h4", "pool", metal3api.StateAvailable).SetLabels(gpu).Build(),
+		*NewBaremetalhost("h5", "pool", metal3api.StateProvisioned).
+			SetConsumerRef(corev1.ObjectReference{Kind: "Metal3Machine", Namespace: "team-c",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Name: "m"}).Build(),
+	}
+	quotas := []metal3api.HostQuota{
+		{Name: "all", MaxHosts: 2},
+		{Name: "gpu", MaxHosts: 1, MatchLabels: gpu},
+		{Name: "team-c", MaxHosts: 3, Namespaces: []string{"team-c"}},
+	}
+
+	It("counts the hosts bound to the claims of a namespace", func() {
+		Expect(quotaUse(quotas, "team-a", hosts)).To(Equal([]int{2, 1, 2}))
+		Expect(quotaUse(quotas, "team-b", hosts)).To(Equal([]int{1, 0, 1}))
+	})
+
+	It("rejects hosts exceeding a quota", func() {
+		used := quotaUse(quotas, "team-a", hosts)
+		Expect(quotaRejection(quotas, used, "team-a", &hosts[3])).To(
+			Equal("quota all reached (2/2), quota gpu reached (1/1)"))
+		used = quotaUse(quotas, "team-b", hosts)
+		Expect(quotaRejection(quotas, used, "team-b", &hosts[3])).To(BeEmpty())
+	})
+
+	It("reports the use of each namespace", func() {
+		policy := NewHostdeploypolicy("hdp", "pool").SetQuotas(quotas).Build()
+		Expect(HostUsage(policy, hosts)).To(Equal([]metal3api.NamespaceHostUsage{
+			{
+				Namespace: "team-a", ClaimedHosts: 2,
+				Quotas: []metal3api.HostQuotaUsage{
+					{Name: "all", Used: 2, Remaining: 0},
+					{Name: "gpu", Used: 1, Remaining: 0},
+				},
+			},
+			{
+				Namespace: "team-b", ClaimedHosts: 1,
+				Quotas: []metal3api.HostQuotaUsage{
+					{Name: "all", Used: 1, Remaining: 1},
+					{Name: "gpu", Used: 0, Remaining: 1},
+				},
+			},
+			{
+				Namespace: "team-c", ClaimedHosts: 0,
+				Quotas: []metal3api.HostQuotaUsage{
+					{Name: "all", Used: 0, Remaining: 2},
+					{Name: "gpu", Used: 0, Remaining: 1},
+					{Name: "team-c", Used: 0, Remaining: 3},
+				},
+			},
+		}))
+	})
+})
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// isReservation checks whether the hostclaim only reserves a host, as there is nothing to deploy on it yet.
+func (m *Manager) isReservation() bool {
+	return m.HostClaim.Spec.Image == nil && m.HostClaim.Spec.CustomDeploy == nil
+}
+
+// reservationExpired checks whether the reservation of the hostclaim is over.
+func (m *Manager) reservationExpired() bool {
+	expiration := m.HostClaim.Status.ReservationExpirationTime
+	return m.isReservation() && expiration != nil && !time.Now().Before(expiration.Time)
+}
+
+// setReservationExpiredCondition records that the reservation is over and the hostclaim will not be bound again.
+func (m *Manager) setReservationExpiredCondition() {
+	m.SetConditionHostToFalse(metal3api.AssociatedCondition, metal3api.ReservationExpiredReason,
+		"The reservation expired and the BareMetalHost was released, set image or customDeploy to claim a host again")
+}
+
+// updateReservation holds the associated BareMetalHost until the reservation expires, then releases it. While
+// the reservation lasts, it returns a RequeueAfterError expiring with the reservation.
+func (m *Manager) updateReservation(ctx context.Context) error {
+	ttl := m.HostClaim.Spec.ReservationTTL
+	if ttl == nil {
+		m.HostClaim.Status.ReservationExpirationTime = nil
+		m.SetConditionHostToTrue(metal3api.AssociatedCondition, metal3api.BareMetalHostReservedReason)
+		return nil
+	}
+
+	if m.HostClaim.Status.ReservationExpirationTime == nil {
+		expiration := metav1.NewTime(time.Now().Add(ttl.Duration))
+		m.HostClaim.Status.ReservationExpirationTime = &expiration
+	}
+
+	if !m.reservationExpired() {
+		m.SetConditionHostToTrue(metal3api.AssociatedCondition, metal3api.BareMetalHostReservedReason)
+		return RequeueAfterError{RequeueAfter: time.Until(m.HostClaim.Status.ReservationExpirationTime.Time)}
+	}
+
+	m.Log.Info("Reservation expired, releasing host", "bmh", m.HostClaim.Status.BareMetalHost.Name,
+		"bmhNamespace", m.HostClaim.Status.BareMetalHost.Namespace)
+	if err := m.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to release reserved host: %w", err)
+	}
+	m.setReservationExpiredCondition()
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/metal3-io/baremetal-operator/internal/testutil"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("HostClaim reservation", func() {
+
+	var (
+		ctx         = context.TODO()
+		image       = metal3api.Image{URL: "url"}
+		consumerRef = corev1.ObjectReference{Kind: HostClaimKind, Namespace: HostclaimNamespace,
+			APIVersion: metal3api.GroupVersion.String(), Name: HostclaimName}
+	)
+
+	// setup returns a manager for the claim and a host available for it.
+	setup := func(hostClaim *metal3api.HostClaim, bmh *metal3api.BareMetalHost) (*Manager, client.Client) {
+		objects := []client.Object{
+			hostClaim, bmh, NewNamespace(HostclaimNamespace).Build(), NewNamespace("ns1").Build(),
+			NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).Build(),
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+		hostMgr, ok := NewManager(fakeClient, GinkgoLogr, hostClaim, fakeClient).(*Manager)
+		Expect(ok).To(BeTrue())
+		return hostMgr, fakeClient
+	}
+	expectReason := func(hostClaim *metal3api.HostClaim, reason string) {
+		cond := conditions.Get(hostClaim, metal3api.AssociatedCondition)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal(reason))
+	}
+
+	It("holds the host without a TTL", func() {
+		hostClaim := NewHostclaim(HostclaimName).Build()
+		hostMgr, _ := setup(hostClaim, NewBaremetalhost("bmh", "ns1", metal3api.StateAvailable).Build())
+		Expect(hostMgr.Associate(ctx)).To(Succeed())
+		Expect(hostMgr.Update(ctx)).To(Succeed())
+		Expect(hostClaim.Status.BareMetalHost).NotTo(BeNil())
+		Expect(hostClaim.Status.ReservationExpirationTime).To(BeNil())
+		expectReason(hostClaim, metal3api.BareMetalHostReservedReason)
+	})
+
+	It("requeues until the reservation expires", func() {
+		hostClaim := NewHostclaim(HostclaimName).Build()
+		hostClaim.Spec.ReservationTTL = &metav1.Duration{Duration: time.Hour}
+		hostMgr, _ := setup(hostClaim, NewBaremetalhost("bmh", "ns1", metal3api.StateAvailable).Build())
+		Expect(hostMgr.Associate(ctx)).To(Succeed())
+		err := hostMgr.Update(ctx)
+		var requeueAfterError RequeueAfterError
+		Expect(errors.As(err, &requeueAfterError)).To(BeTrue())
+		Expect(requeueAfterError.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(hostClaim.Status.ReservationExpirationTime).NotTo(BeNil())
+		expectReason(hostClaim, metal3api.BareMetalHostReservedReason)
+	})
+
+	It("releases the host when the reservation expires", func() {
+		expiration := metav1.NewTime(time.Now().Add(-time.Minute))
+		hostClaim := NewHostclaim(HostclaimName).SetAssociatedBMH("ns1", "bmh").Build()
+		hostClaim.Spec.ReservationTTL = &metav1.Duration{Duration: time.Hour}
+		hostClaim.Status.ReservationExpirationTime = &expiration
+		bmh := NewBaremetalhost("bmh", "ns1", metal3api.StateAvailable).SetConsumerRef(consumerRef).Build()
+		hostMgr, fakeClient := setup(hostClaim, bmh)
+		Expect(hostMgr.Update(ctx)).To(Succeed())
+		Expect(hostClaim.Status.BareMetalHost).To(BeNil())
+		expectReason(hostClaim, metal3api.ReservationExpiredReason)
+		updatedBmh := &metal3api.BareMetalHost{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(bmh), updatedBmh)).To(Succeed())
+		Expect(updatedBmh.Spec.ConsumerRef).To(BeNil())
+
+		// The claim is not bound again until there is something to deploy
+		Expect(hostMgr.Associate(ctx)).To(Succeed())
+		Expect(hostClaim.Status.BareMetalHost).To(BeNil())
+		hostClaim.Spec.Image = &image
+		Expect(hostMgr.Associate(ctx)).To(Succeed())
+		Expect(hostClaim.Status.BareMetalHost).NotTo(BeNil())
+		Expect(hostMgr.Update(ctx)).To(Succeed())
+		Expect(hostClaim.Status.ReservationExpirationTime).To(BeNil())
+	})
+
+	It("keeps the host once there is something to deploy", func() {
+		expiration := metav1.NewTime(time.Now().Add(-time.Minute))
+		hostClaim := NewHostclaim(HostclaimName).SetAssociatedBMH("ns1", "bmh").SetImage(image).Build()
+		hostClaim.Spec.ReservationTTL = &metav1.Duration{Duration: time.Hour}
+		hostClaim.Status.ReservationExpirationTime = &expiration
+		hostMgr, _ := setup(hostClaim,
+			NewBaremetalhost("bmh", "ns1", metal3api.StateAvailable).SetConsumerRef(consumerRef).Build())
+		Expect(hostMgr.Update(ctx)).To(Succeed())
+		Expect(hostClaim.Status.BareMetalHost).NotTo(BeNil())
+		Expect(hostClaim.Status.ReservationExpirationTime).To(BeNil())
+	})
+})
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"cmp"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+)
+
+const (
+	// MaxScore is the highest score a Scorer gives to a candidate.
+	MaxScore = 100
+	// RackLabelName is a label name for the rack holding a BareMetalHost.
+	RackLabelName = "metal3.io/rack"
+)
+
+// Candidate is a BareMetalHost that can be bound to a HostClaim.
+type Candidate struct {
+	Host *metal3api.BareMetalHost
+	// HardwareData of the host, nil if the host was not inspected.
+	HardwareData *metal3api.HardwareData
+}
+
+// ScoringInput is the data available to scorers.
+type ScoringInput struct {
+	Claim      *metal3api.HostClaim
+	Candidates []Candidate
+	// Claimed holds the hosts matching the selector of the claim, in the
+	// namespaces it may use, that already have a consumer. The consumer can
+	// be a HostClaim of any namespace or another kind of object.
+	Claimed []*metal3api.BareMetalHost
+}
+
+// Scorer rates the candidates of a HostClaim.
+type Scorer interface {
+	// Name identifies the scorer in logs.
+	Name() string
+	// Score returns a score between 0 and MaxScore for each candidate,
+	// in the order of input.Candidates. Higher is better.
+	Score(input *ScoringInput) []int
+}
+
+// WeightedScorer is a Scorer with the weight of its scores in the total
+// score of a candidate.
+type WeightedScorer struct {
+	Scorer
+	Weight int
+}
+
+var strategies = map[metal3api.HostSelectionStrategy][]WeightedScorer{}
+
+// RegisterStrategy maps a host selection strategy, as used in the
+// SelectionStrategy field of a HostClaim, to its scorers.
+func RegisterStrategy(strategy metal3api.HostSelectionStrategy, scorers ...WeightedScorer) {
+	strategies[strategy] = scorers
+}
+
+func init() {
+	RegisterStrategy(metal3api.HostSelectionBalanced,
+		WeightedScorer{SpreadScorer{LabelName: FailureDomainLabelName}, 2},
+		WeightedScorer{SpreadScorer{LabelName: RackLabelName}, 1},
+		WeightedScorer{SmallestFitScorer{}, 2},
+		WeightedScorer{ProvisioningFailuresScorer{}, 2},
+		WeightedScorer{PoweredOffScorer{}, 1})
+	RegisterStrategy(metal3api.HostSelectionSpread,
+		WeightedScorer{SpreadScorer{LabelName: FailureDomainLabelName}, 4},
+		WeightedScorer{SpreadScorer{LabelName: RackLabelName}, 2},
+		WeightedScorer{ProvisioningFailuresScorer{}, 1},
+		WeightedScorer{PoweredOffScorer{}, 1})
+	RegisterStrategy(metal3api.HostSelectionSmallestFit,
+		WeightedScorer{SmallestFitScorer{}, 4},
+		WeightedScorer{ProvisioningFailuresScorer{}, 1},
+		WeightedScorer{PoweredOffScorer{}, 1})
+	RegisterStrategy(metal3api.HostSelectionRandom,
+		WeightedScorer{RandomScorer{}, 1})
+}
+
+// scorersFor returns the scorers of the strategy selected by the claim.
+func scorersFor(claim *metal3api.HostClaim) ([]WeightedScorer, error) {
+	strategy := claim.Spec.SelectionStrategy
+	if strategy == "" {
+		strategy = metal3api.HostSelectionBalanced
+	}
+	scorers, ok := strategies[strategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown host selection strategy %q", strategy)
+	}
+	return scorers, nil
+}
+
+// bestCandidate returns the index of the candidate with the highest total
+// score. Ties go to the first candidate by namespace and name, so that the
+// choice does not depend on the order hosts are listed in.
+func bestCandidate(input *ScoringInput, scorers []WeightedScorer) (best int, totals []int) {
+	totals = make([]int, len(input.Candidates))
+	for _, scorer := range scorers {
+		for i, score := range scorer.Score(input) {
+			totals[i] += scorer.Weight * score
+		}
+	}
+
+	for i := 1; i < len(input.Candidates); i++ {
+		if totals[i] > totals[best] ||
+			(totals[i] == totals[best] && compareHosts(input.Candidates[i].Host, input.Candidates[best].Host) < 0) {
+			best = i
+		}
+	}
+	return best, totals
+}
+
+func compareHosts(a, b *metal3api.BareMetalHost) int {
+	return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+}
+
+// SpreadScorer favors hosts whose value of a label is the least used by the
+// claimed hosts bound to HostClaims in the namespace of the claim. Hosts not
+// matching the selector of the claim are not counted.
+type SpreadScorer struct {
+	LabelName string
+}
+
+func (s SpreadScorer) Name() string {
+	return "spread/" + s.LabelName
+}
+
+func (s SpreadScorer) Score(input *ScoringInput) []int {
+	used := map[string]int{}
+	maxUsed := 0
+	for _, host := range input.Claimed {
+		ref := host.Spec.ConsumerRef
+		if ref == nil || ref.Kind != HostClaimKind || ref.Namespace != input.Claim.Namespace {
+			continue
+		}
+		value := host.Labels[s.LabelName]
+		used[value]++
+		maxUsed = max(maxUsed, used[value])
+	}
+
+	scores := make([]int, len(input.Candidates))
+	for i, candidate := range input.Candidates {
+		scores[i] = MaxScore
+		if maxUsed > 0 {
+			scores[i] = MaxScore * (maxUsed - used[candidate.Host.Labels[s.LabelName]]) / maxUsed
+		}
+	}
+	return scores
+}
+
+// SmallestFitScorer favors the hosts with the least CPUs, memory and
+// storage, keeping larger hosts for the claims that need them. Hosts
+// without HardwareData score 0.
+type SmallestFitScorer struct{}
+
+func (SmallestFitScorer) Name() string {
+	return "smallest-fit"
+}
+
+// hardwareSize returns the CPU count, memory and total storage of a host.
+func hardwareSize(hardwareData *metal3api.HardwareData) ([3]int64, bool) {
+	if hardwareData == nil || hardwareData.Spec.HardwareDetails == nil {
+		return [3]int64{}, false
+	}
+	details := hardwareData.Spec.HardwareDetails
+	var storage int64
+	for _, disk := range details.Storage {
+		storage += int64(disk.SizeBytes)
+	}
+	return [3]int64{int64(details.CPU.Count), int64(details.RAMMebibytes), storage}, true
+}
+
+func (SmallestFitScorer) Score(input *ScoringInput) []int {
+	sizes := make([][3]int64, len(input.Candidates))
+	known := make([]bool, len(input.Candidates))
+	var lowest, highest [3]int64
+	first := true
+	for i, candidate := range input.Candidates {
+		sizes[i], known[i] = hardwareSize(candidate.HardwareData)
+		if !known[i] {
+			continue
+		}
+		for dim, size := range sizes[i] {
+			if first || size < lowest[dim] {
+				lowest[dim] = size
+			}
+			if first || size > highest[dim] {
+				highest[dim] = size
+			}
+		}
+		first = false
+	}
+
+	scores := make([]int, len(input.Candidates))
+	for i := range input.Candidates {
+		if !known[i] {
+			continue
+		}
+		var total int64
+		for dim, size := range sizes[i] {
+			if highest[dim] == lowest[dim] {
+				total += MaxScore
+				continue
+			}
+			total += MaxScore * (highest[dim] - size) / (highest[dim] - lowest[dim])
+		}
+		scores[i] = int(total / int64(len(sizes[i])))
+	}
+	return scores
+}
+
+// ProvisioningFailuresScorer favors hosts that failed provisioning the
+// least often.
+type ProvisioningFailuresScorer struct{}
+
+func (ProvisioningFailuresScorer) Name() string {
+	return "provisioning-failures"
+}
+
+func (ProvisioningFailuresScorer) Score(input *ScoringInput) []int {
+	scores := make([]int, len(input.Candidates))
+	for i, candidate := range input.Candidates {
+		scores[i] = MaxScore / (1 + candidate.Host.Status.ProvisioningFailCount)
+	}
+	return scores
+}
+
+// PoweredOffScorer favors hosts that are powered off. An available host
+// that is powered on may still be used outside of Metal3.
+type PoweredOffScorer struct{}
+
+func (PoweredOffScorer) Name() string {
+	return "powered-off"
+}
+
+func (PoweredOffScorer) Score(input *ScoringInput) []int {
+	scores := make([]int, len(input.Candidates))
+	for i, candidate := range input.Candidates {
+		if !candidate.Host.Status.PoweredOn {
+			scores[i] = MaxScore
+		}
+	}
+	return scores
+}
+
+// RandomScorer gives random scores.
+type RandomScorer struct{}
+
+func (RandomScorer) Name() string {
+	return "random"
+}
+
+func (RandomScorer) Score(input *ScoringInput) []int {
+	scores := make([]int, len(input.Candidates))
+	for i := range scores {
+		r, _ := rand.Int(rand.Reader, big.NewInt(MaxScore+1))
+		scores[i] = int(r.Int64())
+	}
+	return scores
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Metal3 Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostclaim
+
+import (
+	"context"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/metal3-io/baremetal-operator/internal/testutil"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Host scoring", func() {
+
+	claimRef := func(namespace, name string) corev1.ObjectReference {
+		return corev1.ObjectReference{Kind: HostClaimKind, Namespace: namespace,
+			APIVersion: metal3api.GroupVersion.String(), Name: name}
+	}
+	hostInZone := func(name, zone string) *metal3api.BareMetalHost {
+		return NewBaremetalhost(name, "ns1", metal3api.StateAvailable).
+			SetLabels(map[string]string{FailureDomainLabelName: zone}).Build()
+	}
+	claimedInZone := func(name, zone string, ref corev1.ObjectReference) *metal3api.BareMetalHost {
+		return NewBaremetalhost(name, "ns1", metal3api.StateProvisioned).
+			SetLabels(map[string]string{FailureDomainLabelName: zone}).SetConsumerRef(ref).Build()
+	}
+	hardware := func(cpus, ramMebibytes int, disks ...metal3api.Capacity) *metal3api.HardwareData {
+		details := &metal3api.HardwareDetails{
+			CPU:          metal3api.CPU{Count: cpus},
+			RAMMebibytes: ramMebibytes,
+		}
+		for _, size := range disks {
+			details.Storage = append(details.Storage, metal3api.Storage{SizeBytes: size})
+		}
+		return &metal3api.HardwareData{Spec: metal3api.HardwareDataSpec{HardwareDetails: details}}
+	}
+	withFailures := func(host *metal3api.BareMetalHost, count int) *metal3api.BareMetalHost {
+		host.Status.ProvisioningFailCount = count
+		return host
+	}
+	poweredOn := func(host *metal3api.BareMetalHost) *metal3api.BareMetalHost {
+		host.Status.PoweredOn = true
+		return host
+	}
+
+	type testCaseScorer struct {
+		Scorer         Scorer
+		Candidates     []Candidate
+		Claimed        []*metal3api.BareMetalHost
+		ExpectedScores []int
+	}
+
+	DescribeTable("Test scorers",
+		func(tc testCaseScorer) {
+			input := &ScoringInput{
+				Claim:      NewHostclaim(HostclaimName).Build(),
+				Candidates: tc.Candidates,
+				Claimed:    tc.Claimed,
+			}
+			Expect(tc.Scorer.Score(input)).To(Equal(tc.ExpectedScores))
+		},
+		Entry("spread without claimed hosts", testCaseScorer{
+			Scorer:         SpreadScorer{LabelName: FailureDomainLabelName},
+			Candidates:     []Candidate{{Host: hostInZone("a", "z1")}, {Host: hostInZone("b", "z2")}},
+			ExpectedScores: []int{100, 100},
+		}),
+		Entry("spread favors least used failure domain", testCaseScorer{
+			Scorer: SpreadScorer{LabelName: FailureDomainLabelName},
+			Candidates: []Candidate{
+				{Host: hostInZone("a", "z1")}, {Host: hostInZone("b", "z2")}, {Host: hostInZone("c", "z3")}},
+			Claimed: []*metal3api.BareMetalHost{
+				claimedInZone("c1", "z1", claimRef(HostclaimNamespace, "other1")),
+				claimedInZone("c2", "z1", claimRef(HostclaimNamespace, "other2")),
+				claimedInZone("c3", "z2", claimRef(HostclaimNamespace, "other3")),
+			},
+			ExpectedScores: []int{0, 50, 100},
+		}),
+		Entry("spread ignores claims of other namespaces", testCaseScorer{
+			Scorer:     SpreadScorer{LabelName: FailureDomainLabelName},
+			Candidates: []Candidate{{Host: hostInZone("a", "z1")}, {Host: hostInZone("b", "z2")}},
+			Claimed: []*metal3api.BareMetalHost{
+				claimedInZone("c1", "z1", claimRef("elsewhere", "other1")),
+			},
+			ExpectedScores: []int{100, 100},
+		}),
+		Entry("smallest fit", testCaseScorer{
+			Scorer: SmallestFitScorer{},
+			Candidates: []Candidate{
+				{Host: hostInZone("a", "z1"), HardwareData: hardware(64, 262144, 2000)},
+				{Host: hostInZone("b", "z1"), HardwareData: hardware(8, 32768, 1000)},
+				{Host: hostInZone("c", "z1"), HardwareData: hardware(8, 32768, 1000, 1000)},
+				{Host: hostInZone("d", "z1")},
+			},
+			ExpectedScores: []int{0, 100, 66, 0},
+		}),
+		Entry("provisioning failures", testCaseScorer{
+			Scorer: ProvisioningFailuresScorer{},
+			Candidates: []Candidate{
+				{Host: hostInZone("a", "z1")},
+				{Host: withFailures(hostInZone("b", "z1"), 1)},
+				{Host: withFailures(hostInZone("c", "z1"), 3)},
+			},
+			ExpectedScores: []int{100, 50, 25},
+		}),
+		Entry("powered off", testCaseScorer{
+			Scorer: PoweredOffScorer{},
+			Candidates: []Candidate{
+				{Host: hostInZone("a", "z1")},
+				{Host: poweredOn(hostInZone("b", "z1"))},
+			},
+			ExpectedScores: []int{100, 0},
+		}),
+	)
+
+	It("breaks ties by namespace and name", func() {
+		input := &ScoringInput{
+			Claim: NewHostclaim(HostclaimName).Build(),
+			Candidates: []Candidate{
+				{Host: NewBaremetalhost("b", "ns1", metal3api.StateAvailable).Build()},
+				{Host: NewBaremetalhost("a", "ns2", metal3api.StateAvailable).Build()},
+				{Host: NewBaremetalhost("a", "ns1", metal3api.StateAvailable).Build()},
+			},
+		}
+		best, totals := bestCandidate(input, []WeightedScorer{{PoweredOffScorer{}, 1}})
+		Expect(best).To(Equal(2))
+		Expect(totals).To(Equal([]int{100, 100, 100}))
+	})
+
+	It("applies the weights of the scorers", func() {
+		input := &ScoringInput{
+			Claim: NewHostclaim(HostclaimName).Build(),
+			Candidates: []Candidate{
+				{Host: withFailures(hostInZone("a", "z1"), 1)},
+				{Host: poweredOn(hostInZone("b", "z1"))},
+			},
+		}
+		best, totals := bestCandidate(input, []WeightedScorer{
+			{ProvisioningFailuresScorer{}, 3}, {PoweredOffScorer{}, 1}})
+		Expect(best).To(Equal(1))
+		Expect(totals).To(Equal([]int{250, 300}))
+	})
+
+	It("rejects unknown strategies", func() {
+		_, err := scorersFor(NewHostclaim(HostclaimName).SetSelectionStrategy("Unknown").Build())
+		Expect(err).To(HaveOccurred())
+		scorers, err := scorersFor(NewHostclaim(HostclaimName).Build())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scorers).To(Equal(strategies[metal3api.HostSelectionBalanced]))
+	})
+
+	type testCaseStrategy struct {
+		Strategy        metal3api.HostSelectionStrategy
+		ExpectedBmhName string
+	}
+
+	DescribeTable("Test chooseBMH with selection strategy",
+		func(tc testCaseStrategy) {
+			labels := map[string]string{"default-selector": "default-value"}
+			hostClaim := NewHostclaim(HostclaimName).SetLabelSelector(labels).SetSelectionStrategy(tc.Strategy).Build()
+			// big is the smallest name but the largest host, small shares its
+			// failure domain with the host already claimed in the namespace.
+			big := NewBaremetalhost("big", "ns1", metal3api.StateAvailable).
+				SetLabels(map[string]string{"default-selector": "default-value", FailureDomainLabelName: "z1"}).Build()
+			small := NewBaremetalhost("small", "ns1", metal3api.StateAvailable).
+				SetLabels(map[string]string{"default-selector": "default-value", FailureDomainLabelName: "z2"}).Build()
+			claimed := NewBaremetalhost("claimed", "ns1", metal3api.StateProvisioned).
+				SetLabels(map[string]string{"default-selector": "default-value", FailureDomainLabelName: "z2"}).
+				SetConsumerRef(claimRef(HostclaimNamespace, "other")).Build()
+			objects := []client.Object{
+				hostClaim,
+				NewNamespace(HostclaimNamespace).Build(),
+				NewNamespace("ns1").Build(),
+				NewHostdeploypolicy("hdp", "ns1").AcceptNames([]string{HostclaimNamespace}).Build(),
+				big, small, claimed,
+				NewHardwareData(big).SetHardwareDetails(&metal3api.HardwareDetails{
+					CPU: metal3api.CPU{Count: 64}, RAMMebibytes: 262144}).Build(),
+				NewHardwareData(small).SetHardwareDetails(&metal3api.HardwareDetails{
+					CPU: metal3api.CPU{Count: 8}, RAMMebibytes: 32768}).Build(),
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			hostMgr, ok := NewManager(fakeClient, GinkgoLogr, hostClaim, fakeClient).(*Manager)
+			Expect(ok).To(BeTrue())
+			bmh, err := hostMgr.chooseBMH(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bmh.Name).To(Equal(tc.ExpectedBmhName))
+		},
+		Entry("spread", testCaseStrategy{Strategy: metal3api.HostSelectionSpread, ExpectedBmhName: "big"}),
+		Entry("smallest fit", testCaseStrategy{Strategy: metal3api.HostSelectionSmallestFit, ExpectedBmhName: "small"}),
+	)
+})
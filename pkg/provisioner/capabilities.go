@@ -0,0 +1,88 @@
+package provisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Capability identifies an optional part of the Provisioner interface that a
+// provisioning backend may not implement for a given host.
+type Capability string
+
+const (
+	// CapabilityFirmwareSettings covers GetFirmwareSettings.
+	CapabilityFirmwareSettings Capability = "FirmwareSettings"
+	// CapabilityFirmwareComponents covers GetFirmwareComponents.
+	CapabilityFirmwareComponents Capability = "FirmwareComponents"
+	// CapabilityDataImage covers GetDataImageStatus, AttachDataImage and
+	// DetachDataImage.
+	CapabilityDataImage Capability = "DataImage"
+	// CapabilityBMCEventSubscription covers AddBMCEventSubscriptionForNode
+	// and RemoveBMCEventSubscriptionForNode.
+	CapabilityBMCEventSubscription Capability = "BMCEventSubscription"
+	// CapabilityHealth covers GetHealth.
+	CapabilityHealth Capability = "Health"
+)
+
+// AllCapabilities lists every known capability.
+var AllCapabilities = []Capability{
+	CapabilityFirmwareSettings,
+	CapabilityFirmwareComponents,
+	CapabilityDataImage,
+	CapabilityBMCEventSubscription,
+	CapabilityHealth,
+}
+
+// Capabilities describes what a provisioner supports for a host.
+type Capabilities struct {
+	// Provisioner is the name of the provisioner, used in messages.
+	Provisioner string
+	Supported   []Capability
+}
+
+// Has reports whether the capability is supported.
+func (c Capabilities) Has(capability Capability) bool {
+	return slices.Contains(c.Supported, capability)
+}
+
+// CapabilityReporter is implemented by provisioners that can tell up front
+// which optional calls they support. Provisioners that do not implement it
+// are assumed to support all of them.
+type CapabilityReporter interface {
+	Capabilities(ctx context.Context) Capabilities
+}
+
+// ErrNotSupported is matched by the errors returned by CheckCapability.
+var ErrNotSupported = errors.New("not supported")
+
+// NotSupportedError is returned by CheckCapability when the provisioner of
+// a host lacks a capability.
+type NotSupportedError struct {
+	Provisioner string
+	Capability  Capability
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s is not supported by provisioner %s", e.Capability, e.Provisioner)
+}
+
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// CheckCapability returns a NotSupportedError when prov reports that it does
+// not support the capability, and nil otherwise. Only the controllers check
+// capabilities, the webhooks admit specs regardless of them.
+func CheckCapability(ctx context.Context, prov Provisioner, capability Capability) error {
+	reporter, ok := prov.(CapabilityReporter)
+	if !ok {
+		return nil
+	}
+	capabilities := reporter.Capabilities(ctx)
+	if capabilities.Has(capability) {
+		return nil
+	}
+	return &NotSupportedError{Provisioner: capabilities.Provisioner, Capability: capability}
+}
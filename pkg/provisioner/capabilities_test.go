@@ -0,0 +1,50 @@
+package provisioner_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+)
+
+// silentProvisioner does not report its capabilities.
+type silentProvisioner struct {
+	provisioner.Provisioner
+}
+
+func TestCheckCapability(t *testing.T) {
+	ctx := context.Background()
+
+	for _, capability := range provisioner.AllCapabilities {
+		if err := provisioner.CheckCapability(ctx, silentProvisioner{}, capability); err != nil {
+			t.Errorf("%s should be assumed supported, got %v", capability, err)
+		}
+	}
+
+	state := &fixture.Fixture{Unsupported: []provisioner.Capability{provisioner.CapabilityFirmwareComponents}}
+	prov, err := state.NewProvisioner(ctx, provisioner.HostData{}, func(string, string) {})
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+
+	if err := provisioner.CheckCapability(ctx, prov, provisioner.CapabilityFirmwareSettings); err != nil {
+		t.Errorf("FirmwareSettings should be supported, got %v", err)
+	}
+
+	err = provisioner.CheckCapability(ctx, prov, provisioner.CapabilityFirmwareComponents)
+	var notSupported *provisioner.NotSupportedError
+	if !errors.As(err, &notSupported) {
+		t.Fatalf("expected a NotSupportedError, got %v", err)
+	}
+	if !errors.Is(err, provisioner.ErrNotSupported) {
+		t.Errorf("error should match ErrNotSupported")
+	}
+	if notSupported.Provisioner != "fixture" || notSupported.Capability != provisioner.CapabilityFirmwareComponents {
+		t.Errorf("unexpected error %+v", notSupported)
+	}
+	if err.Error() != "FirmwareComponents is not supported by provisioner fixture" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
@@ -2,6 +2,8 @@ package demo
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,6 +12,7 @@ import (
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
 	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logz "sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
@@ -61,22 +64,65 @@ type demoProvisioner struct {
 	log logr.Logger
 	// an event publisher for recording significant events
 	publisher provisioner.EventPublisher
+	// the behaviour of the host
+	scenario *Scenario
+	// the state of the host across provisioners
+	states *states
+	state  *hostState
+	now    func() time.Time
 }
 
-type Demo struct{}
+// Demo is a factory of provisioners behaving as described by the scenario
+// of each host, see ScenarioAnnotation. Hosts without a scenario behave
+// according to their name.
+type Demo struct {
+	// Client reads the ConfigMaps holding the scenarios, if set.
+	Client client.Reader
+
+	states states
+	// now is replaced in tests
+	now func() time.Time
+}
 
 // NewProvisioner returns a new demo Provisioner.
-func (d Demo) NewProvisioner(_ context.Context, hostData provisioner.HostData, publisher provisioner.EventPublisher) (provisioner.Provisioner, error) {
+func (d *Demo) NewProvisioner(ctx context.Context, hostData provisioner.HostData, publisher provisioner.EventPublisher) (provisioner.Provisioner, error) {
+	scenario, err := d.loadScenario(ctx, hostData.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &demoProvisioner{
 		objectMeta: hostData.ObjectMeta,
 		provID:     hostData.ProvisionerID,
 		bmcCreds:   hostData.BMCCredentials,
 		log:        log.WithValues("host", hostData.ObjectMeta.Name),
 		publisher:  publisher,
+		scenario:   scenario,
+		states:     &d.states,
+		state:      d.states.get(hostData.ObjectMeta, scenario),
+		now:        d.now,
+	}
+	if p.now == nil {
+		p.now = time.Now
 	}
 	return p, nil
 }
 
+// run advances an operation of the scenario.
+func (p *demoProvisioner) run(name string, operation Operation) (result provisioner.Result, out outcome) {
+	out = p.states.run(p.state, name, operation, p.now())
+	switch {
+	case out.errorMessage != "":
+		p.log.Info("operation failed", "operation", name, "error", out.errorMessage)
+		result.ErrorMessage = out.errorMessage
+	case !out.done:
+		p.log.Info("operation in progress", "operation", name)
+		result.Dirty = true
+		result.RequeueAfter = out.requeueAfter
+	}
+	return result, out
+}
+
 func (p *demoProvisioner) HasCapacity(_ context.Context) (result bool, err error) {
 	return true, nil
 }
@@ -86,26 +132,11 @@ func (p *demoProvisioner) HasCapacity(_ context.Context) (result bool, err error
 func (p *demoProvisioner) Register(_ context.Context, _ provisioner.ManagementAccessData, _, _ bool) (result provisioner.Result, provID string, err error) {
 	p.log.Info("testing management access")
 
-	hostName := p.objectMeta.Name
-
-	switch hostName {
-	case RegistrationErrorHost:
-		// We have set an error, so Reconcile() will stop
-		result.ErrorMessage = "failed to register new host"
-		p.log.Info("setting registration error")
-
-	case RegisteringHost:
-		// Always mark the host as dirty so it never moves past this
-		// point.
+	result, out := p.run(opRegister, p.scenario.Register)
+	if out.done && p.provID == "" {
+		provID = p.objectMeta.Name
+		p.log.Info("setting provisioning id", "provisioningID", provID)
 		result.Dirty = true
-		result.RequeueAfter = time.Second * fixture.DefaultRequeueSecs
-
-	default:
-		if p.provID == "" {
-			provID = p.objectMeta.Name
-			p.log.Info("setting provisioning id", "provisioningID", provID)
-			result.Dirty = true
-		}
 	}
 
 	return
@@ -121,114 +152,97 @@ func (p *demoProvisioner) PreprovisioningImageFormats(_ context.Context) ([]meta
 // inspection is completed.
 func (p *demoProvisioner) InspectHardware(_ context.Context, _ provisioner.InspectData, _, _, _ bool) (result provisioner.Result, started bool, details *metal3api.HardwareDetails, err error) {
 	started = true
-	hostName := p.objectMeta.Name
 
-	if hostName == InspectingHost {
-		// set dirty so we don't allow the host to progress past this
-		// state in Reconcile()
-		result.Dirty = true
-		result.RequeueAfter = time.Second * fixture.DefaultRequeueSecs
+	result, out := p.run(opInspect, p.scenario.Inspect)
+	if !out.done {
 		return result, started, nil, nil
 	}
 
 	p.log.Info("continuing inspection by setting details")
-	details =
-		&metal3api.HardwareDetails{
-			RAMMebibytes: fixture.DefaultRAMMebibytes * fixture.DefaultGB,
-			NIC: []metal3api.NIC{
-				{
-					Name:      "nic-1",
-					Model:     "virt-io",
-					MAC:       "ab:cd:12:34:56:78",
-					IP:        "192.168.100.1",
-					SpeedGbps: 1,
-					PXE:       true,
-				},
-				{
-					Name:      "nic-2",
-					Model:     "e1000",
-					MAC:       "12:34:56:78:ab:cd",
-					IP:        "192.168.100.2",
-					SpeedGbps: 1,
-					PXE:       false,
-				},
-			},
-			Storage: []metal3api.Storage{
-				{
-					Name:       "disk-1 (boot)",
-					Rotational: false,
-					SizeBytes:  metal3api.TebiByte * fixture.DefaultSizeBytes,
-					Model:      "Dell CFJ61",
-				},
-				{
-					Name:       "disk-2",
-					Rotational: false,
-					SizeBytes:  metal3api.TebiByte * fixture.DefaultSizeBytes,
-					Model:      "Dell CFJ61",
-				},
-			},
-			CPU: metal3api.CPU{
-				Arch:           "x86_64",
-				Model:          "Core 2 Duo",
-				ClockMegahertz: fixture.DefaultClockMegahertz * metal3api.GigaHertz,
-				Flags:          []string{"lm", "hypervisor", "vmx"},
-				Count:          1,
-			},
-		}
+	details = defaultHardwareDetails()
+	if p.scenario.Hardware != nil {
+		details = p.scenario.Hardware.DeepCopy()
+	}
 	p.publisher("InspectionComplete", "Hardware inspection completed")
 
 	return result, started, details, nil
 }
 
+func defaultHardwareDetails() *metal3api.HardwareDetails {
+	return &metal3api.HardwareDetails{
+		RAMMebibytes: fixture.DefaultRAMMebibytes * fixture.DefaultGB,
+		NIC: []metal3api.NIC{
+			{
+				Name:      "nic-1",
+				Model:     "virt-io",
+				MAC:       "ab:cd:12:34:56:78",
+				IP:        "192.168.100.1",
+				SpeedGbps: 1,
+				PXE:       true,
+			},
+			{
+				Name:      "nic-2",
+				Model:     "e1000",
+				MAC:       "12:34:56:78:ab:cd",
+				IP:        "192.168.100.2",
+				SpeedGbps: 1,
+				PXE:       false,
+			},
+		},
+		Storage: []metal3api.Storage{
+			{
+				Name:       "disk-1 (boot)",
+				Rotational: false,
+				SizeBytes:  metal3api.TebiByte * fixture.DefaultSizeBytes,
+				Model:      "Dell CFJ61",
+			},
+			{
+				Name:       "disk-2",
+				Rotational: false,
+				SizeBytes:  metal3api.TebiByte * fixture.DefaultSizeBytes,
+				Model:      "Dell CFJ61",
+			},
+		},
+		CPU: metal3api.CPU{
+			Arch:           "x86_64",
+			Model:          "Core 2 Duo",
+			ClockMegahertz: fixture.DefaultClockMegahertz * metal3api.GigaHertz,
+			Flags:          []string{"lm", "hypervisor", "vmx"},
+			Count:          1,
+		},
+	}
+}
+
 // UpdateHardwareState fetches the latest hardware state of the server
 // and updates the HardwareDetails field of the host with details. It
 // is expected to do this in the least expensive way possible, such as
 // reading from a cache.
 func (p *demoProvisioner) UpdateHardwareState(_ context.Context) (hwState provisioner.HardwareState, err error) {
 	p.log.Info("updating hardware state")
+	hwState.PoweredOn = p.states.getPoweredOn(p.state)
 	return
 }
 
 // Prepare remove existing configuration and set new configuration.
 func (p *demoProvisioner) Prepare(_ context.Context, _ provisioner.PrepareData, unprepared bool, _ bool) (result provisioner.Result, started bool, err error) {
-	hostName := p.objectMeta.Name
-
-	switch hostName {
-	case PreparingErrorHost:
-		p.log.Info("preparing error host")
-		result.ErrorMessage = "preparing failed"
+	p.log.Info("preparing host")
 
-	case PreparingHost:
-		p.log.Info("preparing host")
-		started = unprepared
-		result.Dirty = true
-		result.RequeueAfter = time.Second * fixture.DefaultRequeueSecs
-
-	default:
+	result, out := p.run(opPrepare, p.scenario.Prepare)
+	started = out.done || (out.started && unprepared)
+	if out.done {
 		p.log.Info("finished preparing")
-		started = true
 	}
 
 	return
 }
 
 func (p *demoProvisioner) Service(_ context.Context, _ provisioner.ServicingData, unprepared bool, _ bool) (result provisioner.Result, started bool, err error) {
-	hostName := p.objectMeta.Name
-
-	switch hostName {
-	case PreparingErrorHost:
-		p.log.Info("servicing error host")
-		result.ErrorMessage = "servicing failed"
-
-	case PreparingHost:
-		p.log.Info("servicing host")
-		started = unprepared
-		result.Dirty = true
-		result.RequeueAfter = time.Second * fixture.DefaultRequeueSecs
+	p.log.Info("servicing host")
 
-	default:
+	result, out := p.run(opService, p.scenario.Service)
+	started = out.done || (out.started && unprepared)
+	if out.done {
 		p.log.Info("finished servicing")
-		started = true
 	}
 
 	return
@@ -246,20 +260,10 @@ func (p *demoProvisioner) Adopt(_ context.Context, _ provisioner.AdoptData, _ bo
 // be called multiple times, and should return true for its dirty flag
 // until the provisioning operation is completed.
 func (p *demoProvisioner) Provision(_ context.Context, _ provisioner.ProvisionData, _ bool) (result provisioner.Result, err error) {
-	hostName := p.objectMeta.Name
 	p.log.Info("provisioning image to host")
 
-	switch hostName {
-	case ValidationErrorHost:
-		p.log.Info("setting validation error")
-		result.ErrorMessage = "validation failed"
-
-	case ProvisioningHost:
-		p.log.Info("provisioning host")
-		result.Dirty = true
-		result.RequeueAfter = time.Second * fixture.DefaultRequeueSecs
-
-	default:
+	result, out := p.run(opProvision, p.scenario.Provision)
+	if out.done {
 		p.log.Info("finished provisioning")
 	}
 
@@ -271,6 +275,7 @@ func (p *demoProvisioner) Provision(_ context.Context, _ provisioner.ProvisionDa
 // deprovisioning operation is completed.
 func (p *demoProvisioner) Deprovision(_ context.Context, _ bool, _ metal3api.AutomatedCleaningMode) (result provisioner.Result, err error) {
 	p.log.Info("deprovisioning host")
+	result, _ = p.run(opDeprovision, p.scenario.Deprovision)
 	return result, nil
 }
 
@@ -279,6 +284,7 @@ func (p *demoProvisioner) Deprovision(_ context.Context, _ bool, _ metal3api.Aut
 // until the deprovisioning operation is completed.
 func (p *demoProvisioner) Delete(_ context.Context) (result provisioner.Result, err error) {
 	p.log.Info("deleting host")
+	p.states.forget(p.objectMeta)
 	return result, nil
 }
 
@@ -289,6 +295,7 @@ func (p *demoProvisioner) Delete(_ context.Context) (result provisioner.Result,
 // deletion operation is completed.
 func (p *demoProvisioner) Detach(_ context.Context, _ bool) (result provisioner.Result, err error) {
 	p.log.Info("detaching host")
+	p.states.forget(p.objectMeta)
 	return result, nil
 }
 
@@ -296,6 +303,10 @@ func (p *demoProvisioner) Detach(_ context.Context, _ bool) (result provisioner.
 // provisioning operation.
 func (p *demoProvisioner) PowerOn(_ context.Context, _ bool) (result provisioner.Result, err error) {
 	p.log.Info("powering on host")
+	result, out := p.run(opPowerOn, p.scenario.PowerOn)
+	if out.done {
+		p.states.setPoweredOn(p.state, true)
+	}
 	return result, nil
 }
 
@@ -303,11 +314,16 @@ func (p *demoProvisioner) PowerOn(_ context.Context, _ bool) (result provisioner
 // provisioning operation.
 func (p *demoProvisioner) PowerOff(_ context.Context, _ metal3api.RebootMode, _ bool, _ metal3api.AutomatedCleaningMode) (result provisioner.Result, err error) {
 	p.log.Info("powering off host")
+	result, out := p.run(opPowerOff, p.scenario.PowerOff)
+	if out.done {
+		p.states.setPoweredOn(p.state, false)
+	}
 	return result, nil
 }
 
 func (p *demoProvisioner) GetFirmwareSettings(_ context.Context, _ bool) (settings metal3api.SettingsMap, schema map[string]metal3api.SettingSchema, err error) {
 	p.log.Info("getting BIOS settings")
+	settings = maps.Clone(p.scenario.FirmwareSettings)
 	return
 }
 
@@ -320,6 +336,7 @@ func (p *demoProvisioner) RemoveBMCEventSubscriptionForNode(_ context.Context, _
 }
 
 func (p *demoProvisioner) GetFirmwareComponents(_ context.Context) (components []metal3api.FirmwareComponentStatus, err error) {
+	components = slices.Clone(p.scenario.FirmwareComponents)
 	return components, nil
 }
 
@@ -336,9 +353,27 @@ func (p *demoProvisioner) DetachDataImage(_ context.Context) (err error) {
 }
 
 func (p *demoProvisioner) HasPowerFailure(_ context.Context) bool {
-	return false
+	return p.scenario.HasPowerFailure
 }
 
 func (p *demoProvisioner) GetHealth(_ context.Context) string {
-	return ""
+	return p.scenario.Health
+}
+
+// Capabilities reports everything but health, unless the scenario of the
+// host sets one.
+func (p *demoProvisioner) Capabilities(_ context.Context) provisioner.Capabilities {
+	capabilities := provisioner.Capabilities{
+		Provisioner: "demo",
+		Supported: []provisioner.Capability{
+			provisioner.CapabilityFirmwareSettings,
+			provisioner.CapabilityFirmwareComponents,
+			provisioner.CapabilityDataImage,
+			provisioner.CapabilityBMCEventSubscription,
+		},
+	}
+	if p.scenario.Health != "" {
+		capabilities.Supported = append(capabilities.Supported, provisioner.CapabilityHealth)
+	}
+	return capabilities
 }
@@ -26,6 +26,6 @@ func PluginName() string { return "demo" }
 // NewProvisionerFactory is the exported symbol BMO looks up in the plugin,
 // resolved at runtime via plugin.Lookup so static analysis cannot see the
 // reference.
-func NewProvisionerFactory(_ provisioner.PluginConfig) (provisioner.Factory, error) {
-	return &demo.Demo{}, nil
+func NewProvisionerFactory(config provisioner.PluginConfig) (provisioner.Factory, error) {
+	return &demo.Demo{Client: config.APIReader}, nil
 }
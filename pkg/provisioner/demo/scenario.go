@@ -0,0 +1,278 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ScenarioAnnotation names the ConfigMap, in the namespace of the
+	// host, holding the scenario of the host.
+	ScenarioAnnotation = "demo.metal3.io/scenario"
+
+	// ScenarioKey is the key of the scenario in the ConfigMap.
+	ScenarioKey = "scenario.yaml"
+)
+
+// Operation describes how an operation of the provisioner behaves.
+type Operation struct {
+	// Latency is how long each attempt takes to complete.
+	Latency metav1.Duration `json:"latency,omitempty"`
+
+	// Hang makes every attempt stay in progress forever.
+	Hang bool `json:"hang,omitempty"`
+
+	// FailOnAttempts lists the attempts that fail, counting from 1.
+	FailOnAttempts []int `json:"failOnAttempts,omitempty"`
+
+	// Fail makes every attempt fail.
+	Fail bool `json:"fail,omitempty"`
+
+	// ErrorMessage is the error of the failed attempts.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// Scenario describes how the demo provisioner behaves for a host.
+type Scenario struct {
+	Register    Operation `json:"register,omitempty"`
+	Inspect     Operation `json:"inspect,omitempty"`
+	Prepare     Operation `json:"prepare,omitempty"`
+	Service     Operation `json:"service,omitempty"`
+	Provision   Operation `json:"provision,omitempty"`
+	Deprovision Operation `json:"deprovision,omitempty"`
+	PowerOn     Operation `json:"powerOn,omitempty"`
+	PowerOff    Operation `json:"powerOff,omitempty"`
+
+	// PoweredOn is the power state of the host before it is first powered
+	// on or off. It is unknown if not set.
+	PoweredOn *bool `json:"poweredOn,omitempty"`
+
+	// HasPowerFailure reports a power failure of the host.
+	HasPowerFailure bool `json:"hasPowerFailure,omitempty"`
+
+	// Hardware is returned by the inspection instead of the default
+	// details.
+	Hardware *metal3api.HardwareDetails `json:"hardware,omitempty"`
+
+	// FirmwareSettings are the firmware settings of the host.
+	FirmwareSettings metal3api.SettingsMap `json:"firmwareSettings,omitempty"`
+
+	// FirmwareComponents are the firmware components of the host.
+	FirmwareComponents []metal3api.FirmwareComponentStatus `json:"firmwareComponents,omitempty"`
+
+	// Health is the health of the host, e.g. OK, Warning or Critical.
+	// The health capability is only reported when set.
+	Health string `json:"health,omitempty"`
+}
+
+// builtinScenarios keeps the behaviour of the hosts with the names the
+// demo provisioner has always recognized.
+var builtinScenarios = map[string]Scenario{
+	RegistrationErrorHost: {
+		Register: Operation{Fail: true, ErrorMessage: "failed to register new host"},
+	},
+	RegisteringHost: {
+		Register: Operation{Hang: true},
+	},
+	InspectingHost: {
+		Inspect: Operation{Hang: true},
+	},
+	PreparingErrorHost: {
+		Prepare: Operation{Fail: true, ErrorMessage: "preparing failed"},
+		Service: Operation{Fail: true, ErrorMessage: "servicing failed"},
+	},
+	PreparingHost: {
+		Prepare: Operation{Hang: true},
+		Service: Operation{Hang: true},
+	},
+	ValidationErrorHost: {
+		Provision: Operation{Fail: true, ErrorMessage: "validation failed"},
+	},
+	ProvisioningHost: {
+		Provision: Operation{Hang: true},
+	},
+}
+
+// ParseScenario parses a scenario in YAML.
+func ParseScenario(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, scenario); err != nil {
+		return nil, fmt.Errorf("invalid demo scenario: %w", err)
+	}
+	return scenario, nil
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// loadScenario returns the scenario of the host, from the ConfigMap named by
+// its annotation or built in for its name.
+func (d *Demo) loadScenario(ctx context.Context, objectMeta metav1.ObjectMeta) (*Scenario, error) {
+	name, ok := objectMeta.Annotations[ScenarioAnnotation]
+	if !ok {
+		scenario := builtinScenarios[objectMeta.Name]
+		return &scenario, nil
+	}
+
+	if d.Client == nil {
+		return nil, fmt.Errorf("cannot read the demo scenario %s without a Kubernetes client", name)
+	}
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: objectMeta.Namespace, Name: name}
+	if err := d.Client.Get(ctx, key, configMap); err != nil {
+		return nil, fmt.Errorf("failed to read the demo scenario %s: %w", name, err)
+	}
+	data, ok := configMap.Data[ScenarioKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s has no %s key", name, ScenarioKey)
+	}
+	return ParseScenario([]byte(data))
+}
+
+// operationState tracks the attempts of an operation.
+type operationState struct {
+	attempts  int
+	startedAt time.Time
+	running   bool
+	done      bool
+}
+
+// hostState is the state of a host kept across the provisioners created for
+// it.
+type hostState struct {
+	operations map[string]*operationState
+	poweredOn  *bool
+}
+
+// states tracks the hosts of the demo provisioner.
+type states struct {
+	lock  sync.Mutex
+	hosts map[string]*hostState
+}
+
+func (s *states) get(objectMeta metav1.ObjectMeta, scenario *Scenario) *hostState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.hosts == nil {
+		s.hosts = make(map[string]*hostState)
+	}
+	key := objectMeta.Namespace + "/" + objectMeta.Name
+	state, ok := s.hosts[key]
+	if !ok {
+		state = &hostState{operations: make(map[string]*operationState)}
+		if scenario.PoweredOn != nil {
+			poweredOn := *scenario.PoweredOn
+			state.poweredOn = &poweredOn
+		}
+		s.hosts[key] = state
+	}
+	return state
+}
+
+func (s *states) forget(objectMeta metav1.ObjectMeta) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.hosts, objectMeta.Namespace+"/"+objectMeta.Name)
+}
+
+// outcome is the outcome of a call to an operation.
+type outcome struct {
+	// started is set when the call started a new attempt.
+	started bool
+	// done is set when the operation has completed.
+	done bool
+	// requeueAfter is set while the operation is in progress.
+	requeueAfter time.Duration
+	// errorMessage is set when the attempt failed.
+	errorMessage string
+}
+
+// run advances the operation name of the host. Registration stays done
+// once completed, other operations are done until another one starts.
+func (s *states) run(state *hostState, name string, operation Operation, now time.Time) outcome {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	op, ok := state.operations[name]
+	if !ok {
+		op = &operationState{}
+		state.operations[name] = op
+	}
+	if op.done {
+		return outcome{done: true}
+	}
+
+	result := outcome{}
+	if !op.running {
+		op.attempts++
+		op.running = true
+		op.startedAt = now
+		result.started = true
+		for other, otherOp := range state.operations {
+			if other != name && other != opRegister {
+				otherOp.done = false
+			}
+		}
+	}
+
+	if operation.Fail || slices.Contains(operation.FailOnAttempts, op.attempts) {
+		op.running = false
+		result.errorMessage = operation.ErrorMessage
+		if result.errorMessage == "" {
+			result.errorMessage = fmt.Sprintf("%s failed on attempt %d", name, op.attempts)
+		}
+		return result
+	}
+
+	remaining := operation.Latency.Duration - now.Sub(op.startedAt)
+	if operation.Hang || remaining > 0 {
+		result.requeueAfter = time.Second * fixture.DefaultRequeueSecs
+		if !operation.Hang && remaining < result.requeueAfter {
+			result.requeueAfter = remaining
+		}
+		return result
+	}
+
+	op.running = false
+	op.done = true
+	result.done = true
+	return result
+}
+
+func (s *states) setPoweredOn(state *hostState, poweredOn bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state.poweredOn = &poweredOn
+}
+
+func (s *states) getPoweredOn(state *hostState) *bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if state.poweredOn == nil {
+		return nil
+	}
+	poweredOn := *state.poweredOn
+	return &poweredOn
+}
+
+// Names of the operations.
+const (
+	opRegister    = "register"
+	opInspect     = "inspect"
+	opPrepare     = "prepare"
+	opService     = "service"
+	opProvision   = "provision"
+	opDeprovision = "deprovision"
+	opPowerOn     = "power on"
+	opPowerOff    = "power off"
+)
@@ -0,0 +1,75 @@
+package demo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseScenarioStrict(t *testing.T) {
+	if _, err := ParseScenario([]byte("register:\n  latency: 10s\n")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ParseScenario([]byte("regster:\n  latency: 10s\n")); err == nil {
+		t.Error("expected an unknown field to be rejected")
+	}
+}
+
+func TestScenarioOperations(t *testing.T) {
+	scenario, err := ParseScenario([]byte(`
+register:
+  failOnAttempts: [1]
+powerOn:
+  latency: 30s
+poweredOn: false
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	d := &Demo{now: func() time.Time { return now }}
+	// Track the host with the parsed scenario, as if read from a ConfigMap.
+	d.states.get(metav1.ObjectMeta{Namespace: "metal3", Name: "host"}, scenario)
+	hostData := provisioner.HostData{ObjectMeta: metav1.ObjectMeta{Namespace: "metal3", Name: "host"}}
+	prov, err := d.NewProvisioner(context.Background(), hostData, func(_, _ string) {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The host has no annotation, use the parsed scenario instead.
+	p := prov.(*demoProvisioner) //nolint:forcetypeassert
+	p.scenario = scenario
+
+	result, provID, _ := p.Register(context.Background(), provisioner.ManagementAccessData{}, false, false)
+	if result.ErrorMessage == "" || provID != "" {
+		t.Errorf("expected the first registration to fail, got %+v %q", result, provID)
+	}
+	result, provID, _ = p.Register(context.Background(), provisioner.ManagementAccessData{}, false, false)
+	if result.ErrorMessage != "" || provID != "host" {
+		t.Errorf("expected the second registration to succeed, got %+v %q", result, provID)
+	}
+
+	result, _ = p.PowerOn(context.Background(), false)
+	if !result.Dirty || result.RequeueAfter != 5*time.Second {
+		t.Errorf("expected powering on to be in progress, got %+v", result)
+	}
+	now = now.Add(27 * time.Second)
+	result, _ = p.PowerOn(context.Background(), false)
+	if !result.Dirty || result.RequeueAfter != 3*time.Second {
+		t.Errorf("expected powering on to complete in 3s, got %+v", result)
+	}
+	if state, _ := p.UpdateHardwareState(context.Background()); state.PoweredOn == nil || *state.PoweredOn {
+		t.Errorf("expected the host to be powered off, got %+v", state.PoweredOn)
+	}
+	now = now.Add(3 * time.Second)
+	result, _ = p.PowerOn(context.Background(), false)
+	if result.Dirty {
+		t.Errorf("expected powering on to be done, got %+v", result)
+	}
+	if state, _ := p.UpdateHardwareState(context.Background()); state.PoweredOn == nil || !*state.PoweredOn {
+		t.Errorf("expected the host to be powered on, got %+v", state.PoweredOn)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The demo sidecar serves the demo provisioner over gRPC on a unix socket,
+// for use with PROVISIONER_PLUGIN_ADDRESS=unix://<socket>.
+package main
+
+import (
+	"flag"
+	"net"
+	"os"
+
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/demo"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func main() {
+	var socket string
+	flag.StringVar(&socket, "socket", "/run/provisioner/provisioner.sock",
+		"Path of the unix socket to serve the provisioner on.")
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New())
+	log := ctrl.Log.WithName("demo-sidecar")
+
+	// A socket left over by a previous run would make Listen fail
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		log.Error(err, "cannot remove stale socket", "socket", socket)
+		os.Exit(1)
+	}
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		log.Error(err, "cannot listen", "socket", socket)
+		os.Exit(1)
+	}
+
+	// The plugin configuration has no Kubernetes clients, the scenarios
+	// are read with the credentials of the sidecar.
+	var reader client.Reader
+	if restConfig, err := ctrl.GetConfig(); err == nil {
+		reader, err = client.New(restConfig, client.Options{})
+		if err != nil {
+			log.Error(err, "cannot create a Kubernetes client")
+			os.Exit(1)
+		}
+	} else {
+		log.Info("no Kubernetes configuration, scenarios in ConfigMaps cannot be read", "error", err.Error())
+	}
+
+	server := grpcplugin.NewServer("demo", func(_ provisioner.PluginConfig) (provisioner.Factory, error) {
+		return &demo.Demo{Client: reader}, nil
+	}, log)
+	log.Info("serving the demo provisioner", "socket", socket)
+	if err := server.Serve(lis); err != nil {
+		log.Error(err, "server failed")
+		os.Exit(1)
+	}
+}
@@ -2,6 +2,7 @@ package fixture
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -105,6 +106,15 @@ type Fixture struct {
 	PowerFailed bool
 
 	Health string
+
+	// capabilities the provisioner reports as not supported
+	Unsupported []provisioner.Capability
+
+	// the BMC password set by ChangeBMCPassword, any password is accepted
+	// while it is empty
+	BMCPassword string
+	// error returned by ChangeBMCPassword
+	BMCPasswordChangeError error
 }
 
 // NewProvisioner returns a new Fixture Provisioner.
@@ -441,3 +451,25 @@ func (p *fixtureProvisioner) GetHealth(_ context.Context) string {
 	}
 	return p.state.Health
 }
+
+func (p *fixtureProvisioner) Capabilities(_ context.Context) provisioner.Capabilities {
+	capabilities := provisioner.Capabilities{Provisioner: "fixture"}
+	for _, capability := range provisioner.AllCapabilities {
+		if p.state == nil || !slices.Contains(p.state.Unsupported, capability) {
+			capabilities.Supported = append(capabilities.Supported, capability)
+		}
+	}
+	return capabilities
+}
+
+func (p *fixtureProvisioner) ChangeBMCPassword(_ context.Context, _ bmc.Credentials, newPassword string) error {
+	if p.state.BMCPasswordChangeError != nil {
+		return p.state.BMCPasswordChangeError
+	}
+	p.state.BMCPassword = newPassword
+	return nil
+}
+
+func (p *fixtureProvisioner) BMCAcceptsCredentials(_ context.Context, creds bmc.Credentials) (bool, error) {
+	return p.state.BMCPassword == "" || creds.Password == p.state.BMCPassword, nil
+}
@@ -0,0 +1,462 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin/api/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// configureTimeout bounds the Configure call made when the factory is built.
+const configureTimeout = 30 * time.Second
+
+// Plugin is a provisioner plugin running in another process, usually a
+// sidecar container, and reached over gRPC.
+type Plugin struct {
+	address string
+	name    string
+	conn    *grpc.ClientConn
+	client  v1alpha1.ProvisionerClient
+}
+
+// CheckAddress rejects plugin addresses other than unix sockets. The
+// connection to the plugin is not encrypted and carries the BMC credentials
+// of the hosts, so it must not leave the pod.
+func CheckAddress(address string) error {
+	if !strings.HasPrefix(address, "unix://") {
+		return fmt.Errorf("plugin address %q is not a unix:// socket", address)
+	}
+	return nil
+}
+
+// Dial connects to the plugin at address, waiting for it to come up until ctx
+// is done, and rejects a protocol version or PluginName mismatch. The
+// connection is not encrypted, callers check addresses coming from the
+// configuration with CheckAddress.
+func Dial(ctx context.Context, address, expectedName string, opts ...grpc.DialOption) (*Plugin, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin %s: %w", address, err)
+	}
+
+	client := v1alpha1.NewProvisionerClient(conn)
+	resp, err := client.Handshake(ctx, &v1alpha1.HandshakeRequest{ProtocolVersion: ProtocolVersion}, grpc.WaitForReady(true))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("plugin %s: handshake failed: %w", address, err)
+	}
+
+	if resp.GetProtocolVersion() != ProtocolVersion {
+		conn.Close()
+		return nil, fmt.Errorf("plugin %s: protocol version %d is not supported, expected %d",
+			address, resp.GetProtocolVersion(), ProtocolVersion)
+	}
+
+	if expectedName != "" && resp.GetName() != expectedName {
+		conn.Close()
+		return nil, fmt.Errorf("plugin %s: PluginName() returned %q, expected %q", address, resp.GetName(), expectedName)
+	}
+
+	return &Plugin{address: address, name: resp.GetName(), conn: conn, client: client}, nil
+}
+
+// Address returns the address of the plugin.
+func (p *Plugin) Address() string { return p.address }
+
+// Name returns the plugin's self-reported name.
+func (p *Plugin) Name() string { return p.name }
+
+// Close closes the connection to the plugin.
+func (p *Plugin) Close() error { return p.conn.Close() }
+
+// HostConfigure yields zero HostRequirements, the plugin has its own scheme
+// and cache in its process.
+func (p *Plugin) HostConfigure(_ provisioner.HostConfigureInput) (provisioner.HostRequirements, error) {
+	return provisioner.HostRequirements{}, nil
+}
+
+// NewFactory passes the features and provisioner namespace of config to the
+// plugin and returns a factory of provisioners calling it. The Kubernetes
+// clients of config stay in the host, the plugin builds its own.
+func (p *Plugin) NewFactory(config provisioner.PluginConfig) (provisioner.Factory, error) {
+	features := make([]string, 0, len(config.Features))
+	for _, feature := range config.Features {
+		features = append(features, string(feature))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), configureTimeout)
+	defer cancel()
+	_, err := p.client.Configure(ctx, &v1alpha1.ConfigureRequest{
+		Features:             features,
+		ProvisionerNamespace: config.ProvisionerNamespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: configure failed: %w", p.address, err)
+	}
+
+	return &factory{client: p.client, name: p.name, log: config.Logger}, nil
+}
+
+type factory struct {
+	client v1alpha1.ProvisionerClient
+	name   string
+	log    logr.Logger
+}
+
+// NewProvisioner checks that the plugin can create a provisioner for the
+// host, so that provisioner.ErrNotReady is returned here as with in-process
+// plugins, and gets the shard of the host from it.
+func (f *factory) NewProvisioner(ctx context.Context, hostData provisioner.HostData, publish provisioner.EventPublisher) (provisioner.Provisioner, error) {
+	host, err := encodeHostData(hostData)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &remoteProvisioner{
+		client:  f.client,
+		name:    f.name,
+		host:    host,
+		publish: publish,
+		log:     f.log.WithValues("host", hostData.ObjectMeta.Name),
+	}
+	resp, err := f.client.NewProvisioner(ctx, p.hostRequest())
+	if _, err := p.finish(resp.GetOutcome(), err); err != nil {
+		return nil, err
+	}
+	p.shard = resp.GetShard()
+	return p, nil
+}
+
+// remoteProvisioner implements provisioner.Provisioner, as well as
+// provisioner.Sharded and provisioner.CredentialsRotator, by calling the
+// plugin.
+type remoteProvisioner struct {
+	client  v1alpha1.ProvisionerClient
+	name    string
+	host    *v1alpha1.HostData
+	shard   string
+	publish provisioner.EventPublisher
+	log     logr.Logger
+}
+
+func (p *remoteProvisioner) hostRequest() *v1alpha1.HostRequest {
+	return &v1alpha1.HostRequest{Host: p.host}
+}
+
+// finish publishes the events of the outcome of a call to the plugin again
+// on the host, and returns the result and error of the call.
+func (p *remoteProvisioner) finish(outcome *v1alpha1.Outcome, err error) (provisioner.Result, error) {
+	if err != nil {
+		return provisioner.Result{}, fmt.Errorf("provisioner plugin call failed: %w", err)
+	}
+
+	if p.publish != nil {
+		for _, event := range outcome.GetEvents() {
+			p.publish(event.GetReason(), event.GetMessage())
+		}
+	}
+
+	result := decodeResult(outcome.GetResult())
+	if outcome.GetError() != nil {
+		return result, decodeError(outcome.GetError())
+	}
+	return result, nil
+}
+
+func encodeFailed(err error) error {
+	return fmt.Errorf("failed to encode the arguments of the plugin call: %w", err)
+}
+
+func decodeFailed(err error) error {
+	return fmt.Errorf("failed to decode the values returned by the plugin: %w", err)
+}
+
+func (p *remoteProvisioner) Register(ctx context.Context, data provisioner.ManagementAccessData, credentialsChanged, restartOnFailure bool) (provisioner.Result, string, error) {
+	codec := &objectCodec{}
+	req := &v1alpha1.RegisterRequest{
+		Host:               p.host,
+		Data:               encodeManagementAccessData(codec, data),
+		CredentialsChanged: credentialsChanged,
+		RestartOnFailure:   restartOnFailure,
+	}
+	if codec.err != nil {
+		return provisioner.Result{}, "", encodeFailed(codec.err)
+	}
+	resp, err := p.client.Register(ctx, req)
+	result, err := p.finish(resp.GetOutcome(), err)
+	return result, resp.GetProvisionerId(), err
+}
+
+func (p *remoteProvisioner) PreprovisioningImageFormats(ctx context.Context) ([]metal3api.ImageFormat, error) {
+	resp, err := p.client.PreprovisioningImageFormats(ctx, p.hostRequest())
+	_, err = p.finish(resp.GetOutcome(), err)
+	return decodeImageFormats(resp.GetFormats()), err
+}
+
+func (p *remoteProvisioner) InspectHardware(ctx context.Context, data provisioner.InspectData, restartOnFailure, refresh, forceReboot bool) (provisioner.Result, bool, *metal3api.HardwareDetails, error) {
+	resp, err := p.client.InspectHardware(ctx, &v1alpha1.InspectHardwareRequest{
+		Host:             p.host,
+		Data:             encodeInspectData(data),
+		RestartOnFailure: restartOnFailure,
+		Refresh:          refresh,
+		ForceReboot:      forceReboot,
+	})
+	result, err := p.finish(resp.GetOutcome(), err)
+	codec := &objectCodec{}
+	details := decodeObject[metal3api.HardwareDetails](codec, resp.GetDetails())
+	if codec.err != nil {
+		return result, false, nil, decodeFailed(codec.err)
+	}
+	return result, resp.GetStarted(), details, err
+}
+
+func (p *remoteProvisioner) UpdateHardwareState(ctx context.Context) (provisioner.HardwareState, error) {
+	resp, err := p.client.UpdateHardwareState(ctx, p.hostRequest())
+	_, err = p.finish(resp.GetOutcome(), err)
+	return decodePowerState(resp.GetPowerState()), err
+}
+
+func (p *remoteProvisioner) Adopt(ctx context.Context, data provisioner.AdoptData, restartOnFailure bool) (provisioner.Result, error) {
+	return p.finish(p.client.Adopt(ctx, &v1alpha1.AdoptRequest{
+		Host:             p.host,
+		State:            string(data.State),
+		RestartOnFailure: restartOnFailure,
+	}))
+}
+
+func (p *remoteProvisioner) Prepare(ctx context.Context, data provisioner.PrepareData, unprepared, restartOnFailure bool) (provisioner.Result, bool, error) {
+	codec := &objectCodec{}
+	req := &v1alpha1.PrepareRequest{
+		Host:             p.host,
+		Data:             encodePrepareData(codec, data),
+		Unprepared:       unprepared,
+		RestartOnFailure: restartOnFailure,
+	}
+	if codec.err != nil {
+		return provisioner.Result{}, false, encodeFailed(codec.err)
+	}
+	resp, err := p.client.Prepare(ctx, req)
+	result, err := p.finish(resp.GetOutcome(), err)
+	return result, resp.GetStarted(), err
+}
+
+func (p *remoteProvisioner) Service(ctx context.Context, data provisioner.ServicingData, unprepared, restartOnFailure bool) (provisioner.Result, bool, error) {
+	codec := &objectCodec{}
+	req := &v1alpha1.ServiceRequest{
+		Host:             p.host,
+		Data:             encodeServicingData(codec, data),
+		Unprepared:       unprepared,
+		RestartOnFailure: restartOnFailure,
+	}
+	if codec.err != nil {
+		return provisioner.Result{}, false, encodeFailed(codec.err)
+	}
+	resp, err := p.client.Service(ctx, req)
+	result, err := p.finish(resp.GetOutcome(), err)
+	return result, resp.GetStarted(), err
+}
+
+func (p *remoteProvisioner) Provision(ctx context.Context, data provisioner.ProvisionData, forceReboot bool) (provisioner.Result, error) {
+	codec := &objectCodec{}
+	req := &v1alpha1.ProvisionRequest{Host: p.host, Data: encodeProvisionData(codec, data), ForceReboot: forceReboot}
+	if codec.err != nil {
+		return provisioner.Result{}, encodeFailed(codec.err)
+	}
+	if data.HostConfig != nil {
+		hostConfig, err := getHostConfig(ctx, data.HostConfig)
+		if err != nil {
+			return provisioner.Result{}, fmt.Errorf("failed to get the host configuration data: %w", err)
+		}
+		req.Data.HostConfig = hostConfig
+	}
+	return p.finish(p.client.Provision(ctx, req))
+}
+
+func (p *remoteProvisioner) Deprovision(ctx context.Context, restartOnFailure bool, automatedCleaningMode metal3api.AutomatedCleaningMode) (provisioner.Result, error) {
+	return p.finish(p.client.Deprovision(ctx, &v1alpha1.DeprovisionRequest{
+		Host:                  p.host,
+		RestartOnFailure:      restartOnFailure,
+		AutomatedCleaningMode: string(automatedCleaningMode),
+	}))
+}
+
+func (p *remoteProvisioner) Delete(ctx context.Context) (provisioner.Result, error) {
+	return p.finish(p.client.Delete(ctx, p.hostRequest()))
+}
+
+func (p *remoteProvisioner) Detach(ctx context.Context, force bool) (provisioner.Result, error) {
+	return p.finish(p.client.Detach(ctx, &v1alpha1.ForceRequest{Host: p.host, Force: force}))
+}
+
+func (p *remoteProvisioner) PowerOn(ctx context.Context, force bool) (provisioner.Result, error) {
+	return p.finish(p.client.PowerOn(ctx, &v1alpha1.ForceRequest{Host: p.host, Force: force}))
+}
+
+func (p *remoteProvisioner) PowerOff(ctx context.Context, rebootMode metal3api.RebootMode, force bool, automatedCleaningMode metal3api.AutomatedCleaningMode) (provisioner.Result, error) {
+	return p.finish(p.client.PowerOff(ctx, &v1alpha1.PowerOffRequest{
+		Host:                  p.host,
+		RebootMode:            string(rebootMode),
+		Force:                 force,
+		AutomatedCleaningMode: string(automatedCleaningMode),
+	}))
+}
+
+func (p *remoteProvisioner) HasCapacity(ctx context.Context) (bool, error) {
+	resp, err := p.client.HasCapacity(ctx, p.hostRequest())
+	_, err = p.finish(resp.GetOutcome(), err)
+	return resp.GetValue(), err
+}
+
+func (p *remoteProvisioner) GetFirmwareSettings(ctx context.Context, includeSchema bool) (metal3api.SettingsMap, map[string]metal3api.SettingSchema, error) {
+	resp, err := p.client.GetFirmwareSettings(ctx, &v1alpha1.GetFirmwareSettingsRequest{Host: p.host, IncludeSchema: includeSchema})
+	_, err = p.finish(resp.GetOutcome(), err)
+	codec := &objectCodec{}
+	schema := decodeSettingSchemas(codec, resp.GetSchema())
+	if codec.err != nil {
+		return nil, nil, decodeFailed(codec.err)
+	}
+	return resp.GetSettings(), schema, err
+}
+
+func (p *remoteProvisioner) AddBMCEventSubscriptionForNode(ctx context.Context, subscription *metal3api.BMCEventSubscription, httpHeaders provisioner.HTTPHeaders) (provisioner.Result, error) {
+	codec := &objectCodec{}
+	req := &v1alpha1.BMCEventSubscriptionRequest{
+		Host:         p.host,
+		Subscription: encodeObject(codec, subscription),
+		HttpHeaders:  encodeHTTPHeaders(httpHeaders),
+	}
+	if codec.err != nil {
+		return provisioner.Result{}, encodeFailed(codec.err)
+	}
+	resp, err := p.client.AddBMCEventSubscriptionForNode(ctx, req)
+	result, err := p.finish(resp.GetOutcome(), err)
+	// The provisioner records the ID of the new subscription in its status
+	subscription.Status = metal3api.BMCEventSubscriptionStatus{
+		SubscriptionID: resp.GetSubscriptionId(),
+		Error:          resp.GetError(),
+	}
+	return result, err
+}
+
+func (p *remoteProvisioner) RemoveBMCEventSubscriptionForNode(ctx context.Context, subscription metal3api.BMCEventSubscription) (provisioner.Result, error) {
+	codec := &objectCodec{}
+	req := &v1alpha1.BMCEventSubscriptionRequest{Host: p.host, Subscription: encodeObject(codec, &subscription)}
+	if codec.err != nil {
+		return provisioner.Result{}, encodeFailed(codec.err)
+	}
+	return p.finish(p.client.RemoveBMCEventSubscriptionForNode(ctx, req))
+}
+
+func (p *remoteProvisioner) GetFirmwareComponents(ctx context.Context) ([]metal3api.FirmwareComponentStatus, error) {
+	resp, err := p.client.GetFirmwareComponents(ctx, p.hostRequest())
+	_, err = p.finish(resp.GetOutcome(), err)
+	return decodeFirmwareComponents(resp.GetComponents()), err
+}
+
+func (p *remoteProvisioner) GetDataImageStatus(ctx context.Context) (bool, error) {
+	resp, err := p.client.GetDataImageStatus(ctx, p.hostRequest())
+	_, err = p.finish(resp.GetOutcome(), err)
+	return resp.GetValue(), err
+}
+
+func (p *remoteProvisioner) AttachDataImage(ctx context.Context, url string) error {
+	_, err := p.finish(p.client.AttachDataImage(ctx, &v1alpha1.AttachDataImageRequest{Host: p.host, Url: url}))
+	return err
+}
+
+func (p *remoteProvisioner) DetachDataImage(ctx context.Context) error {
+	_, err := p.finish(p.client.DetachDataImage(ctx, p.hostRequest()))
+	return err
+}
+
+func (p *remoteProvisioner) HasPowerFailure(ctx context.Context) bool {
+	resp, err := p.client.HasPowerFailure(ctx, p.hostRequest())
+	if _, err := p.finish(resp.GetOutcome(), err); err != nil {
+		p.log.Error(err, "failed to get the power failure state from the plugin")
+		return false
+	}
+	return resp.GetValue()
+}
+
+func (p *remoteProvisioner) GetHealth(ctx context.Context) string {
+	resp, err := p.client.GetHealth(ctx, p.hostRequest())
+	if _, err := p.finish(resp.GetOutcome(), err); err != nil {
+		p.log.Error(err, "failed to get the health from the plugin")
+		return ""
+	}
+	return resp.GetHealth()
+}
+
+// Capabilities reports the capabilities of the provisioner of the plugin
+// under the name of the plugin. When they cannot be retrieved, everything is
+// reported as supported and the calls themselves fail.
+func (p *remoteProvisioner) Capabilities(ctx context.Context) provisioner.Capabilities {
+	resp, err := p.client.Capabilities(ctx, p.hostRequest())
+	supported := resp.GetSupported()
+	if _, err := p.finish(resp.GetOutcome(), err); err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			p.log.Error(err, "failed to get the capabilities from the plugin")
+		}
+		supported = encodeCapabilities(provisioner.AllCapabilities)
+	}
+	return provisioner.Capabilities{Provisioner: p.name, Supported: decodeCapabilities(supported)}
+}
+
+// Shard returns the shard reported by the plugin when the provisioner was
+// created.
+func (p *remoteProvisioner) Shard() string {
+	return p.shard
+}
+
+// rotationCallFailed reports plugins predating the credentials rotation
+// calls as not supporting it.
+func rotationCallFailed(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("credentials rotation is %w by the plugin", provisioner.ErrNotSupported)
+	}
+	return err
+}
+
+func (p *remoteProvisioner) ChangeBMCPassword(ctx context.Context, current bmc.Credentials, newPassword string) error {
+	_, err := p.finish(p.client.ChangeBMCPassword(ctx, &v1alpha1.ChangeBMCPasswordRequest{
+		Host:        p.host,
+		Current:     encodeCredentials(current),
+		NewPassword: newPassword,
+	}))
+	return rotationCallFailed(err)
+}
+
+func (p *remoteProvisioner) BMCAcceptsCredentials(ctx context.Context, creds bmc.Credentials) (bool, error) {
+	resp, err := p.client.BMCAcceptsCredentials(ctx, &v1alpha1.BMCAcceptsCredentialsRequest{
+		Host:        p.host,
+		Credentials: encodeCredentials(creds),
+	})
+	_, err = p.finish(resp.GetOutcome(), err)
+	return resp.GetValue(), rotationCallFailed(err)
+}
@@ -0,0 +1,342 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// startPlugin serves the plugin on an in-memory listener and returns the
+// dial option reaching it.
+func startPlugin(t *testing.T, name string, newFactory func(provisioner.PluginConfig) (provisioner.Factory, error)) grpc.DialOption {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpcplugin.NewServer(name, newFactory, logr.Discard())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+}
+
+func dial(t *testing.T, expectedName string, dialer grpc.DialOption) (*grpcplugin.Plugin, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	p, err := grpcplugin.Dial(ctx, "passthrough:///plugin", expectedName, dialer)
+	if err == nil {
+		t.Cleanup(func() { _ = p.Close() })
+	}
+	return p, err
+}
+
+func fixtureFactory(state *fixture.Fixture) func(provisioner.PluginConfig) (provisioner.Factory, error) {
+	return func(provisioner.PluginConfig) (provisioner.Factory, error) {
+		return state, nil
+	}
+}
+
+func TestDialNameMismatch(t *testing.T) {
+	dialer := startPlugin(t, "fixture", fixtureFactory(&fixture.Fixture{}))
+
+	_, err := dial(t, "other", dialer)
+	if err == nil {
+		t.Fatal("expected error for a name mismatch, got nil")
+	}
+	if !strings.Contains(err.Error(), `expected "other"`) {
+		t.Errorf("error should mention the expected name, got: %v", err)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	var received provisioner.PluginConfig
+	dialer := startPlugin(t, "fixture", func(config provisioner.PluginConfig) (provisioner.Factory, error) {
+		received = config
+		return &fixture.Fixture{}, nil
+	})
+
+	p, err := dial(t, "fixture", dialer)
+	if err != nil {
+		t.Fatalf("Dial returned unexpected error: %v", err)
+	}
+	if p.Name() != "fixture" {
+		t.Errorf("Name() = %q, want %q", p.Name(), "fixture")
+	}
+
+	requirements, err := p.HostConfigure(provisioner.HostConfigureInput{})
+	if err != nil || requirements.AddToScheme != nil || requirements.CacheByObject != nil {
+		t.Errorf("HostConfigure should yield zero requirements, got %+v, %v", requirements, err)
+	}
+
+	_, err = p.NewFactory(provisioner.PluginConfig{
+		Features:             []provisioner.HostFeature{provisioner.FeaturePreprovisioningImage},
+		ProvisionerNamespace: "metal3",
+	})
+	if err != nil {
+		t.Fatalf("NewFactory returned unexpected error: %v", err)
+	}
+	if !received.HasFeature(provisioner.FeaturePreprovisioningImage) {
+		t.Errorf("plugin did not receive the features of the host: %v", received.Features)
+	}
+	if received.ProvisionerNamespace != "metal3" {
+		t.Errorf("ProvisionerNamespace = %q, want %q", received.ProvisionerNamespace, "metal3")
+	}
+}
+
+func newFactory(t *testing.T, state *fixture.Fixture) provisioner.Factory {
+	t.Helper()
+	p, err := dial(t, "fixture", startPlugin(t, "fixture", fixtureFactory(state)))
+	if err != nil {
+		t.Fatalf("Dial returned unexpected error: %v", err)
+	}
+	factory, err := p.NewFactory(provisioner.PluginConfig{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("NewFactory returned unexpected error: %v", err)
+	}
+	return factory
+}
+
+type event struct {
+	reason, message string
+}
+
+func TestRemoteProvisioner(t *testing.T) {
+	ctx := context.Background()
+	state := &fixture.Fixture{}
+	factory := newFactory(t, state)
+
+	var events []event
+	hostData := provisioner.HostData{ObjectMeta: metav1.ObjectMeta{Name: "host", Namespace: "metal3"}}
+	prov, err := factory.NewProvisioner(ctx, hostData, func(reason, message string) {
+		events = append(events, event{reason, message})
+	})
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+
+	result, provID, err := prov.Register(ctx, provisioner.ManagementAccessData{}, false, false)
+	if err != nil {
+		t.Fatalf("Register returned unexpected error: %v", err)
+	}
+	if provID != "temporary-fake-id" {
+		t.Errorf("provID = %q, want %q", provID, "temporary-fake-id")
+	}
+	if !result.Dirty || result.RequeueAfter != fixture.DefaultRequeueSecs*time.Second {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(events) != 1 || events[0].reason != "Registered" {
+		t.Errorf("expected the Registered event to be published, got %v", events)
+	}
+
+	if _, err := prov.PowerOn(ctx, false); err != nil {
+		t.Fatalf("PowerOn returned unexpected error: %v", err)
+	}
+	if !state.PoweredOn {
+		t.Error("the plugin did not power on the host")
+	}
+
+	_, err = prov.Provision(ctx, provisioner.ProvisionData{
+		Image:      metal3api.Image{URL: "http://image"},
+		HostConfig: fixture.NewHostConfigData("user", "network", "meta"),
+	}, false)
+	if err != nil {
+		t.Fatalf("Provision returned unexpected error: %v", err)
+	}
+	if events[len(events)-1].reason != "ProvisioningComplete" {
+		t.Errorf("expected the ProvisioningComplete event to be published, got %v", events)
+	}
+
+	state.Health = provisioner.HealthWarning
+	if health := prov.GetHealth(ctx); health != provisioner.HealthWarning {
+		t.Errorf("GetHealth() = %q, want %q", health, provisioner.HealthWarning)
+	}
+}
+
+func TestRemoteProvisionerCapabilities(t *testing.T) {
+	ctx := context.Background()
+	factory := newFactory(t, &fixture.Fixture{Unsupported: []provisioner.Capability{provisioner.CapabilityHealth}})
+
+	prov, err := factory.NewProvisioner(ctx, provisioner.HostData{}, nil)
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+
+	if err := provisioner.CheckCapability(ctx, prov, provisioner.CapabilityDataImage); err != nil {
+		t.Errorf("DataImage should be supported, got %v", err)
+	}
+	err = provisioner.CheckCapability(ctx, prov, provisioner.CapabilityHealth)
+	if !errors.Is(err, provisioner.ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+	if err.Error() != "Health is not supported by provisioner fixture" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestRemoteProvisionerNotReady(t *testing.T) {
+	factory := newFactory(t, &fixture.Fixture{BecomeReadyCounter: 2})
+
+	_, err := factory.NewProvisioner(context.Background(), provisioner.HostData{}, nil)
+	if !errors.Is(err, provisioner.ErrNotReady) {
+		t.Errorf("expected ErrNotReady, got %v", err)
+	}
+}
+
+// busyProvisioner only implements the calls used by the tests.
+type busyProvisioner struct {
+	provisioner.Provisioner
+}
+
+func (busyProvisioner) GetDataImageStatus(context.Context) (bool, error) {
+	return false, provisioner.ErrNodeIsBusy
+}
+
+type busyFactory struct{}
+
+func (busyFactory) NewProvisioner(context.Context, provisioner.HostData, provisioner.EventPublisher) (provisioner.Provisioner, error) {
+	return busyProvisioner{}, nil
+}
+
+func TestRemoteProvisionerErrors(t *testing.T) {
+	dialer := startPlugin(t, "busy", func(provisioner.PluginConfig) (provisioner.Factory, error) {
+		return busyFactory{}, nil
+	})
+	p, err := dial(t, "busy", dialer)
+	if err != nil {
+		t.Fatalf("Dial returned unexpected error: %v", err)
+	}
+	factory, err := p.NewFactory(provisioner.PluginConfig{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("NewFactory returned unexpected error: %v", err)
+	}
+	prov, err := factory.NewProvisioner(context.Background(), provisioner.HostData{}, nil)
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+
+	_, err = prov.GetDataImageStatus(context.Background())
+	if !errors.Is(err, provisioner.ErrNodeIsBusy) {
+		t.Errorf("expected ErrNodeIsBusy, got %v", err)
+	}
+	if err.Error() != provisioner.ErrNodeIsBusy.Error() {
+		t.Errorf("error message = %q, want %q", err.Error(), provisioner.ErrNodeIsBusy.Error())
+	}
+}
+
+func TestRemoteProvisionerCredentialsRotation(t *testing.T) {
+	ctx := context.Background()
+	state := &fixture.Fixture{BMCPassword: "old"}
+	prov, err := newFactory(t, state).NewProvisioner(ctx, provisioner.HostData{}, nil)
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+	rotator, ok := prov.(provisioner.CredentialsRotator)
+	if !ok {
+		t.Fatal("the provisioner does not implement CredentialsRotator")
+	}
+
+	creds := bmc.Credentials{Username: "admin", Password: "old"}
+	if err := rotator.ChangeBMCPassword(ctx, creds, "new"); err != nil {
+		t.Fatalf("ChangeBMCPassword returned unexpected error: %v", err)
+	}
+	if state.BMCPassword != "new" {
+		t.Errorf("BMCPassword = %q, want %q", state.BMCPassword, "new")
+	}
+
+	accepted, err := rotator.BMCAcceptsCredentials(ctx, creds)
+	if err != nil {
+		t.Fatalf("BMCAcceptsCredentials returned unexpected error: %v", err)
+	}
+	if accepted {
+		t.Error("the previous password should not be accepted any more")
+	}
+}
+
+// shardedProvisioner only implements the calls used by the tests.
+type shardedProvisioner struct {
+	provisioner.Provisioner
+}
+
+func (shardedProvisioner) Shard() string { return "east" }
+
+type shardedFactory struct{}
+
+func (shardedFactory) NewProvisioner(context.Context, provisioner.HostData, provisioner.EventPublisher) (provisioner.Provisioner, error) {
+	return shardedProvisioner{}, nil
+}
+
+func TestRemoteProvisionerOptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	dialer := startPlugin(t, "sharded", func(provisioner.PluginConfig) (provisioner.Factory, error) {
+		return shardedFactory{}, nil
+	})
+	p, err := dial(t, "sharded", dialer)
+	if err != nil {
+		t.Fatalf("Dial returned unexpected error: %v", err)
+	}
+	factory, err := p.NewFactory(provisioner.PluginConfig{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("NewFactory returned unexpected error: %v", err)
+	}
+	prov, err := factory.NewProvisioner(ctx, provisioner.HostData{}, nil)
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+
+	sharded, ok := prov.(provisioner.Sharded)
+	if !ok {
+		t.Fatal("the provisioner does not implement Sharded")
+	}
+	if shard := sharded.Shard(); shard != "east" {
+		t.Errorf("Shard() = %q, want %q", shard, "east")
+	}
+
+	rotator, ok := prov.(provisioner.CredentialsRotator)
+	if !ok {
+		t.Fatal("the provisioner does not implement CredentialsRotator")
+	}
+	err = rotator.ChangeBMCPassword(ctx, bmc.Credentials{}, "new")
+	if !errors.Is(err, provisioner.ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	if err := grpcplugin.CheckAddress("unix:///run/provisioner/provisioner.sock"); err != nil {
+		t.Errorf("unexpected error for a unix socket: %v", err)
+	}
+	for _, address := range []string{"localhost:50051", "dns:///plugin:50051", "/run/provisioner/provisioner.sock"} {
+		if err := grpcplugin.CheckAddress(address); err == nil {
+			t.Errorf("expected %q to be rejected", address)
+		}
+	}
+}
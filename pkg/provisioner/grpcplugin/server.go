@@ -0,0 +1,431 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin/api/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errRotationNotSupported is returned by the credentials rotation calls when
+// the provisioner of the plugin does not implement
+// provisioner.CredentialsRotator.
+var errRotationNotSupported = fmt.Errorf("credentials rotation is %w by the plugin", provisioner.ErrNotSupported)
+
+// NewServer returns a gRPC server serving the provisioner plugin name, whose
+// factory is built by newFactory once the host has sent its configuration.
+// The PluginConfig passed to newFactory has no Kubernetes clients.
+func NewServer(name string, newFactory func(provisioner.PluginConfig) (provisioner.Factory, error), logger logr.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	v1alpha1.RegisterProvisionerServer(s, &server{
+		name:       name,
+		newFactory: newFactory,
+		log:        logger,
+	})
+	return s
+}
+
+type server struct {
+	v1alpha1.UnimplementedProvisionerServer
+
+	name       string
+	newFactory func(provisioner.PluginConfig) (provisioner.Factory, error)
+	log        logr.Logger
+
+	lock    sync.RWMutex
+	factory provisioner.Factory
+}
+
+func (s *server) Handshake(_ context.Context, req *v1alpha1.HandshakeRequest) (*v1alpha1.HandshakeResponse, error) {
+	if req.GetProtocolVersion() != ProtocolVersion {
+		s.log.Info("host speaks another protocol version",
+			"hostVersion", req.GetProtocolVersion(), "pluginVersion", ProtocolVersion)
+	}
+	return &v1alpha1.HandshakeResponse{Name: s.name, ProtocolVersion: ProtocolVersion}, nil
+}
+
+// Configure builds the factory, again if the host restarted.
+func (s *server) Configure(_ context.Context, req *v1alpha1.ConfigureRequest) (*v1alpha1.ConfigureResponse, error) {
+	features := make([]provisioner.HostFeature, 0, len(req.GetFeatures()))
+	for _, feature := range req.GetFeatures() {
+		features = append(features, provisioner.HostFeature(feature))
+	}
+
+	factory, err := s.newFactory(provisioner.PluginConfig{
+		Logger:               s.log,
+		Features:             features,
+		ProvisionerNamespace: req.GetProvisionerNamespace(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot initialize provisioner plugin: %s", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.factory = factory
+	return &v1alpha1.ConfigureResponse{}, nil
+}
+
+func (s *server) getFactory() provisioner.Factory {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.factory
+}
+
+// call creates a provisioner for host and runs fn with it. Errors of the
+// provisioner are returned in the outcome, errors of the call itself as a
+// gRPC status.
+func (s *server) call(ctx context.Context, host *v1alpha1.HostData, fn func(provisioner.Provisioner) (provisioner.Result, error)) (*v1alpha1.Outcome, error) {
+	factory := s.getFactory()
+	if factory == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the plugin is not configured")
+	}
+
+	hostData, err := decodeHostData(host)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	outcome := &v1alpha1.Outcome{}
+	publish := func(reason, message string) {
+		outcome.Events = append(outcome.Events, &v1alpha1.Event{Reason: reason, Message: message})
+	}
+
+	prov, err := factory.NewProvisioner(ctx, hostData, publish)
+	if err != nil {
+		outcome.Error = encodeError(err)
+		return outcome, nil
+	}
+
+	result, err := fn(prov)
+	outcome.Result = encodeResult(result)
+	if err != nil {
+		outcome.Error = encodeError(err)
+	}
+	return outcome, nil
+}
+
+func invalidArguments(err error) error {
+	return status.Errorf(codes.InvalidArgument, "failed to decode the arguments: %s", err)
+}
+
+func encodingFailed(err error) error {
+	return status.Errorf(codes.Internal, "failed to encode the values: %s", err)
+}
+
+func (s *server) NewProvisioner(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.NewProvisionerResponse, error) {
+	resp := &v1alpha1.NewProvisionerResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		if sharded, ok := prov.(provisioner.Sharded); ok {
+			resp.Shard = sharded.Shard()
+		}
+		return provisioner.Result{}, nil
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) Register(ctx context.Context, req *v1alpha1.RegisterRequest) (*v1alpha1.RegisterResponse, error) {
+	codec := &objectCodec{}
+	data := decodeManagementAccessData(codec, req.GetData())
+	if codec.err != nil {
+		return nil, invalidArguments(codec.err)
+	}
+	resp := &v1alpha1.RegisterResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		result, provID, err := prov.Register(ctx, data, req.GetCredentialsChanged(), req.GetRestartOnFailure())
+		resp.ProvisionerId = provID
+		return result, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) PreprovisioningImageFormats(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.PreprovisioningImageFormatsResponse, error) {
+	resp := &v1alpha1.PreprovisioningImageFormatsResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		formats, err := prov.PreprovisioningImageFormats(ctx)
+		resp.Formats = encodeImageFormats(formats)
+		return provisioner.Result{}, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) InspectHardware(ctx context.Context, req *v1alpha1.InspectHardwareRequest) (*v1alpha1.InspectHardwareResponse, error) {
+	resp := &v1alpha1.InspectHardwareResponse{}
+	codec := &objectCodec{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		result, started, details, err := prov.InspectHardware(ctx, decodeInspectData(req.GetData()), req.GetRestartOnFailure(), req.GetRefresh(), req.GetForceReboot())
+		resp.Started = started
+		resp.Details = encodeObject(codec, details)
+		return result, err
+	})
+	if codec.err != nil {
+		return nil, encodingFailed(codec.err)
+	}
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) UpdateHardwareState(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.UpdateHardwareStateResponse, error) {
+	resp := &v1alpha1.UpdateHardwareStateResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		state, err := prov.UpdateHardwareState(ctx)
+		resp.PowerState = encodePowerState(state)
+		return provisioner.Result{}, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) Adopt(ctx context.Context, req *v1alpha1.AdoptRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		data := provisioner.AdoptData{State: metal3api.ProvisioningState(req.GetState())}
+		return prov.Adopt(ctx, data, req.GetRestartOnFailure())
+	})
+}
+
+func (s *server) Prepare(ctx context.Context, req *v1alpha1.PrepareRequest) (*v1alpha1.StartedResponse, error) {
+	codec := &objectCodec{}
+	data := decodePrepareData(codec, req.GetData())
+	if codec.err != nil {
+		return nil, invalidArguments(codec.err)
+	}
+	resp := &v1alpha1.StartedResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		result, started, err := prov.Prepare(ctx, data, req.GetUnprepared(), req.GetRestartOnFailure())
+		resp.Started = started
+		return result, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) Service(ctx context.Context, req *v1alpha1.ServiceRequest) (*v1alpha1.StartedResponse, error) {
+	codec := &objectCodec{}
+	data := decodeServicingData(codec, req.GetData())
+	if codec.err != nil {
+		return nil, invalidArguments(codec.err)
+	}
+	resp := &v1alpha1.StartedResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		result, started, err := prov.Service(ctx, data, req.GetUnprepared(), req.GetRestartOnFailure())
+		resp.Started = started
+		return result, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) Provision(ctx context.Context, req *v1alpha1.ProvisionRequest) (*v1alpha1.Outcome, error) {
+	codec := &objectCodec{}
+	data := decodeProvisionData(codec, req.GetData())
+	if codec.err != nil {
+		return nil, invalidArguments(codec.err)
+	}
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.Provision(ctx, data, req.GetForceReboot())
+	})
+}
+
+func (s *server) Deprovision(ctx context.Context, req *v1alpha1.DeprovisionRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.Deprovision(ctx, req.GetRestartOnFailure(), metal3api.AutomatedCleaningMode(req.GetAutomatedCleaningMode()))
+	})
+}
+
+func (s *server) Delete(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.Delete(ctx)
+	})
+}
+
+func (s *server) Detach(ctx context.Context, req *v1alpha1.ForceRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.Detach(ctx, req.GetForce())
+	})
+}
+
+func (s *server) PowerOn(ctx context.Context, req *v1alpha1.ForceRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.PowerOn(ctx, req.GetForce())
+	})
+}
+
+func (s *server) PowerOff(ctx context.Context, req *v1alpha1.PowerOffRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.PowerOff(ctx, metal3api.RebootMode(req.GetRebootMode()), req.GetForce(),
+			metal3api.AutomatedCleaningMode(req.GetAutomatedCleaningMode()))
+	})
+}
+
+func (s *server) HasCapacity(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.BoolResponse, error) {
+	resp := &v1alpha1.BoolResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		hasCapacity, err := prov.HasCapacity(ctx)
+		resp.Value = hasCapacity
+		return provisioner.Result{}, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) GetFirmwareSettings(ctx context.Context, req *v1alpha1.GetFirmwareSettingsRequest) (*v1alpha1.GetFirmwareSettingsResponse, error) {
+	resp := &v1alpha1.GetFirmwareSettingsResponse{}
+	codec := &objectCodec{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		settings, schema, err := prov.GetFirmwareSettings(ctx, req.GetIncludeSchema())
+		resp.Settings = settings
+		resp.Schema = encodeSettingSchemas(codec, schema)
+		return provisioner.Result{}, err
+	})
+	if codec.err != nil {
+		return nil, encodingFailed(codec.err)
+	}
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) AddBMCEventSubscriptionForNode(ctx context.Context, req *v1alpha1.BMCEventSubscriptionRequest) (*v1alpha1.AddBMCEventSubscriptionResponse, error) {
+	codec := &objectCodec{}
+	subscription := decodeValue[metal3api.BMCEventSubscription](codec, req.GetSubscription())
+	if codec.err != nil {
+		return nil, invalidArguments(codec.err)
+	}
+	resp := &v1alpha1.AddBMCEventSubscriptionResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		result, err := prov.AddBMCEventSubscriptionForNode(ctx, &subscription, decodeHTTPHeaders(req.GetHttpHeaders()))
+		resp.SubscriptionId = subscription.Status.SubscriptionID
+		resp.Error = subscription.Status.Error
+		return result, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) RemoveBMCEventSubscriptionForNode(ctx context.Context, req *v1alpha1.BMCEventSubscriptionRequest) (*v1alpha1.Outcome, error) {
+	codec := &objectCodec{}
+	subscription := decodeValue[metal3api.BMCEventSubscription](codec, req.GetSubscription())
+	if codec.err != nil {
+		return nil, invalidArguments(codec.err)
+	}
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return prov.RemoveBMCEventSubscriptionForNode(ctx, subscription)
+	})
+}
+
+func (s *server) GetFirmwareComponents(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.GetFirmwareComponentsResponse, error) {
+	resp := &v1alpha1.GetFirmwareComponentsResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		components, err := prov.GetFirmwareComponents(ctx)
+		resp.Components = encodeFirmwareComponents(components)
+		return provisioner.Result{}, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) GetDataImageStatus(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.BoolResponse, error) {
+	resp := &v1alpha1.BoolResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		attached, err := prov.GetDataImageStatus(ctx)
+		resp.Value = attached
+		return provisioner.Result{}, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) AttachDataImage(ctx context.Context, req *v1alpha1.AttachDataImageRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return provisioner.Result{}, prov.AttachDataImage(ctx, req.GetUrl())
+	})
+}
+
+func (s *server) DetachDataImage(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		return provisioner.Result{}, prov.DetachDataImage(ctx)
+	})
+}
+
+func (s *server) HasPowerFailure(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.BoolResponse, error) {
+	resp := &v1alpha1.BoolResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		resp.Value = prov.HasPowerFailure(ctx)
+		return provisioner.Result{}, nil
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) GetHealth(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.GetHealthResponse, error) {
+	resp := &v1alpha1.GetHealthResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		resp.Health = prov.GetHealth(ctx)
+		return provisioner.Result{}, nil
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) Capabilities(ctx context.Context, req *v1alpha1.HostRequest) (*v1alpha1.CapabilitiesResponse, error) {
+	resp := &v1alpha1.CapabilitiesResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		supported := provisioner.AllCapabilities
+		if reporter, ok := prov.(provisioner.CapabilityReporter); ok {
+			supported = reporter.Capabilities(ctx).Supported
+		}
+		resp.Supported = encodeCapabilities(supported)
+		return provisioner.Result{}, nil
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
+
+func (s *server) ChangeBMCPassword(ctx context.Context, req *v1alpha1.ChangeBMCPasswordRequest) (*v1alpha1.Outcome, error) {
+	return s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		rotator, ok := prov.(provisioner.CredentialsRotator)
+		if !ok {
+			return provisioner.Result{}, errRotationNotSupported
+		}
+		return provisioner.Result{}, rotator.ChangeBMCPassword(ctx, decodeCredentials(req.GetCurrent()), req.GetNewPassword())
+	})
+}
+
+func (s *server) BMCAcceptsCredentials(ctx context.Context, req *v1alpha1.BMCAcceptsCredentialsRequest) (*v1alpha1.BoolResponse, error) {
+	resp := &v1alpha1.BoolResponse{}
+	outcome, err := s.call(ctx, req.GetHost(), func(prov provisioner.Provisioner) (provisioner.Result, error) {
+		rotator, ok := prov.(provisioner.CredentialsRotator)
+		if !ok {
+			return provisioner.Result{}, errRotationNotSupported
+		}
+		accepted, err := rotator.BMCAcceptsCredentials(ctx, decodeCredentials(req.GetCredentials()))
+		resp.Value = accepted
+		return provisioner.Result{}, err
+	})
+	resp.Outcome = outcome
+	return resp, err
+}
@@ -0,0 +1,578 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1/profile"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/imageprovider"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProtocolVersion is the version of the protocol spoken between the host and
+// its plugins. It is bumped on any incompatible change to the service or its
+// messages.
+const ProtocolVersion uint32 = 1
+
+// The functions below copy the types of the provisioner package to and from
+// the messages of the service, so that changes to those do not change the
+// protocol by accident.
+
+// objectCodec encodes and decodes the objects of the metal3.io API carried by
+// the messages as JSON, keeping the first error.
+type objectCodec struct {
+	err error
+}
+
+func encodeObject[T any](c *objectCodec, object *T) []byte {
+	if object == nil || c.err != nil {
+		return nil
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		c.err = err
+		return nil
+	}
+	return data
+}
+
+func decodeObject[T any](c *objectCodec, data []byte) *T {
+	if len(data) == 0 || c.err != nil {
+		return nil
+	}
+	object := new(T)
+	if err := json.Unmarshal(data, object); err != nil {
+		c.err = err
+		return nil
+	}
+	return object
+}
+
+// decodeValue decodes an object that is not a pointer in the types of the
+// provisioner package, which is the zero value when unset.
+func decodeValue[T any](c *objectCodec, data []byte) T {
+	var zero T
+	if object := decodeObject[T](c, data); object != nil {
+		return *object
+	}
+	return zero
+}
+
+func encodeCredentials(creds bmc.Credentials) *v1alpha1.Credentials {
+	return &v1alpha1.Credentials{Username: creds.Username, Password: creds.Password}
+}
+
+func decodeCredentials(creds *v1alpha1.Credentials) bmc.Credentials {
+	return bmc.Credentials{Username: creds.GetUsername(), Password: creds.GetPassword()}
+}
+
+func encodeCapabilities(capabilities []provisioner.Capability) []string {
+	encoded := make([]string, 0, len(capabilities))
+	for _, capability := range capabilities {
+		encoded = append(encoded, string(capability))
+	}
+	return encoded
+}
+
+func decodeCapabilities(capabilities []string) []provisioner.Capability {
+	decoded := make([]provisioner.Capability, 0, len(capabilities))
+	for _, capability := range capabilities {
+		decoded = append(decoded, provisioner.Capability(capability))
+	}
+	return decoded
+}
+
+func encodeImageFormats(formats []metal3api.ImageFormat) []string {
+	encoded := make([]string, 0, len(formats))
+	for _, format := range formats {
+		encoded = append(encoded, string(format))
+	}
+	return encoded
+}
+
+func decodeImageFormats(formats []string) []metal3api.ImageFormat {
+	if formats == nil {
+		return nil
+	}
+	decoded := make([]metal3api.ImageFormat, 0, len(formats))
+	for _, format := range formats {
+		decoded = append(decoded, metal3api.ImageFormat(format))
+	}
+	return decoded
+}
+
+func encodePowerState(state provisioner.HardwareState) v1alpha1.PowerState {
+	switch {
+	case state.PoweredOn == nil:
+		return v1alpha1.PowerState_POWER_STATE_UNKNOWN
+	case *state.PoweredOn:
+		return v1alpha1.PowerState_POWER_STATE_ON
+	default:
+		return v1alpha1.PowerState_POWER_STATE_OFF
+	}
+}
+
+func decodePowerState(state v1alpha1.PowerState) provisioner.HardwareState {
+	switch state {
+	case v1alpha1.PowerState_POWER_STATE_ON:
+		return provisioner.HardwareState{PoweredOn: ptr(true)}
+	case v1alpha1.PowerState_POWER_STATE_OFF:
+		return provisioner.HardwareState{PoweredOn: ptr(false)}
+	default:
+		return provisioner.HardwareState{}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func encodeNetworkInterfaces(c *objectCodec, interfaces []provisioner.NetworkInterfaceData) []*v1alpha1.NetworkInterface {
+	if interfaces == nil {
+		return nil
+	}
+	encoded := make([]*v1alpha1.NetworkInterface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		encoded = append(encoded, &v1alpha1.NetworkInterface{
+			MacAddress: iface.MACAddress,
+			SwitchPort: encodeObject(c, iface.SwitchPort),
+			Attachment: encodeObject(c, iface.Attachment),
+		})
+	}
+	return encoded
+}
+
+func decodeNetworkInterfaces(c *objectCodec, interfaces []*v1alpha1.NetworkInterface) []provisioner.NetworkInterfaceData {
+	if interfaces == nil {
+		return nil
+	}
+	decoded := make([]provisioner.NetworkInterfaceData, 0, len(interfaces))
+	for _, iface := range interfaces {
+		decoded = append(decoded, provisioner.NetworkInterfaceData{
+			MACAddress: iface.GetMacAddress(),
+			SwitchPort: decodeObject[metal3api.SwitchPort](c, iface.GetSwitchPort()),
+			Attachment: decodeObject[metal3api.HostNetworkAttachmentSpec](c, iface.GetAttachment()),
+		})
+	}
+	return decoded
+}
+
+func encodeManagementAccessData(c *objectCodec, data provisioner.ManagementAccessData) *v1alpha1.ManagementAccessData {
+	encoded := &v1alpha1.ManagementAccessData{
+		BootMode:                   string(data.BootMode),
+		AutomatedCleaningMode:      string(data.AutomatedCleaningMode),
+		State:                      string(data.State),
+		OperationalStatus:          string(data.OperationalStatus),
+		CurrentImage:               encodeObject(c, data.CurrentImage),
+		PreprovisioningNetworkData: data.PreprovisioningNetworkData,
+		HasCustomDeploy:            data.HasCustomDeploy,
+		DisablePowerOff:            data.DisablePowerOff,
+		CpuArchitecture:            data.CPUArchitecture,
+		HardwareData:               encodeObject(c, data.HardwareData),
+		DisableInspection:          data.DisableInspection,
+		InspectionMode:             string(data.InspectionMode),
+		NetworkInterfaces:          encodeNetworkInterfaces(c, data.NetworkInterfaces),
+		ConductorGroup:             data.ConductorGroup,
+		NodeShard:                  data.NodeShard,
+	}
+	if image := data.PreprovisioningImage; image != nil {
+		encoded.PreprovisioningImage = &v1alpha1.PreprovisioningImage{
+			ImageUrl:          image.ImageURL,
+			KernelUrl:         image.KernelURL,
+			ExtraKernelParams: image.ExtraKernelParams,
+			Format:            string(image.Format),
+		}
+	}
+	return encoded
+}
+
+func decodeManagementAccessData(c *objectCodec, data *v1alpha1.ManagementAccessData) provisioner.ManagementAccessData {
+	decoded := provisioner.ManagementAccessData{
+		BootMode:                   metal3api.BootMode(data.GetBootMode()),
+		AutomatedCleaningMode:      metal3api.AutomatedCleaningMode(data.GetAutomatedCleaningMode()),
+		State:                      metal3api.ProvisioningState(data.GetState()),
+		OperationalStatus:          metal3api.OperationalStatus(data.GetOperationalStatus()),
+		CurrentImage:               decodeObject[metal3api.Image](c, data.GetCurrentImage()),
+		PreprovisioningNetworkData: data.GetPreprovisioningNetworkData(),
+		HasCustomDeploy:            data.GetHasCustomDeploy(),
+		DisablePowerOff:            data.GetDisablePowerOff(),
+		CPUArchitecture:            data.GetCpuArchitecture(),
+		HardwareData:               decodeObject[metal3api.HardwareData](c, data.GetHardwareData()),
+		DisableInspection:          data.GetDisableInspection(),
+		InspectionMode:             metal3api.InspectionMode(data.GetInspectionMode()),
+		NetworkInterfaces:          decodeNetworkInterfaces(c, data.GetNetworkInterfaces()),
+		ConductorGroup:             data.GetConductorGroup(),
+		NodeShard:                  data.GetNodeShard(),
+	}
+	if image := data.GetPreprovisioningImage(); image != nil {
+		decoded.PreprovisioningImage = &provisioner.PreprovisioningImage{
+			GeneratedImage: imageprovider.GeneratedImage{
+				ImageURL:          image.GetImageUrl(),
+				KernelURL:         image.GetKernelUrl(),
+				ExtraKernelParams: image.GetExtraKernelParams(),
+			},
+			Format: metal3api.ImageFormat(image.GetFormat()),
+		}
+	}
+	return decoded
+}
+
+func encodeInspectData(data provisioner.InspectData) *v1alpha1.InspectData {
+	return &v1alpha1.InspectData{
+		BootMode:        string(data.BootMode),
+		CpuArchitecture: data.CPUArchitecture,
+		InspectionMode:  string(data.InspectionMode),
+	}
+}
+
+func decodeInspectData(data *v1alpha1.InspectData) provisioner.InspectData {
+	return provisioner.InspectData{
+		BootMode:        metal3api.BootMode(data.GetBootMode()),
+		CPUArchitecture: data.GetCpuArchitecture(),
+		InspectionMode:  metal3api.InspectionMode(data.GetInspectionMode()),
+	}
+}
+
+func encodeFirmwareUpdates(updates []metal3api.FirmwareUpdate) []*v1alpha1.FirmwareUpdate {
+	if updates == nil {
+		return nil
+	}
+	encoded := make([]*v1alpha1.FirmwareUpdate, 0, len(updates))
+	for _, update := range updates {
+		encoded = append(encoded, &v1alpha1.FirmwareUpdate{Component: update.Component, Url: update.URL})
+	}
+	return encoded
+}
+
+func decodeFirmwareUpdates(updates []*v1alpha1.FirmwareUpdate) []metal3api.FirmwareUpdate {
+	if updates == nil {
+		return nil
+	}
+	decoded := make([]metal3api.FirmwareUpdate, 0, len(updates))
+	for _, update := range updates {
+		decoded = append(decoded, metal3api.FirmwareUpdate{Component: update.GetComponent(), URL: update.GetUrl()})
+	}
+	return decoded
+}
+
+func encodePrepareData(c *objectCodec, data provisioner.PrepareData) *v1alpha1.PrepareData {
+	return &v1alpha1.PrepareData{
+		TargetRaidConfig:         encodeObject(c, data.TargetRAIDConfig),
+		ActualRaidConfig:         encodeObject(c, data.ActualRAIDConfig),
+		RootDeviceHints:          encodeObject(c, data.RootDeviceHints),
+		TargetFirmwareSettings:   encodeObject(c, &data.TargetFirmwareSettings),
+		ActualFirmwareSettings:   data.ActualFirmwareSettings,
+		TargetFirmwareComponents: encodeFirmwareUpdates(data.TargetFirmwareComponents),
+	}
+}
+
+func decodePrepareData(c *objectCodec, data *v1alpha1.PrepareData) provisioner.PrepareData {
+	return provisioner.PrepareData{
+		TargetRAIDConfig:         decodeObject[metal3api.RAIDConfig](c, data.GetTargetRaidConfig()),
+		ActualRAIDConfig:         decodeObject[metal3api.RAIDConfig](c, data.GetActualRaidConfig()),
+		RootDeviceHints:          decodeObject[metal3api.RootDeviceHints](c, data.GetRootDeviceHints()),
+		TargetFirmwareSettings:   decodeValue[metal3api.DesiredSettingsMap](c, data.GetTargetFirmwareSettings()),
+		ActualFirmwareSettings:   data.GetActualFirmwareSettings(),
+		TargetFirmwareComponents: decodeFirmwareUpdates(data.GetTargetFirmwareComponents()),
+	}
+}
+
+func encodeServicingData(c *objectCodec, data provisioner.ServicingData) *v1alpha1.ServicingData {
+	return &v1alpha1.ServicingData{
+		TargetFirmwareSettings:   encodeObject(c, &data.TargetFirmwareSettings),
+		ActualFirmwareSettings:   data.ActualFirmwareSettings,
+		TargetFirmwareComponents: encodeFirmwareUpdates(data.TargetFirmwareComponents),
+		HasFirmwareSpec:          data.HasFirmwareSpec,
+	}
+}
+
+func decodeServicingData(c *objectCodec, data *v1alpha1.ServicingData) provisioner.ServicingData {
+	return provisioner.ServicingData{
+		TargetFirmwareSettings:   decodeValue[metal3api.DesiredSettingsMap](c, data.GetTargetFirmwareSettings()),
+		ActualFirmwareSettings:   data.GetActualFirmwareSettings(),
+		TargetFirmwareComponents: decodeFirmwareUpdates(data.GetTargetFirmwareComponents()),
+		HasFirmwareSpec:          data.GetHasFirmwareSpec(),
+	}
+}
+
+// encodeProvisionData leaves the host configuration data out, the caller
+// gets its values with getHostConfig.
+func encodeProvisionData(c *objectCodec, data provisioner.ProvisionData) *v1alpha1.ProvisionData {
+	return &v1alpha1.ProvisionData{
+		Image:    encodeObject(c, &data.Image),
+		BootMode: string(data.BootMode),
+		HardwareProfile: &v1alpha1.HardwareProfile{
+			Name:            data.HardwareProfile.Name,
+			RootDeviceHints: encodeObject(c, &data.HardwareProfile.RootDeviceHints),
+		},
+		RootDeviceHints:   encodeObject(c, data.RootDeviceHints),
+		CustomDeploy:      encodeObject(c, data.CustomDeploy),
+		ImagePullSecret:   data.ImagePullSecret,
+		NetworkInterfaces: encodeNetworkInterfaces(c, data.NetworkInterfaces),
+	}
+}
+
+func decodeProvisionData(c *objectCodec, data *v1alpha1.ProvisionData) provisioner.ProvisionData {
+	decoded := provisioner.ProvisionData{
+		Image:    decodeValue[metal3api.Image](c, data.GetImage()),
+		BootMode: metal3api.BootMode(data.GetBootMode()),
+		HardwareProfile: profile.Profile{
+			Name:            data.GetHardwareProfile().GetName(),
+			RootDeviceHints: decodeValue[metal3api.RootDeviceHints](c, data.GetHardwareProfile().GetRootDeviceHints()),
+		},
+		RootDeviceHints:   decodeObject[metal3api.RootDeviceHints](c, data.GetRootDeviceHints()),
+		CustomDeploy:      decodeObject[metal3api.CustomDeploy](c, data.GetCustomDeploy()),
+		ImagePullSecret:   data.GetImagePullSecret(),
+		NetworkInterfaces: decodeNetworkInterfaces(c, data.GetNetworkInterfaces()),
+	}
+	if hostConfig := data.GetHostConfig(); hostConfig != nil {
+		decoded.HostConfig = &hostConfigData{values: hostConfig}
+	}
+	return decoded
+}
+
+func getHostConfig(ctx context.Context, hostConfig provisioner.HostConfigData) (*v1alpha1.HostConfig, error) {
+	userData, err := hostConfig.UserData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networkData, err := hostConfig.NetworkData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metaData, err := hostConfig.MetaData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.HostConfig{UserData: userData, NetworkData: networkData, MetaData: metaData}, nil
+}
+
+// hostConfigData gives the provisioners of the plugin the values of the
+// provisioner.HostConfigData of the host.
+type hostConfigData struct {
+	values *v1alpha1.HostConfig
+}
+
+func (cd *hostConfigData) UserData(_ context.Context) (string, error) {
+	return cd.values.GetUserData(), nil
+}
+
+func (cd *hostConfigData) NetworkData(_ context.Context) (string, error) {
+	return cd.values.GetNetworkData(), nil
+}
+
+func (cd *hostConfigData) MetaData(_ context.Context) (string, error) {
+	return cd.values.GetMetaData(), nil
+}
+
+func encodeSettingSchemas(c *objectCodec, schemas map[string]metal3api.SettingSchema) map[string][]byte {
+	if schemas == nil {
+		return nil
+	}
+	encoded := make(map[string][]byte, len(schemas))
+	for name, schema := range schemas {
+		encoded[name] = encodeObject(c, &schema)
+	}
+	return encoded
+}
+
+func decodeSettingSchemas(c *objectCodec, schemas map[string][]byte) map[string]metal3api.SettingSchema {
+	if schemas == nil {
+		return nil
+	}
+	decoded := make(map[string]metal3api.SettingSchema, len(schemas))
+	for name, schema := range schemas {
+		decoded[name] = decodeValue[metal3api.SettingSchema](c, schema)
+	}
+	return decoded
+}
+
+func encodeHTTPHeaders(headers provisioner.HTTPHeaders) []*v1alpha1.HTTPHeaders {
+	if headers == nil {
+		return nil
+	}
+	encoded := make([]*v1alpha1.HTTPHeaders, 0, len(headers))
+	for _, h := range headers {
+		encoded = append(encoded, &v1alpha1.HTTPHeaders{Headers: h})
+	}
+	return encoded
+}
+
+func decodeHTTPHeaders(headers []*v1alpha1.HTTPHeaders) provisioner.HTTPHeaders {
+	if headers == nil {
+		return nil
+	}
+	decoded := make(provisioner.HTTPHeaders, 0, len(headers))
+	for _, h := range headers {
+		decoded = append(decoded, h.GetHeaders())
+	}
+	return decoded
+}
+
+func encodeFirmwareComponents(components []metal3api.FirmwareComponentStatus) []*v1alpha1.FirmwareComponent {
+	if components == nil {
+		return nil
+	}
+	encoded := make([]*v1alpha1.FirmwareComponent, 0, len(components))
+	for _, component := range components {
+		var updatedAt int64
+		if !component.UpdatedAt.IsZero() {
+			updatedAt = component.UpdatedAt.UnixNano()
+		}
+		encoded = append(encoded, &v1alpha1.FirmwareComponent{
+			Component:                component.Component,
+			InitialVersion:           component.InitialVersion,
+			CurrentVersion:           component.CurrentVersion,
+			LastVersionFlashed:       component.LastVersionFlashed,
+			UpdatedAtUnixNanoseconds: updatedAt,
+		})
+	}
+	return encoded
+}
+
+func decodeFirmwareComponents(components []*v1alpha1.FirmwareComponent) []metal3api.FirmwareComponentStatus {
+	if components == nil {
+		return nil
+	}
+	decoded := make([]metal3api.FirmwareComponentStatus, 0, len(components))
+	for _, component := range components {
+		var updatedAt metav1.Time
+		if nanoseconds := component.GetUpdatedAtUnixNanoseconds(); nanoseconds != 0 {
+			updatedAt = metav1.NewTime(time.Unix(0, nanoseconds))
+		}
+		decoded = append(decoded, metal3api.FirmwareComponentStatus{
+			Component:          component.GetComponent(),
+			InitialVersion:     component.GetInitialVersion(),
+			CurrentVersion:     component.GetCurrentVersion(),
+			LastVersionFlashed: component.GetLastVersionFlashed(),
+			UpdatedAt:          updatedAt,
+		})
+	}
+	return decoded
+}
+
+func encodeHostData(hostData provisioner.HostData) (*v1alpha1.HostData, error) {
+	objectMeta, err := json.Marshal(hostData.ObjectMeta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the metadata of the host: %w", err)
+	}
+	return &v1alpha1.HostData{
+		ObjectMeta:                     objectMeta,
+		BmcAddress:                     hostData.BMCAddress,
+		BmcUsername:                    hostData.BMCCredentials.Username,
+		BmcPassword:                    hostData.BMCCredentials.Password,
+		DisableCertificateVerification: hostData.DisableCertificateVerification,
+		BootMacAddress:                 hostData.BootMACAddress,
+		ProvisionerId:                  hostData.ProvisionerID,
+		Shard:                          hostData.Shard,
+		BmcCaBundle:                    hostData.BMCCABundle,
+		BmcCertificateFingerprint:      hostData.BMCCertificateFingerprint,
+	}, nil
+}
+
+func decodeHostData(data *v1alpha1.HostData) (provisioner.HostData, error) {
+	hostData := provisioner.HostData{
+		BMCAddress: data.GetBmcAddress(),
+		BMCCredentials: bmc.Credentials{
+			Username: data.GetBmcUsername(),
+			Password: data.GetBmcPassword(),
+		},
+		DisableCertificateVerification: data.GetDisableCertificateVerification(),
+		BootMACAddress:                 data.GetBootMacAddress(),
+		ProvisionerID:                  data.GetProvisionerId(),
+		Shard:                          data.GetShard(),
+		BMCCABundle:                    data.GetBmcCaBundle(),
+		BMCCertificateFingerprint:      data.GetBmcCertificateFingerprint(),
+	}
+	if len(data.GetObjectMeta()) > 0 {
+		objectMeta := metav1.ObjectMeta{}
+		if err := json.Unmarshal(data.GetObjectMeta(), &objectMeta); err != nil {
+			return provisioner.HostData{}, fmt.Errorf("failed to decode the metadata of the host: %w", err)
+		}
+		hostData.ObjectMeta = objectMeta
+	}
+	return hostData, nil
+}
+
+func encodeResult(result provisioner.Result) *v1alpha1.Result {
+	return &v1alpha1.Result{
+		Dirty:                   result.Dirty,
+		RequeueAfterNanoseconds: int64(result.RequeueAfter),
+		ErrorMessage:            result.ErrorMessage,
+	}
+}
+
+func decodeResult(result *v1alpha1.Result) provisioner.Result {
+	return provisioner.Result{
+		Dirty:        result.GetDirty(),
+		RequeueAfter: time.Duration(result.GetRequeueAfterNanoseconds()),
+		ErrorMessage: result.GetErrorMessage(),
+	}
+}
+
+// errorKinds lists the errors of the provisioner package that are recognized
+// on the host after crossing the process boundary.
+var errorKinds = []struct {
+	kind v1alpha1.ErrorKind
+	err  error
+}{
+	{v1alpha1.ErrorKind_ERROR_KIND_NOT_READY, provisioner.ErrNotReady},
+	{v1alpha1.ErrorKind_ERROR_KIND_NEEDS_REGISTRATION, provisioner.ErrNeedsRegistration},
+	{v1alpha1.ErrorKind_ERROR_KIND_NEEDS_PREPROVISIONING_IMAGE, provisioner.ErrNeedsPreprovisioningImage},
+	{v1alpha1.ErrorKind_ERROR_KIND_NODE_IS_BUSY, provisioner.ErrNodeIsBusy},
+	{v1alpha1.ErrorKind_ERROR_KIND_NOT_SUPPORTED, provisioner.ErrNotSupported},
+}
+
+// pluginError is an error returned by a provisioner of the plugin.
+type pluginError struct {
+	message string
+	cause   error
+}
+
+func (e *pluginError) Error() string { return e.message }
+
+func (e *pluginError) Unwrap() error { return e.cause }
+
+func encodeError(err error) *v1alpha1.Error {
+	encoded := &v1alpha1.Error{Message: err.Error()}
+	for _, known := range errorKinds {
+		if errors.Is(err, known.err) {
+			encoded.Kind = known.kind
+			break
+		}
+	}
+	return encoded
+}
+
+func decodeError(encoded *v1alpha1.Error) error {
+	decoded := &pluginError{message: encoded.GetMessage()}
+	for _, known := range errorKinds {
+		if encoded.GetKind() == known.kind {
+			decoded.cause = known.err
+			break
+		}
+	}
+	return decoded
+}
@@ -0,0 +1,142 @@
+package ironic
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+)
+
+// bmcCABundleMode lets Ironic read the CA bundles whatever user it runs as.
+const bmcCABundleMode os.FileMode = 0o644
+
+// hasBMCTLSOptions returns whether the certificate of the BMC is verified
+// with a CA bundle or a pinned fingerprint rather than the system CAs.
+func (p *ironicProvisioner) hasBMCTLSOptions() bool {
+	return len(p.bmcCABundle) > 0 || p.bmcCertificateFingerprint != ""
+}
+
+// bmcCABundlePath returns where the CA bundle of the host is written for
+// Ironic to read it, or an empty string when there is no directory for it.
+func (p *ironicProvisioner) bmcCABundlePath() string {
+	if p.config.bmcCADir == "" {
+		return ""
+	}
+	return filepath.Join(p.config.bmcCADir, fmt.Sprintf("%s_%s.pem", p.objectMeta.Namespace, p.objectMeta.Name))
+}
+
+// pemMatchesFingerprint returns whether the first certificate of the PEM
+// data has the fingerprint.
+func pemMatchesFingerprint(data []byte, fingerprint string) bool {
+	sum, err := bmc.ParseCertificateFingerprint(fingerprint)
+	if err != nil {
+		return false
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return false
+	}
+	actual := sha256.Sum256(block.Bytes)
+	return subtle.ConstantTimeCompare(actual[:], sum) == 1
+}
+
+// ensureBMCCABundle writes the file Ironic verifies the certificate of the
+// BMC with, or removes it once the host has no CA bundle or fingerprint any
+// more. Ironic cannot pin certificates, so without a CA bundle the pinned
+// certificate itself is fetched from the BMC and used as the bundle.
+func (p *ironicProvisioner) ensureBMCCABundle(ctx context.Context) error {
+	if !p.hasBMCTLSOptions() {
+		p.removeBMCCABundle()
+		return nil
+	}
+	path := p.bmcCABundlePath()
+	if path == "" {
+		return errors.New("BMC_CA_DIR must be set to verify BMC certificates with a CA bundle or a fingerprint")
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read the BMC CA bundle: %w", err)
+	}
+
+	bundle := p.bmcCABundle
+	if len(bundle) == 0 {
+		if pemMatchesFingerprint(existing, p.bmcCertificateFingerprint) {
+			return nil
+		}
+		p.log.Info("fetching the pinned certificate of the BMC")
+		bundle, err = bmc.FetchPinnedCertificate(ctx, p.bmcAddress, p.bmcCertificateFingerprint)
+		if err != nil {
+			return err
+		}
+	}
+	if bytes.Equal(existing, bundle) {
+		return nil
+	}
+
+	// Write to a temporary file first so that Ironic never reads a
+	// partially written bundle.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return fmt.Errorf("failed to write the BMC CA bundle: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(bundle); err == nil {
+		err = tmp.Chmod(bmcCABundleMode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the BMC CA bundle: %w", err)
+	}
+	p.log.Info("updated the BMC CA bundle", "path", path)
+	return nil
+}
+
+// removeBMCCABundle removes the CA bundle written for the host, if any.
+func (p *ironicProvisioner) removeBMCCABundle() {
+	path := p.bmcCABundlePath()
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		p.log.Error(err, "failed to remove the BMC CA bundle", "path", path)
+	}
+}
+
+// bmcVerifyCAMatches returns whether the node verifies the certificate of
+// the BMC with the same CA bundle as the driver info. Only bundle paths are
+// compared, so that nodes registered before CA bundles were supported are
+// not updated on every registration.
+func bmcVerifyCAMatches(ironicNode *nodes.Node, driverInfo map[string]any) bool {
+	for key, value := range driverInfo {
+		if !strings.HasSuffix(key, "_verify_ca") {
+			continue
+		}
+		_, newPath := value.(string)
+		_, oldPath := ironicNode.DriverInfo[key].(string)
+		if newPath || oldPath {
+			return value == ironicNode.DriverInfo[key]
+		}
+		return true
+	}
+	for key, value := range ironicNode.DriverInfo {
+		if _, isPath := value.(string); isPath && strings.HasSuffix(key, "_verify_ca") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package ironic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/clients"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBMCTLSTestIronic(t *testing.T, bmcAddress string, verifyCA any) *testserver.IronicMock {
+	t.Helper()
+	return testserver.NewIronic(t).
+		Node(nodes.Node{
+			Name: "myns" + nameSeparator + "myhost",
+			UUID: "uuid",
+			DriverInfo: map[string]any{
+				"redfish_address":   bmcAddress,
+				"redfish_username":  "",
+				"redfish_password":  "",
+				"redfish_system_id": "/redfish/v1/Systems/1",
+				"redfish_verify_ca": verifyCA,
+			},
+			ProvisionState: string(nodes.Verifying),
+		}).NodeUpdate(nodes.Node{UUID: "uuid", ProvisionState: string(nodes.Verifying)}).
+		Port(ports.Port{NodeUUID: "uuid", Address: "11:11:11:11:11:11"})
+}
+
+func TestRegisterBMCCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	server.Close()
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	for _, tc := range []struct {
+		Scenario       string
+		VerifyCA       any
+		ExistingBundle []byte
+		ExpectedUpdate bool
+	}{
+		{
+			Scenario:       "new bundle",
+			VerifyCA:       true,
+			ExpectedUpdate: true,
+		},
+		{
+			Scenario:       "changed bundle",
+			ExistingBundle: []byte("old bundle"),
+		},
+		{
+			Scenario:       "same bundle",
+			ExistingBundle: bundle,
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := makeHost()
+			host.Spec.BMC.Address = "redfish://192.168.122.1/redfish/v1/Systems/1"
+			host.Spec.BootMACAddress = "11:11:11:11:11:11"
+			host.Status.Provisioning.ID = "uuid"
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "myns_myhost.pem")
+			if tc.ExistingBundle != nil {
+				require.NoError(t, os.WriteFile(path, tc.ExistingBundle, 0o600))
+			}
+			verifyCA := tc.VerifyCA
+			if verifyCA == nil {
+				verifyCA = path
+			}
+
+			ironic := newBMCTLSTestIronic(t, "https://192.168.122.1", verifyCA)
+			ironic.Start()
+			defer ironic.Stop()
+
+			auth := clients.AuthConfig{Type: clients.NoAuth}
+			prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nil, ironic.Endpoint(), auth)
+			require.NoError(t, err)
+			prov.config.bmcCADir = dir
+			prov.bmcCABundle = bundle
+
+			result, _, err := prov.Register(t.Context(), provisioner.ManagementAccessData{}, false, false)
+			require.NoError(t, err)
+			assert.Empty(t, result.ErrorMessage)
+
+			written, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, bundle, written)
+
+			updates := ironic.GetLastNodeUpdateRequestFor("uuid")
+			var newDriverInfo map[string]any
+			for _, update := range updates {
+				if update.Path == "/driver_info" {
+					newDriverInfo, _ = update.Value.(map[string]any)
+				}
+			}
+			if tc.ExpectedUpdate {
+				require.NotNil(t, newDriverInfo, "expected the driver info to be updated")
+				assert.Equal(t, path, newDriverInfo["redfish_verify_ca"])
+			} else {
+				assert.Nil(t, newDriverInfo, "unexpected driver info update")
+			}
+		})
+	}
+}
+
+func TestRegisterBMCCABundleWithoutDir(t *testing.T) {
+	host := makeHost()
+	host.Spec.BMC.Address = "redfish://192.168.122.1/redfish/v1/Systems/1"
+	host.Spec.BootMACAddress = "11:11:11:11:11:11"
+	host.Spec.BMC.CertificateFingerprint = strings.Repeat("ab", sha256.Size)
+	host.Status.Provisioning.ID = "uuid"
+
+	ironic := newBMCTLSTestIronic(t, "https://192.168.122.1", true)
+	ironic.Start()
+	defer ironic.Stop()
+
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+	prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nil, ironic.Endpoint(), auth)
+	require.NoError(t, err)
+
+	result, _, err := prov.Register(t.Context(), provisioner.ManagementAccessData{}, false, false)
+	require.NoError(t, err)
+	assert.Contains(t, result.ErrorMessage, "BMC_CA_DIR must be set")
+}
+
+func TestRegisterBMCCertificateFingerprint(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	sum := sha256.Sum256(server.Certificate().Raw)
+
+	host := makeHost()
+	host.Spec.BMC.Address = "redfish+" + server.URL + "/redfish/v1/Systems/1"
+	host.Spec.BootMACAddress = "11:11:11:11:11:11"
+	host.Spec.BMC.CertificateFingerprint = "sha256:" + hex.EncodeToString(sum[:])
+	host.Status.Provisioning.ID = "uuid"
+
+	ironic := newBMCTLSTestIronic(t, server.URL, true)
+	ironic.Start()
+	defer ironic.Stop()
+
+	dir := t.TempDir()
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+	prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nil, ironic.Endpoint(), auth)
+	require.NoError(t, err)
+	prov.config.bmcCADir = dir
+
+	result, _, err := prov.Register(t.Context(), provisioner.ManagementAccessData{}, false, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.ErrorMessage)
+
+	written, err := os.ReadFile(filepath.Join(dir, "myns_myhost.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), written)
+
+	// A BMC presenting another certificate is refused.
+	prov.bmcCertificateFingerprint = strings.Repeat("00", sha256.Size)
+	result, _, err = prov.Register(t.Context(), provisioner.ManagementAccessData{}, false, false)
+	require.NoError(t, err)
+	assert.Contains(t, result.ErrorMessage, "not the pinned one")
+}
+
+func TestRegisterRemovesBMCCABundle(t *testing.T) {
+	host := makeHost()
+	host.Spec.BMC.Address = "redfish://192.168.122.1/redfish/v1/Systems/1"
+	host.Spec.BootMACAddress = "11:11:11:11:11:11"
+	host.Status.Provisioning.ID = "uuid"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "myns_myhost.pem")
+	require.NoError(t, os.WriteFile(path, []byte("bundle"), 0o600))
+
+	ironic := newBMCTLSTestIronic(t, "https://192.168.122.1", path)
+	ironic.Start()
+	defer ironic.Stop()
+
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+	prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nil, ironic.Endpoint(), auth)
+	require.NoError(t, err)
+	prov.config.bmcCADir = dir
+
+	result, _, err := prov.Register(t.Context(), provisioner.ManagementAccessData{}, false, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.ErrorMessage)
+	assert.NoFileExists(t, path)
+}
+
+func TestRemovalRemovesBMCCABundle(t *testing.T) {
+	for _, tc := range []struct {
+		Scenario string
+		Remove   func(context.Context, *ironicProvisioner) (provisioner.Result, error)
+	}{
+		{
+			Scenario: "delete",
+			Remove: func(ctx context.Context, prov *ironicProvisioner) (provisioner.Result, error) {
+				return prov.Delete(ctx)
+			},
+		},
+		{
+			Scenario: "detach",
+			Remove: func(ctx context.Context, prov *ironicProvisioner) (provisioner.Result, error) {
+				return prov.Detach(ctx, false)
+			},
+		},
+	} {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := makeHost()
+			host.Status.Provisioning.ID = "uuid"
+
+			ironic := testserver.NewIronic(t).NoNode("uuid").NoNode(host.Namespace + nameSeparator + host.Name)
+			ironic.Start()
+			defer ironic.Stop()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "myns_myhost.pem")
+			require.NoError(t, os.WriteFile(path, []byte("bundle"), 0o600))
+
+			auth := clients.AuthConfig{Type: clients.NoAuth}
+			prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nil, ironic.Endpoint(), auth)
+			require.NoError(t, err)
+			prov.config.bmcCADir = dir
+
+			_, err = tc.Remove(t.Context(), prov)
+			require.NoError(t, err)
+			assert.NoFileExists(t, path)
+		})
+	}
+}
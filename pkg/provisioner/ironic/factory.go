@@ -57,6 +57,12 @@ type ironicProvisionerFactory struct {
 
 	// HTTP client timeout for all requests to Ironic
 	clientTimeout time.Duration
+
+	// Ironic instances by shard name, including the default one, when
+	// IRONIC_SHARDS is set
+	shards          map[string]*ironicShard
+	shardAssignment string
+	shardLoad       *shardLoad
 }
 
 func NewProvisionerFactory(logger logr.Logger, havePreprovImgBuilder bool) (provisioner.Factory, error) {
@@ -113,7 +119,15 @@ func (f *ironicProvisionerFactory) init(havePreprovImgBuilder bool) error {
 		return err
 	}
 
+	shardEndpoints, shardAssignment, err := loadShardsFromEnv()
+	if err != nil {
+		return err
+	}
+
 	if f.ironicName != "" && f.ironicNamespace != "" {
+		if len(shardEndpoints) > 0 {
+			return errors.New("IRONIC_SHARDS is not supported with the Ironic resource configuration")
+		}
 		f.log.Info("will use Ironic resource configuration",
 			"ironicName", f.ironicName,
 			"ironicNamespace", f.ironicNamespace,
@@ -160,18 +174,43 @@ func (f *ironicProvisionerFactory) init(havePreprovImgBuilder bool) error {
 		return err
 	}
 
+	if len(shardEndpoints) == 0 {
+		return nil
+	}
+
+	f.shardAssignment = shardAssignment
+	f.shardLoad = new(shardLoad)
+	f.shards = map[string]*ironicShard{
+		defaultShard: {clientIronic: f.clientIronic, cache: f.cache},
+	}
+	for name, endpoint := range shardEndpoints {
+		shardClient, err := clients.IronicClient(endpoint, ironicAuth, tlsConf, f.clientTimeout)
+		if err != nil {
+			return fmt.Errorf("ironic shard %s: %w", name, err)
+		}
+		f.shards[name] = &ironicShard{clientIronic: shardClient, cache: new(ironicProvisionerCache)}
+	}
+	f.log.Info("ironic shards from environment variables",
+		"shards", shardEndpoints,
+		"assignment", f.shardAssignment,
+	)
+
 	return nil
 }
 
 func (f ironicProvisionerFactory) refreshCache(ctx context.Context, createClient func() (*gophercloud.ServiceClient, error)) (*gophercloud.ServiceClient, clients.AvailableFeatures, error) {
+	return f.refreshCacheOf(ctx, f.cache, createClient)
+}
+
+func (f ironicProvisionerFactory) refreshCacheOf(ctx context.Context, cache *ironicProvisionerCache, createClient func() (*gophercloud.ServiceClient, error)) (*gophercloud.ServiceClient, clients.AvailableFeatures, error) {
 	enableCache := f.cacheTTL > 0
 	if enableCache {
-		f.cache.lock.Lock()
-		defer f.cache.lock.Unlock()
+		cache.lock.Lock()
+		defer cache.lock.Unlock()
 
-		isExpired := time.Now().After(f.cache.expiresAt)
-		if f.cache.clientIronic != nil && f.cache.availableFeatures.MaxVersion != 0 && !isExpired {
-			return f.cache.clientIronic, f.cache.availableFeatures, nil // cache is up-to-date
+		isExpired := time.Now().After(cache.expiresAt)
+		if cache.clientIronic != nil && cache.availableFeatures.MaxVersion != 0 && !isExpired {
+			return cache.clientIronic, cache.availableFeatures, nil // cache is up-to-date
 		}
 
 		f.log.V(VerbosityLevelDebug).Info("client cache expired, refreshing", "TTL", f.cacheTTL, "HasGlobalClient", f.ironicName == "")
@@ -202,9 +241,9 @@ func (f ironicProvisionerFactory) refreshCache(ctx context.Context, createClient
 	if enableCache {
 		// NOTE(dtantsur): the cache is only updated on success. If validation
 		// fails, the cache stays expired until the next try.
-		f.cache.clientIronic = newClient
-		f.cache.availableFeatures = availableFeatures
-		f.cache.expiresAt = time.Now().Add(f.cacheTTL)
+		cache.clientIronic = newClient
+		cache.availableFeatures = availableFeatures
+		cache.expiresAt = time.Now().Add(f.cacheTTL)
 	}
 
 	return newClient, availableFeatures, nil
@@ -255,6 +294,10 @@ func (f ironicProvisionerFactory) checkIronicConductor(ctx context.Context, clie
 func (f ironicProvisionerFactory) ironicProvisioner(ctx context.Context, hostData provisioner.HostData, publisher provisioner.EventPublisher) (*ironicProvisioner, error) {
 	provisionerLogger := f.log.WithValues("host", ironicNodeName(hostData.ObjectMeta))
 
+	if len(f.shards) > 0 {
+		return f.shardedIronicProvisioner(ctx, hostData, publisher, provisionerLogger)
+	}
+
 	var createClient func() (*gophercloud.ServiceClient, error)
 
 	// Check if we should use Ironic CR configuration (fetch fresh config on each provisioner creation)
@@ -301,24 +344,44 @@ func (f ironicProvisionerFactory) ironicProvisioner(ctx context.Context, hostDat
 		return nil, err
 	}
 
-	p := &ironicProvisioner{
-		config:                  f.config,
-		objectMeta:              hostData.ObjectMeta,
-		nodeID:                  hostData.ProvisionerID,
-		bmcCreds:                hostData.BMCCredentials,
-		bmcAddress:              hostData.BMCAddress,
-		disableCertVerification: hostData.DisableCertificateVerification,
-		bootMACAddress:          hostData.BootMACAddress,
-		client:                  clientIronic,
-		log:                     provisionerLogger,
-		debugLog:                provisionerLogger.V(1),
-		publisher:               publisher,
-		availableFeatures:       availableFeatures,
+	return f.newIronicProvisioner(hostData, publisher, provisionerLogger, clientIronic, availableFeatures), nil
+}
+
+func (f ironicProvisionerFactory) shardedIronicProvisioner(ctx context.Context, hostData provisioner.HostData, publisher provisioner.EventPublisher, provisionerLogger logr.Logger) (*ironicProvisioner, error) {
+	shard, err := f.selectShard(ctx, hostData)
+	if err != nil {
+		return nil, err
 	}
 
+	clientIronic, availableFeatures, err := f.refreshShardCache(ctx, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	p := f.newIronicProvisioner(hostData, publisher, provisionerLogger.WithValues("shard", shard), clientIronic, availableFeatures)
+	p.shard = shard
 	return p, nil
 }
 
+func (f ironicProvisionerFactory) newIronicProvisioner(hostData provisioner.HostData, publisher provisioner.EventPublisher, provisionerLogger logr.Logger, clientIronic *gophercloud.ServiceClient, availableFeatures clients.AvailableFeatures) *ironicProvisioner {
+	return &ironicProvisioner{
+		config:                    f.config,
+		objectMeta:                hostData.ObjectMeta,
+		nodeID:                    hostData.ProvisionerID,
+		bmcCreds:                  hostData.BMCCredentials,
+		bmcAddress:                hostData.BMCAddress,
+		disableCertVerification:   hostData.DisableCertificateVerification,
+		bmcCABundle:               hostData.BMCCABundle,
+		bmcCertificateFingerprint: hostData.BMCCertificateFingerprint,
+		bootMACAddress:            hostData.BootMACAddress,
+		client:                    clientIronic,
+		log:                       provisionerLogger,
+		debugLog:                  provisionerLogger.V(1),
+		publisher:                 publisher,
+		availableFeatures:         availableFeatures,
+	}
+}
+
 // NewProvisioner returns a new Ironic Provisioner using the global
 // configuration for finding the Ironic services.
 // Returns provisioner.ErrNotReady if Ironic is not available yet.
@@ -381,6 +444,9 @@ func loadConfigFromEnv(havePreprovImgBuilder bool) (ironicConfig, error) {
 
 	c.provNetDisabled = strings.ToLower(os.Getenv("PROVISIONING_NETWORK_DISABLED")) == "true"
 
+	// Must be the same path for the operator and the Ironic conductors.
+	c.bmcCADir = os.Getenv("BMC_CA_DIR")
+
 	return c, nil
 }
 
@@ -0,0 +1,179 @@
+package ironic
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/clients"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionWithFaults(t *testing.T) {
+	nodeUUID := "33ce8659-7400-4c68-9535-d10766f07a58"
+	manageable := nodes.Node{
+		ProvisionState: string(nodes.Manageable),
+		UUID:           nodeUUID,
+	}
+
+	cases := []struct {
+		name                 string
+		fault                testserver.Fault
+		pattern              string
+		method               string
+		expectedDirty        bool
+		expectedError        bool
+		expectedRequestAfter int
+	}{
+		{
+			name:                 "node locked",
+			fault:                testserver.Fault{Code: http.StatusConflict},
+			pattern:              "/v1/nodes/{id}/states/provision",
+			method:               http.MethodPut,
+			expectedDirty:        true,
+			expectedRequestAfter: 3,
+		},
+		{
+			name:          "ironic unavailable",
+			fault:         testserver.Fault{Code: http.StatusServiceUnavailable},
+			pattern:       "/v1/nodes/{id}",
+			method:        http.MethodGet,
+			expectedError: true,
+		},
+		{
+			name:          "state change fails",
+			fault:         testserver.Fault{Code: http.StatusInternalServerError},
+			pattern:       "/v1/nodes/{id}/states/provision",
+			method:        http.MethodPut,
+			expectedError: true,
+		},
+		{
+			name:          "connection dropped",
+			fault:         testserver.Fault{Drop: true},
+			pattern:       "/v1/nodes/{id}",
+			expectedError: true,
+		},
+		{
+			name:          "ironic too slow",
+			fault:         testserver.Fault{Latency: time.Second},
+			pattern:       "/v1/nodes/{id}",
+			expectedError: true,
+		},
+		{
+			name:                 "ironic slow",
+			fault:                testserver.Fault{Latency: 10 * time.Millisecond},
+			pattern:              "/v1/nodes/{id}",
+			expectedDirty:        true,
+			expectedRequestAfter: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ironic := testserver.NewIronic(t).WithDefaultResponses().Node(manageable).
+				WithFault(tc.pattern, tc.method, tc.fault)
+			ironic.Start()
+			defer ironic.Stop()
+
+			host := makeHost()
+			host.Status.Provisioning.ID = nodeUUID
+			auth := clients.AuthConfig{Type: clients.NoAuth}
+			prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+			require.NoError(t, err)
+			prov.client.HTTPClient.Timeout = 200 * time.Millisecond
+
+			result, err := prov.Provision(t.Context(), provisioner.ProvisionData{
+				Image:    metal3api.Image{URL: "http://test-image", Checksum: "abcd"},
+				BootMode: metal3api.DefaultBootMode,
+			}, false)
+
+			assert.Equal(t, 1, ironic.InjectedFaults())
+			assert.Equal(t, tc.expectedDirty, result.Dirty)
+			assert.Equal(t, time.Second*time.Duration(tc.expectedRequestAfter), result.RequeueAfter)
+			assert.Empty(t, result.ErrorMessage)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProvisionIntermittentFaults(t *testing.T) {
+	nodeUUID := "33ce8659-7400-4c68-9535-d10766f07a58"
+	ironic := testserver.NewIronic(t).WithDefaultResponses().Node(nodes.Node{
+		ProvisionState: string(nodes.Manageable),
+		UUID:           nodeUUID,
+	}).WithFault("/v1/nodes/{id}", http.MethodGet, testserver.Fault{
+		Code:  http.StatusServiceUnavailable,
+		Every: 2,
+		Times: 2,
+	})
+	ironic.Start()
+	defer ironic.Stop()
+
+	host := makeHost()
+	host.Status.Provisioning.ID = nodeUUID
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+
+	var failures []bool
+	for range 5 {
+		prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+		require.NoError(t, err)
+		_, err = prov.Provision(t.Context(), provisioner.ProvisionData{
+			Image:    metal3api.Image{URL: "http://test-image", Checksum: "abcd"},
+			BootMode: metal3api.DefaultBootMode,
+		}, false)
+		failures = append(failures, err != nil)
+	}
+
+	assert.Equal(t, []bool{true, false, true, false, false}, failures)
+	assert.Equal(t, 2, ironic.InjectedFaults())
+}
+
+func TestProvisionOutOfOrderStates(t *testing.T) {
+	nodeUUID := "33ce8659-7400-4c68-9535-d10766f07a58"
+	image := metal3api.Image{
+		URL:          "http://test-image",
+		Checksum:     "abcd",
+		ChecksumType: metal3api.SHA256,
+	}
+	ironic := testserver.NewIronic(t).WithDefaultResponses().NodeProvisionStates(nodes.Node{
+		UUID: nodeUUID,
+		InstanceInfo: map[string]any{
+			"image_source":        image.URL,
+			"image_os_hash_algo":  string(image.ChecksumType),
+			"image_os_hash_value": image.Checksum,
+		},
+		LastError: "no work today",
+	}, nodes.Deploying, nodes.DeployFail, nodes.Active)
+	ironic.Start()
+	defer ironic.Stop()
+
+	host := makeHost()
+	host.Status.Provisioning.ID = nodeUUID
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+
+	var results []provisioner.Result
+	for range 3 {
+		prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+		require.NoError(t, err)
+		result, err := prov.Provision(t.Context(), provisioner.ProvisionData{
+			Image:    image,
+			BootMode: metal3api.DefaultBootMode,
+		}, false)
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+
+	assert.Equal(t, provisioner.Result{Dirty: true, RequeueAfter: longRetryDelay}, results[0])
+	assert.Equal(t, "Image provisioning failed (url: http://test-image, checksum: abcd): no work today", results[1].ErrorMessage)
+	assert.Equal(t, provisioner.Result{}, results[2])
+}
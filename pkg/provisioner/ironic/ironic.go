@@ -75,6 +75,7 @@ type ironicConfig struct {
 	maxBusyHosts                          int
 	externalURL                           string
 	provNetDisabled                       bool
+	bmcCADir                              string
 }
 
 // Provisioner implements the provisioning.Provisioner interface
@@ -92,6 +93,10 @@ type ironicProvisioner struct {
 	disableCertVerification bool
 	// credentials to log in to the BMC
 	bmcCreds bmc.Credentials
+	// the CA certificates and the pinned fingerprint the certificate of
+	// the BMC is verified with
+	bmcCABundle               []byte
+	bmcCertificateFingerprint string
 	// the MAC address of the PXE boot interface
 	bootMACAddress string
 	// a client for talking to ironic
@@ -106,6 +111,8 @@ type ironicProvisioner struct {
 	availableFeatures clients.AvailableFeatures
 	// node cache for the duration of reconcile
 	cachedNode *nodes.Node
+	// the Ironic shard of the host, when sharding is configured
+	shard string
 }
 
 // FIXME(hroyrh) : move this to gophercloud when implementing
@@ -122,6 +129,16 @@ func (p *ironicProvisioner) bmcAccess() (bmc.AccessDetails, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse BMC address information: %w", err)
 	}
+	if p.hasBMCTLSOptions() {
+		err = bmc.ConfigureTLS(bmcAccess, bmc.TLSOptions{
+			CABundle:               p.bmcCABundle,
+			CABundlePath:           p.bmcCABundlePath(),
+			CertificateFingerprint: p.bmcCertificateFingerprint,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 	return bmcAccess, nil
 }
 
@@ -809,6 +826,10 @@ func (p *ironicProvisioner) GetFirmwareComponents(ctx context.Context) ([]metal3
 func (p *ironicProvisioner) setUpForProvisioning(ctx context.Context, ironicNode *nodes.Node, data provisioner.ProvisionData) (result provisioner.Result, err error) {
 	p.log.Info("starting provisioning", "node properties", ironicNode.Properties)
 
+	if _, err = p.applySwitchPortConfig(ctx, ironicNode, data.NetworkInterfaces); err != nil {
+		return transientError(fmt.Errorf("failed to configure switch ports: %w", err))
+	}
+
 	ironicNode, success, result, err := p.tryUpdateNode(ctx, ironicNode,
 		p.getInstanceUpdateOpts(ironicNode, data))
 	if !success {
@@ -1467,6 +1488,13 @@ func (p *ironicProvisioner) Deprovision(ctx context.Context, restartOnFailure bo
 			return operationContinuing(0)
 		}
 
+		// Return the switch ports to their default configuration so that
+		// cleaning does not happen on the networks of the deployed host,
+		// even if the interfaces were removed from the host since.
+		if _, err = p.clearSwitchPortConfig(ctx, ironicNode); err != nil {
+			return transientError(fmt.Errorf("failed to reset switch ports: %w", err))
+		}
+
 		p.log.Info("starting deprovisioning", "automatedClean", ironicNode.AutomatedClean)
 		p.publisher("DeprovisioningStarted", "Image deprovisioning started")
 		return p.changeNodeProvisionState(ctx, ironicNode,
@@ -1487,6 +1515,7 @@ func (p *ironicProvisioner) Delete(ctx context.Context) (result provisioner.Resu
 	if err != nil {
 		if errors.Is(err, provisioner.ErrNeedsRegistration) {
 			p.log.Info("no node found, already deleted")
+			p.removeBMCCABundle()
 			return operationComplete()
 		}
 		return transientError(err)
@@ -1607,6 +1636,7 @@ func (p *ironicProvisioner) Detach(ctx context.Context, force bool) (result prov
 	if err != nil {
 		if errors.Is(err, provisioner.ErrNeedsRegistration) {
 			p.log.Info("no node found, already deleted")
+			p.removeBMCCABundle()
 			return operationComplete()
 		}
 		return transientError(err)
@@ -2017,6 +2047,62 @@ func (p *ironicProvisioner) HasPowerFailure(ctx context.Context) bool {
 	return node.Fault == "power failure"
 }
 
+// Shard returns the Ironic instance managing the host.
+func (p *ironicProvisioner) Shard() string {
+	return p.shard
+}
+
+// ChangeBMCPassword changes the password of the BMC account directly on the
+// BMC, as Ironic has no API for it.
+func (p *ironicProvisioner) ChangeBMCPassword(ctx context.Context, current bmc.Credentials, newPassword string) error {
+	bmcAccess, err := p.bmcAccess()
+	if err != nil {
+		return err
+	}
+	changer, ok := bmcAccess.(bmc.PasswordChanger)
+	if !ok {
+		return fmt.Errorf("changing the password of %s BMCs is %w", bmcAccess.Type(), provisioner.ErrNotSupported)
+	}
+	return changer.ChangePassword(ctx, current, newPassword)
+}
+
+// BMCAcceptsCredentials checks the credentials against the BMC directly.
+func (p *ironicProvisioner) BMCAcceptsCredentials(ctx context.Context, creds bmc.Credentials) (bool, error) {
+	bmcAccess, err := p.bmcAccess()
+	if err != nil {
+		return false, err
+	}
+	prober, ok := bmcAccess.(bmc.Prober)
+	if !ok {
+		return false, fmt.Errorf("checking the credentials of %s BMCs is %w", bmcAccess.Type(), provisioner.ErrNotSupported)
+	}
+	_, err = prober.Probe(ctx, creds)
+	probeErr := &bmc.ProbeError{}
+	if errors.As(err, &probeErr) && probeErr.Failure == bmc.ProbeUnauthorized {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Capabilities reports the optional calls the Ironic API in use supports.
+func (p *ironicProvisioner) Capabilities(_ context.Context) provisioner.Capabilities {
+	capabilities := provisioner.Capabilities{
+		Provisioner: "ironic",
+		Supported: []provisioner.Capability{
+			provisioner.CapabilityFirmwareSettings,
+			provisioner.CapabilityFirmwareComponents,
+			provisioner.CapabilityBMCEventSubscription,
+		},
+	}
+	if p.availableFeatures.HasVirtualMediaGetAPI() {
+		capabilities.Supported = append(capabilities.Supported, provisioner.CapabilityDataImage)
+	}
+	if p.availableFeatures.HasHealthAPI() {
+		capabilities.Supported = append(capabilities.Supported, provisioner.CapabilityHealth)
+	}
+	return capabilities
+}
+
 func (p *ironicProvisioner) GetHealth(ctx context.Context) string {
 	node, err := p.getNode(ctx)
 	if err != nil {
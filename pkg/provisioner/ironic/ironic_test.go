@@ -45,17 +45,19 @@ func newProvisionerWithSettings(host metal3api.BareMetalHost, bmcCreds bmc.Crede
 
 	provisionerLogger := logf.Log.WithValues("host", ironicNodeName(hostData.ObjectMeta))
 	p := &ironicProvisioner{
-		config:                  testProvisionerConfig,
-		objectMeta:              hostData.ObjectMeta,
-		nodeID:                  hostData.ProvisionerID,
-		bmcCreds:                hostData.BMCCredentials,
-		bmcAddress:              hostData.BMCAddress,
-		disableCertVerification: hostData.DisableCertificateVerification,
-		bootMACAddress:          hostData.BootMACAddress,
-		client:                  clientIronic,
-		log:                     provisionerLogger,
-		debugLog:                provisionerLogger.V(1),
-		publisher:               publisher,
+		config:                    testProvisionerConfig,
+		objectMeta:                hostData.ObjectMeta,
+		nodeID:                    hostData.ProvisionerID,
+		bmcCreds:                  hostData.BMCCredentials,
+		bmcAddress:                hostData.BMCAddress,
+		disableCertVerification:   hostData.DisableCertificateVerification,
+		bmcCABundle:               hostData.BMCCABundle,
+		bmcCertificateFingerprint: hostData.BMCCertificateFingerprint,
+		bootMACAddress:            hostData.BootMACAddress,
+		client:                    clientIronic,
+		log:                       provisionerLogger,
+		debugLog:                  provisionerLogger.V(1),
+		publisher:                 publisher,
 	}
 	return p, nil
 }
@@ -145,3 +147,54 @@ func TestNewNoBMCDetails(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, prov)
 }
+
+func TestCapabilities(t *testing.T) {
+	cases := []struct {
+		name        string
+		maxVersion  int
+		supported   []provisioner.Capability
+		unsupported []provisioner.Capability
+	}{
+		{
+			name:       "baseline",
+			maxVersion: 89,
+			supported: []provisioner.Capability{
+				provisioner.CapabilityFirmwareSettings,
+				provisioner.CapabilityFirmwareComponents,
+				provisioner.CapabilityBMCEventSubscription,
+			},
+			unsupported: []provisioner.Capability{
+				provisioner.CapabilityDataImage,
+				provisioner.CapabilityHealth,
+			},
+		},
+		{
+			name:        "virtual media get",
+			maxVersion:  93,
+			supported:   []provisioner.Capability{provisioner.CapabilityDataImage},
+			unsupported: []provisioner.Capability{provisioner.CapabilityHealth},
+		},
+		{
+			name:       "health",
+			maxVersion: 109,
+			supported:  provisioner.AllCapabilities,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prov, err := newProvisionerWithSettings(makeHost(), bmc.Credentials{}, nullEventPublisher, "https://ironic.test/v1/", clients.AuthConfig{Type: clients.NoAuth})
+			require.NoError(t, err)
+			prov.availableFeatures = clients.AvailableFeatures{MaxVersion: tc.maxVersion}
+
+			for _, capability := range tc.supported {
+				require.NoError(t, provisioner.CheckCapability(t.Context(), prov, capability))
+			}
+			for _, capability := range tc.unsupported {
+				err := provisioner.CheckCapability(t.Context(), prov, capability)
+				require.ErrorIs(t, err, provisioner.ErrNotSupported)
+				assert.Equal(t, string(capability)+" is not supported by provisioner ironic", err.Error())
+			}
+		})
+	}
+}
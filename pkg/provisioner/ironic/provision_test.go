@@ -2,11 +2,13 @@ package ironic
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
 	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1/profile"
 	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
@@ -431,6 +433,7 @@ func TestDeprovisionSyncAutomatedClean(t *testing.T) {
 				UUID:           nodeUUID,
 				AutomatedClean: tc.nodeAutomatedClean,
 			})
+			ironic.ResponseJSON("/v1/ports:"+http.MethodGet, map[string][]ports.Port{"ports": {}})
 			ironic.Start()
 			defer ironic.Stop()
 
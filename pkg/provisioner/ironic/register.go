@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
@@ -113,6 +114,13 @@ func (p *ironicProvisioner) Register(ctx context.Context, data provisioner.Manag
 		return result, "", err
 	}
 
+	if err = p.ensureBMCCABundle(ctx); err != nil {
+		msg := fmt.Sprintf("failed to set up the verification of the BMC certificate: %s", err)
+		p.log.Info(msg)
+		result, err = operationFailed(msg)
+		return result, "", err
+	}
+
 	driverInfo := bmcAccess.DriverInfo(p.bmcCreds)
 	driverInfo = setExternalURL(p, driverInfo)
 
@@ -142,13 +150,14 @@ func (p *ironicProvisioner) Register(ctx context.Context, data provisioner.Manag
 		updater.SetTopLevelOpt("name", ironicNodeName(p.objectMeta), ironicNode.Name)
 
 		bmcAddressChanged := !bmcAddressMatches(ironicNode, driverInfo)
+		bmcVerifyCAChanged := !bmcVerifyCAMatches(ironicNode, driverInfo)
 
 		// The actual password is not returned from ironic, so we want to
-		// update the whole DriverInfo only if the credentials or BMC address
-		// has changed, otherwise we will be writing on every call to this
-		// function.
-		if credentialsChanged || bmcAddressChanged {
-			p.log.Info("Updating driver info because the credentials and/or the BMC address changed")
+		// update the whole DriverInfo only if the credentials, BMC address
+		// or CA bundle has changed, otherwise we will be writing on every
+		// call to this function.
+		if credentialsChanged || bmcAddressChanged || bmcVerifyCAChanged {
+			p.log.Info("Updating driver info because the credentials, the BMC address and/or the BMC CA bundle changed")
 			updater.SetTopLevelOpt("driver_info", driverInfo, ironicNode.DriverInfo)
 		}
 
@@ -169,6 +178,17 @@ func (p *ironicProvisioner) Register(ctx context.Context, data provisioner.Manag
 		// below.
 	}
 
+	// Keep the conductor group and the shard in sync with the host. Both
+	// predate the baseline API version, no feature check is needed. The
+	// shard cannot be set on creation.
+	updater.SetTopLevelOpt("conductor_group", conductorGroup(data), ironicNode.ConductorGroup)
+	if data.NodeShard != "" {
+		updater.SetTopLevelOpt("shard", data.NodeShard, ironicNode.Shard)
+	} else if ironicNode.Shard != "" {
+		// Unlike the conductor group, an unset shard is null
+		updater.SetTopLevelOpt("shard", nil, ironicNode.Shard)
+	}
+
 	// NOTE(dtantsur): don't try to create ports in states where it's
 	// either impossible because of a lock or potentially disruptive.
 	switch nodes.ProvisionState(ironicNode.ProvisionState) {
@@ -187,6 +207,18 @@ func (p *ironicProvisioner) Register(ctx context.Context, data provisioner.Manag
 	default:
 	}
 
+	// The switch port a NIC is connected to can only be changed while the
+	// node is not deployed.
+	switch nodes.ProvisionState(ironicNode.ProvisionState) {
+	case nodes.Enroll, nodes.Manageable, nodes.Available:
+		err = p.setLocalLinkConnections(ctx, ironicNode, data.NetworkInterfaces, data.HardwareData)
+		if err != nil {
+			result, err = transientError(err)
+			return result, provID, err
+		}
+	default:
+	}
+
 	// If no PreprovisioningImage builder is enabled we set the Node network_data
 	// this enables Ironic to inject the network_data into the ramdisk image
 	if !p.config.havePreprovImgBuilder {
@@ -277,6 +309,12 @@ func inspectInterfaceForMode(mode metal3api.InspectionMode, bmcAccess bmc.Access
 	return defaultInspectInterface
 }
 
+// conductorGroup returns the conductor group of the host the way Ironic
+// stores it, in lower case.
+func conductorGroup(data provisioner.ManagementAccessData) string {
+	return strings.ToLower(data.ConductorGroup)
+}
+
 func (p *ironicProvisioner) enrollNode(ctx context.Context, data provisioner.ManagementAccessData, bmcAccess bmc.AccessDetails, driverInfo map[string]any) (ironicNode *nodes.Node, retry bool, err error) {
 	nodeCreateOpts := nodes.CreateOpts{
 		Driver:              bmcAccess.Driver(),
@@ -292,6 +330,7 @@ func (p *ironicProvisioner) enrollNode(ctx context.Context, data provisioner.Man
 		RAIDInterface:       bmcAccess.RAIDInterface(),
 		VendorInterface:     bmcAccess.VendorInterface(),
 		DisablePowerOff:     &data.DisablePowerOff,
+		ConductorGroup:      conductorGroup(data),
 		Properties: map[string]any{
 			"capabilities": buildCapabilitiesValue(nil, data.BootMode),
 			"cpu_arch":     data.CPUArchitecture,
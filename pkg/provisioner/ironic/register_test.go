@@ -136,6 +136,110 @@ func TestRegisterCreateNode(t *testing.T) {
 	assert.Equal(t, "agent", createdNode.InspectInterface)
 }
 
+func TestRegisterCreateNodeConductorGroupAndShard(t *testing.T) {
+	host := makeHost()
+	host.Spec.BootMACAddress = ""
+	host.Spec.Image = nil
+	host.Status.Provisioning.ID = "" // so we don't lookup by uuid
+
+	var createdNode *nodes.Node
+
+	createCallback := func(node nodes.Node) {
+		createdNode = &node
+	}
+
+	ironic := testserver.NewIronic(t).WithDrivers().CreateNodes(createCallback).NoNode(host.Namespace + nameSeparator + host.Name).NoNode(host.Name)
+	ironic.AddDefaultResponse("/v1/nodes/node-0", "PATCH", http.StatusOK, `{"uuid": "node-0", "provision_state": "inspecting"}`)
+	ironic.Start()
+	defer ironic.Stop()
+
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+	prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+	if err != nil {
+		t.Fatalf("could not create provisioner: %s", err)
+	}
+
+	data := provisioner.ManagementAccessData{ConductorGroup: "Row-1", NodeShard: "shard-a"}
+	result, _, err := prov.Register(t.Context(), data, false, false)
+	if err != nil {
+		t.Fatalf("error from Register: %s", err)
+	}
+	assert.Empty(t, result.ErrorMessage)
+	assert.Equal(t, "row-1", createdNode.ConductorGroup)
+
+	updates := ironic.GetLastNodeUpdateRequestFor("node-0")
+	assert.Contains(t, updates, nodes.UpdateOperation{Op: nodes.AddOp, Path: "/shard", Value: "shard-a"})
+	for _, update := range updates {
+		assert.NotEqual(t, "/conductor_group", update.Path, "conductor group is set on creation")
+	}
+}
+
+func TestRegisterExistingNodeConductorGroupAndShard(t *testing.T) {
+	testCases := []struct {
+		Scenario        string
+		Data            provisioner.ManagementAccessData
+		ExpectedUpdates []nodes.UpdateOperation
+	}{
+		{
+			Scenario: "unchanged",
+			Data:     provisioner.ManagementAccessData{ConductorGroup: "Row-1", NodeShard: "shard-a"},
+		},
+		{
+			Scenario: "changed",
+			Data:     provisioner.ManagementAccessData{ConductorGroup: "row-2", NodeShard: "shard-b"},
+			ExpectedUpdates: []nodes.UpdateOperation{
+				{Op: nodes.AddOp, Path: "/conductor_group", Value: "row-2"},
+				{Op: nodes.AddOp, Path: "/shard", Value: "shard-b"},
+			},
+		},
+		{
+			Scenario: "removed",
+			ExpectedUpdates: []nodes.UpdateOperation{
+				{Op: nodes.AddOp, Path: "/conductor_group", Value: ""},
+				{Op: nodes.RemoveOp, Path: "/shard"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			host := makeHost()
+			host.Spec.BootMACAddress = ""
+			host.Status.Provisioning.ID = "uuid"
+
+			node := nodes.Node{
+				Name:           host.Namespace + nameSeparator + host.Name,
+				UUID:           "uuid",
+				ProvisionState: string(nodes.Inspecting),
+				DriverInfo: map[string]any{
+					"test_address":  "test.bmc",
+					"test_username": "",
+					"test_password": "******",
+					"test_port":     "42",
+				},
+				ConductorGroup: "row-1",
+				Shard:          "shard-a",
+			}
+			ironic := testserver.NewIronic(t).Node(node).NodeUpdate(node)
+			ironic.Start()
+			defer ironic.Stop()
+
+			auth := clients.AuthConfig{Type: clients.NoAuth}
+			prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+			if err != nil {
+				t.Fatalf("could not create provisioner: %s", err)
+			}
+
+			result, _, err := prov.Register(t.Context(), tc.Data, false, false)
+			if err != nil {
+				t.Fatalf("error from Register: %s", err)
+			}
+			assert.Empty(t, result.ErrorMessage)
+			assert.ElementsMatch(t, tc.ExpectedUpdates, ironic.GetLastNodeUpdateRequestFor("uuid"))
+		})
+	}
+}
+
 func TestRegisterCreateNodeFastInspection(t *testing.T) {
 	host := makeHost()
 	host.Spec.BMC.Address = "redfish://192.168.122.1/redfish/v1/Systems/1"
@@ -0,0 +1,226 @@
+package ironic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/clients"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// defaultShard is the name of the shard of the Ironic instance at
+// IRONIC_ENDPOINT when IRONIC_SHARDS configures more instances. Hosts
+// registered before sharding was configured belong to it.
+const defaultShard = "default"
+
+const (
+	// shardAssignmentHash assigns hosts to shards with a consistent hash of
+	// their namespace and name.
+	shardAssignmentHash = "hash"
+	// shardAssignmentLeastLoaded assigns hosts to the shard with the fewest
+	// nodes.
+	shardAssignmentLeastLoaded = "least-loaded"
+)
+
+// shardLoadTTL is how long the node counts of the shards are reused for the
+// least-loaded assignment before the nodes are listed again.
+const shardLoadTTL = time.Minute
+
+var errUnknownShard = errors.New("unknown Ironic shard")
+
+// ironicShard is one of the Ironic instances of a sharded factory.
+type ironicShard struct {
+	clientIronic *gophercloud.ServiceClient
+	cache        *ironicProvisionerCache
+}
+
+// shardLoad caches the number of nodes of each shard for the least-loaded
+// assignment. The hosts assigned since the nodes were listed are counted
+// too, their nodes may not exist yet.
+type shardLoad struct {
+	lock      sync.Mutex
+	expiresAt time.Time
+	counts    map[string]int
+}
+
+// loadShardsFromEnv returns the endpoints of the additional Ironic instances
+// listed in IRONIC_SHARDS as comma-separated name=endpoint pairs, and the
+// assignment strategy from IRONIC_SHARD_ASSIGNMENT.
+func loadShardsFromEnv() (endpoints map[string]string, assignment string, err error) {
+	shardsString := os.Getenv("IRONIC_SHARDS")
+	if shardsString == "" {
+		return nil, "", nil
+	}
+
+	endpoints = make(map[string]string)
+	for entry := range strings.SplitSeq(shardsString, ",") {
+		name, endpoint, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" || endpoint == "" {
+			return nil, "", fmt.Errorf("invalid IRONIC_SHARDS entry %q, expected name=endpoint", entry)
+		}
+		if errs := validation.IsValidLabelValue(name); len(errs) > 0 {
+			return nil, "", fmt.Errorf("invalid IRONIC_SHARDS name %q: %s", name, strings.Join(errs, ", "))
+		}
+		if _, exists := endpoints[name]; exists || name == defaultShard {
+			return nil, "", fmt.Errorf("duplicate IRONIC_SHARDS name %q", name)
+		}
+		endpoints[name] = endpoint
+	}
+
+	assignment = os.Getenv("IRONIC_SHARD_ASSIGNMENT")
+	switch assignment {
+	case "":
+		assignment = shardAssignmentHash
+	case shardAssignmentHash, shardAssignmentLeastLoaded:
+	default:
+		return nil, "", fmt.Errorf("invalid IRONIC_SHARD_ASSIGNMENT %q, must be one of %s or %s",
+			assignment, shardAssignmentHash, shardAssignmentLeastLoaded)
+	}
+
+	return endpoints, assignment, nil
+}
+
+func (f ironicProvisionerFactory) shardNames() []string {
+	names := make([]string, 0, len(f.shards))
+	for name := range f.shards {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// shardClient returns a verified client for the Ironic instance of the shard.
+func (f ironicProvisionerFactory) shardClient(ctx context.Context, name string) (*gophercloud.ServiceClient, error) {
+	client, _, err := f.refreshShardCache(ctx, name)
+	return client, err
+}
+
+func (f ironicProvisionerFactory) refreshShardCache(ctx context.Context, name string) (*gophercloud.ServiceClient, clients.AvailableFeatures, error) {
+	shard := f.shards[name]
+	return f.refreshCacheOf(ctx, shard.cache, func() (*gophercloud.ServiceClient, error) {
+		// NOTE(dtantsur): refreshCache mutates the client by setting Microversion.
+		// Make a shallow copy of the shard client object to avoid data races.
+		clientCopy := *shard.clientIronic
+		return &clientCopy, nil
+	})
+}
+
+// selectShard returns the shard managing the host. The shard label of the
+// host wins, then the shard recorded in its status, which the controller
+// sets on the first selection. Other hosts are assigned a shard with the
+// configured strategy, unless a node already exists for them in one of the
+// shards.
+func (f ironicProvisionerFactory) selectShard(ctx context.Context, hostData provisioner.HostData) (string, error) {
+	name := hostData.ObjectMeta.Labels[metal3api.ShardLabel]
+	if name == "" {
+		name = hostData.Shard
+	}
+	if name == "" && hostData.ProvisionerID != "" {
+		name = defaultShard
+	}
+	if name != "" {
+		if _, ok := f.shards[name]; !ok {
+			return "", fmt.Errorf("%w %q", errUnknownShard, name)
+		}
+		return name, nil
+	}
+
+	// The status of the host may have been lost, e.g. by moving it to
+	// another cluster, look for the node it had
+	name, err := f.findShardOfNode(ctx, ironicNodeName(hostData.ObjectMeta))
+	if err != nil || name != "" {
+		return name, err
+	}
+
+	if f.shardAssignment == shardAssignmentLeastLoaded {
+		return f.leastLoadedShard(ctx)
+	}
+	return hashShard(f.shardNames(), hostData.ObjectMeta.Namespace+"/"+hostData.ObjectMeta.Name), nil
+}
+
+func (f ironicProvisionerFactory) findShardOfNode(ctx context.Context, nodeName string) (string, error) {
+	for _, name := range f.shardNames() {
+		client, err := f.shardClient(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("cannot look for the node in Ironic shard %s: %w", name, err)
+		}
+		_, err = nodes.Get(ctx, client, nodeName).Extract()
+		if err == nil {
+			return name, nil
+		}
+		if !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return "", fmt.Errorf("cannot look for the node in Ironic shard %s: %w", name, err)
+		}
+	}
+	return "", nil
+}
+
+func (f ironicProvisionerFactory) leastLoadedShard(ctx context.Context) (string, error) {
+	load := f.shardLoad
+	load.lock.Lock()
+	defer load.lock.Unlock()
+
+	if load.counts == nil || time.Now().After(load.expiresAt) {
+		counts := make(map[string]int, len(f.shards))
+		for _, name := range f.shardNames() {
+			count, err := f.countShardNodes(ctx, name)
+			if err != nil {
+				return "", fmt.Errorf("cannot count the nodes of Ironic shard %s: %w", name, err)
+			}
+			counts[name] = count
+		}
+		load.counts = counts
+		load.expiresAt = time.Now().Add(shardLoadTTL)
+	}
+
+	selected := ""
+	for _, name := range f.shardNames() {
+		if selected == "" || load.counts[name] < load.counts[selected] {
+			selected = name
+		}
+	}
+	load.counts[selected]++
+	return selected, nil
+}
+
+func (f ironicProvisionerFactory) countShardNodes(ctx context.Context, name string) (int, error) {
+	client, err := f.shardClient(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	page, err := nodes.List(client, nodes.ListOpts{Fields: []string{"uuid"}}).AllPages(ctx)
+	if err != nil {
+		return 0, err
+	}
+	shardNodes, err := nodes.ExtractNodes(page)
+	if err != nil {
+		return 0, err
+	}
+	return len(shardNodes), nil
+}
+
+// hashShard picks a shard with rendezvous hashing, so that adding or removing
+// a shard only moves the hosts assigned to it.
+func hashShard(names []string, key string) string {
+	selected, selectedScore := "", uint64(0)
+	for _, name := range names {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(name + "\x00" + key))
+		if score := h.Sum64(); selected == "" || score > selectedScore {
+			selected, selectedScore = name, score
+		}
+	}
+	return selected
+}
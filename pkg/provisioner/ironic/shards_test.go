@@ -0,0 +1,252 @@
+package ironic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadShardsFromEnv(t *testing.T) {
+	cases := []struct {
+		name               string
+		shards             string
+		assignment         string
+		expectedEndpoints  map[string]string
+		expectedAssignment string
+		expectedError      string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:               "default assignment",
+			shards:             "east=http://east:6385, west=http://west:6385",
+			expectedEndpoints:  map[string]string{"east": "http://east:6385", "west": "http://west:6385"},
+			expectedAssignment: shardAssignmentHash,
+		},
+		{
+			name:               "least loaded",
+			shards:             "east=http://east:6385",
+			assignment:         shardAssignmentLeastLoaded,
+			expectedEndpoints:  map[string]string{"east": "http://east:6385"},
+			expectedAssignment: shardAssignmentLeastLoaded,
+		},
+		{
+			name:          "missing endpoint",
+			shards:        "east",
+			expectedError: "invalid IRONIC_SHARDS entry",
+		},
+		{
+			name:          "invalid name",
+			shards:        "east/1=http://east:6385",
+			expectedError: "invalid IRONIC_SHARDS name",
+		},
+		{
+			name:          "duplicate name",
+			shards:        "east=http://east:6385,east=http://other:6385",
+			expectedError: "duplicate IRONIC_SHARDS name",
+		},
+		{
+			name:          "default name",
+			shards:        "default=http://east:6385",
+			expectedError: "duplicate IRONIC_SHARDS name",
+		},
+		{
+			name:          "invalid assignment",
+			shards:        "east=http://east:6385",
+			assignment:    "random",
+			expectedError: "invalid IRONIC_SHARD_ASSIGNMENT",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("IRONIC_SHARDS", tc.shards)
+			t.Setenv("IRONIC_SHARD_ASSIGNMENT", tc.assignment)
+
+			endpoints, assignment, err := loadShardsFromEnv()
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEndpoints, endpoints)
+			assert.Equal(t, tc.expectedAssignment, assignment)
+		})
+	}
+}
+
+func TestHashShard(t *testing.T) {
+	names := []string{"default", "east", "west"}
+	counts := map[string]int{}
+	for i := range 300 {
+		key := fmt.Sprintf("metal3/host-%d", i)
+		selected := hashShard(names, key)
+		assert.Equal(t, selected, hashShard(names, key), "assignment must be stable")
+		counts[selected]++
+
+		// Adding a shard only moves hosts to the new shard
+		moved := hashShard(append([]string{"north"}, names...), key)
+		if moved != "north" {
+			assert.Equal(t, selected, moved)
+		}
+	}
+	for _, name := range names {
+		assert.Positive(t, counts[name], "no host assigned to shard %s", name)
+	}
+}
+
+func newShardedTestFactory(t *testing.T, assignment string, servers map[string]*testserver.IronicMock) ironicProvisionerFactory {
+	t.Helper()
+	factory := ironicProvisionerFactory{
+		log:             logr.Discard(),
+		shards:          make(map[string]*ironicShard),
+		shardAssignment: assignment,
+		shardLoad:       new(shardLoad),
+	}
+	for name, server := range servers {
+		server.Start()
+		t.Cleanup(server.Stop)
+		factory.shards[name] = &ironicShard{
+			clientIronic: newFakeIronicClient(server.Endpoint()),
+			cache:        new(ironicProvisionerCache),
+		}
+	}
+	return factory
+}
+
+func TestSelectShard(t *testing.T) {
+	hostMeta := metav1.ObjectMeta{Name: "host", Namespace: "metal3"}
+	nodeName := ironicNodeName(hostMeta)
+
+	cases := []struct {
+		name          string
+		hostData      provisioner.HostData
+		assignment    string
+		defaultNodes  []nodes.Node
+		eastNodes     []nodes.Node
+		eastHasNode   bool
+		expectedShard string
+		expectedError string
+	}{
+		{
+			name: "label",
+			hostData: provisioner.HostData{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "host",
+					Namespace: "metal3",
+					Labels:    map[string]string{metal3api.ShardLabel: "east"},
+				},
+				Shard: defaultShard,
+			},
+			expectedShard: "east",
+		},
+		{
+			name:          "recorded shard",
+			hostData:      provisioner.HostData{ObjectMeta: hostMeta, Shard: "east", ProvisionerID: "uuid"},
+			expectedShard: "east",
+		},
+		{
+			name:          "registered before sharding",
+			hostData:      provisioner.HostData{ObjectMeta: hostMeta, ProvisionerID: "uuid"},
+			expectedShard: defaultShard,
+		},
+		{
+			name:          "unknown shard",
+			hostData:      provisioner.HostData{ObjectMeta: hostMeta, Shard: "north"},
+			expectedError: "unknown Ironic shard \"north\"",
+		},
+		{
+			name:          "existing node",
+			hostData:      provisioner.HostData{ObjectMeta: hostMeta},
+			eastHasNode:   true,
+			expectedShard: "east",
+		},
+		{
+			name:          "hash",
+			hostData:      provisioner.HostData{ObjectMeta: hostMeta},
+			assignment:    shardAssignmentHash,
+			expectedShard: hashShard([]string{defaultShard, "east"}, "metal3/host"),
+		},
+		{
+			name:          "least loaded",
+			hostData:      provisioner.HostData{ObjectMeta: hostMeta},
+			assignment:    shardAssignmentLeastLoaded,
+			defaultNodes:  []nodes.Node{{UUID: "1"}, {UUID: "2"}},
+			eastNodes:     []nodes.Node{{UUID: "3"}},
+			expectedShard: "east",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			east := testserver.NewIronic(t).WithDrivers().Nodes(tc.eastNodes)
+			if tc.eastHasNode {
+				east = east.Node(nodes.Node{Name: nodeName, UUID: "uuid"})
+			} else {
+				east = east.NoNode(nodeName)
+			}
+			servers := map[string]*testserver.IronicMock{
+				defaultShard: testserver.NewIronic(t).WithDrivers().NoNode(nodeName).Nodes(tc.defaultNodes),
+				"east":       east,
+			}
+			factory := newShardedTestFactory(t, tc.assignment, servers)
+
+			shard, err := factory.selectShard(t.Context(), tc.hostData)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedShard, shard)
+		})
+	}
+}
+
+func TestLeastLoadedShardCountsAssignedHosts(t *testing.T) {
+	servers := map[string]*testserver.IronicMock{
+		defaultShard: testserver.NewIronic(t).WithDrivers().Nodes([]nodes.Node{{UUID: "1"}, {UUID: "2"}}),
+		"east":       testserver.NewIronic(t).WithDrivers().Nodes([]nodes.Node{{UUID: "3"}}),
+	}
+	factory := newShardedTestFactory(t, shardAssignmentLeastLoaded, servers)
+
+	var selected []string
+	for range 3 {
+		shard, err := factory.leastLoadedShard(t.Context())
+		require.NoError(t, err)
+		selected = append(selected, shard)
+	}
+
+	// The hosts assigned to east count before their nodes exist
+	assert.Equal(t, []string{"east", defaultShard, "east"}, selected)
+	for name, server := range servers {
+		assert.Equal(t, 1, strings.Count(server.Requests, "/v1/nodes;"), "nodes of %s listed more than once", name)
+	}
+}
+
+func TestShardedProvisioner(t *testing.T) {
+	hostMeta := metav1.ObjectMeta{
+		Name:      "host",
+		Namespace: "metal3",
+		Labels:    map[string]string{metal3api.ShardLabel: "east"},
+	}
+	servers := map[string]*testserver.IronicMock{
+		defaultShard: testserver.NewIronic(t).WithDrivers(),
+		"east":       testserver.NewIronic(t).WithDrivers(),
+	}
+	factory := newShardedTestFactory(t, shardAssignmentHash, servers)
+
+	prov, err := factory.ironicProvisioner(t.Context(), provisioner.HostData{ObjectMeta: hostMeta}, nullEventPublisher)
+	require.NoError(t, err)
+	assert.Equal(t, "east", prov.Shard())
+	assert.Equal(t, servers["east"].Endpoint(), prov.client.Endpoint)
+}
@@ -0,0 +1,258 @@
+package ironic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+)
+
+const (
+	// switchportExtraKey is the key in the port extra field that holds the
+	// switch port configuration consumed by ironic-networking.
+	switchportExtraKey = "switchport"
+
+	localLinkSwitchID   = "switch_id"
+	localLinkPortID     = "port_id"
+	localLinkSwitchInfo = "switch_info"
+)
+
+// getPortsWithLinkInfo lists the ports of a node including their local link
+// connection and extra fields.
+func (p *ironicProvisioner) getPortsWithLinkInfo(ctx context.Context, nodeUUID string) ([]ports.Port, error) {
+	opts := ports.ListOpts{
+		NodeUUID: nodeUUID,
+		Fields: []string{
+			"node_uuid",
+			"uuid",
+			"address",
+			"local_link_connection",
+			"extra",
+		},
+	}
+
+	allPages, err := ports.List(p.client, opts).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to page over list of ports: %w", err)
+	}
+
+	return ports.ExtractPorts(allPages)
+}
+
+// localLinkConnection builds the local_link_connection of a port from the
+// SwitchPort override of the interface or, failing that, from the LLDP data
+// discovered during inspection. It returns nil if neither is usable.
+func localLinkConnection(iface provisioner.NetworkInterfaceData, nics []metal3api.NIC) map[string]any {
+	if iface.SwitchPort != nil {
+		return map[string]any{
+			localLinkSwitchID: strings.ToLower(iface.SwitchPort.SwitchID),
+			localLinkPortID:   iface.SwitchPort.PortID,
+		}
+	}
+
+	for _, nic := range nics {
+		if !strings.EqualFold(nic.MAC, iface.MACAddress) || nic.LLDP == nil {
+			continue
+		}
+		// Ironic requires the switch ID to be a MAC address, other
+		// chassis ID subtypes cannot be used.
+		if _, err := net.ParseMAC(nic.LLDP.SwitchID); err != nil || nic.LLDP.PortID == "" {
+			return nil
+		}
+		llc := map[string]any{
+			localLinkSwitchID: strings.ToLower(nic.LLDP.SwitchID),
+			localLinkPortID:   nic.LLDP.PortID,
+		}
+		if nic.LLDP.SwitchSystemName != "" {
+			llc[localLinkSwitchInfo] = nic.LLDP.SwitchSystemName
+		}
+		return llc
+	}
+
+	return nil
+}
+
+// switchportConfig converts a HostNetworkAttachment spec into the switch
+// port configuration stored in the port extra field.
+func switchportConfig(spec *metal3api.HostNetworkAttachmentSpec) map[string]any {
+	config := map[string]any{
+		"mode":        string(spec.Mode),
+		"native_vlan": spec.NativeVLAN,
+	}
+	if len(spec.AllowedVLANs) > 0 {
+		config["allowed_vlans"] = spec.AllowedVLANs
+	}
+	if spec.MTU != nil {
+		config["mtu"] = *spec.MTU
+	}
+	return config
+}
+
+// jsonEqual compares a locally built value with one decoded from the Ironic
+// API, where numbers are float64 and lists are []any.
+func jsonEqual(local, remote any) bool {
+	raw, err := json.Marshal(local)
+	if err != nil {
+		return false
+	}
+	var normalized any
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalized, remote)
+}
+
+func findInterface(networkInterfaces []provisioner.NetworkInterfaceData, mac string) *provisioner.NetworkInterfaceData {
+	for i := range networkInterfaces {
+		if strings.EqualFold(networkInterfaces[i].MACAddress, mac) {
+			return &networkInterfaces[i]
+		}
+	}
+	return nil
+}
+
+func (p *ironicProvisioner) updatePort(ctx context.Context, port ports.Port, opts ports.UpdateOpts) error {
+	_, err := ports.Update(ctx, p.client, port.UUID, opts).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to update ironic port %s, MAC: %s: %w", port.UUID, port.Address, err)
+	}
+	return nil
+}
+
+func (p *ironicProvisioner) removeSwitchPortConfig(ctx context.Context, port ports.Port) error {
+	p.log.Info("removing switch port configuration from port", "MAC", port.Address)
+	return p.updatePort(ctx, port, ports.UpdateOpts{
+		ports.UpdateOperation{
+			Op:   ports.RemoveOp,
+			Path: "/extra/" + switchportExtraKey,
+		},
+	})
+}
+
+// setLocalLinkConnections populates the local_link_connection of the node
+// ports that correspond to the requested network interfaces.
+func (p *ironicProvisioner) setLocalLinkConnections(ctx context.Context, ironicNode *nodes.Node, networkInterfaces []provisioner.NetworkInterfaceData, hardwareData *metal3api.HardwareData) error {
+	if len(networkInterfaces) == 0 {
+		return nil
+	}
+
+	var nics []metal3api.NIC
+	if hardwareData != nil && hardwareData.Spec.HardwareDetails != nil {
+		nics = hardwareData.Spec.HardwareDetails.NIC
+	}
+
+	nodePorts, err := p.getPortsWithLinkInfo(ctx, ironicNode.UUID)
+	if err != nil {
+		return err
+	}
+
+	for _, port := range nodePorts {
+		iface := findInterface(networkInterfaces, port.Address)
+		if iface == nil {
+			continue
+		}
+
+		llc := localLinkConnection(*iface, nics)
+		if llc == nil {
+			p.log.Info("no switch port information available for interface", "MAC", port.Address)
+			continue
+		}
+		if jsonEqual(llc, port.LocalLinkConnection) {
+			continue
+		}
+
+		p.log.Info("updating local link connection of port", "MAC", port.Address, "localLinkConnection", llc)
+		err = p.updatePort(ctx, port, ports.UpdateOpts{
+			ports.UpdateOperation{
+				Op:    ports.AddOp,
+				Path:  "/local_link_connection",
+				Value: llc,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applySwitchPortConfig stores the requested switch port configuration on
+// the node ports so that it is applied to the switch while the host is
+// deployed. Ports without a requested attachment have any previous
+// configuration removed. It returns true if any port was changed.
+func (p *ironicProvisioner) applySwitchPortConfig(ctx context.Context, ironicNode *nodes.Node, networkInterfaces []provisioner.NetworkInterfaceData) (changed bool, err error) {
+	if len(networkInterfaces) == 0 {
+		return false, nil
+	}
+
+	nodePorts, err := p.getPortsWithLinkInfo(ctx, ironicNode.UUID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, port := range nodePorts {
+		current, hasCurrent := port.Extra[switchportExtraKey]
+
+		iface := findInterface(networkInterfaces, port.Address)
+		if iface == nil || iface.Attachment == nil {
+			if !hasCurrent {
+				continue
+			}
+			if err = p.removeSwitchPortConfig(ctx, port); err != nil {
+				return changed, err
+			}
+			changed = true
+			continue
+		}
+
+		config := switchportConfig(iface.Attachment)
+		if hasCurrent && jsonEqual(config, current) {
+			continue
+		}
+
+		p.log.Info("setting switch port configuration of port", "MAC", port.Address, "config", config)
+		err = p.updatePort(ctx, port, ports.UpdateOpts{
+			ports.UpdateOperation{
+				Op:    ports.AddOp,
+				Path:  "/extra/" + switchportExtraKey,
+				Value: config,
+			},
+		})
+		if err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// clearSwitchPortConfig removes the switch port configuration from all ports
+// of the node so that the default configuration is used for cleaning.
+func (p *ironicProvisioner) clearSwitchPortConfig(ctx context.Context, ironicNode *nodes.Node) (changed bool, err error) {
+	nodePorts, err := p.getPortsWithLinkInfo(ctx, ironicNode.UUID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, port := range nodePorts {
+		if _, ok := port.Extra[switchportExtraKey]; !ok {
+			continue
+		}
+
+		if err = p.removeSwitchPortConfig(ctx, port); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,309 @@
+package ironic
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/hardwareutils/bmc"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/clients"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/ironic/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestLocalLinkConnection(t *testing.T) {
+	nics := []metal3api.NIC{
+		{
+			MAC: "00:11:22:33:44:55",
+			LLDP: &metal3api.LLDP{
+				SwitchID:         "AA:BB:CC:DD:EE:FF",
+				PortID:           "Ethernet1/1",
+				SwitchSystemName: "leaf-1",
+			},
+		},
+		{
+			MAC: "00:11:22:33:44:56",
+			LLDP: &metal3api.LLDP{
+				SwitchID: "leaf-1.example.com",
+				PortID:   "Ethernet1/2",
+			},
+		},
+		{
+			MAC: "00:11:22:33:44:57",
+		},
+	}
+
+	cases := []struct {
+		name     string
+		iface    provisioner.NetworkInterfaceData
+		expected map[string]any
+	}{
+		{
+			name:  "from LLDP",
+			iface: provisioner.NetworkInterfaceData{MACAddress: "00:11:22:33:44:55"},
+			expected: map[string]any{
+				"switch_id":   "aa:bb:cc:dd:ee:ff",
+				"port_id":     "Ethernet1/1",
+				"switch_info": "leaf-1",
+			},
+		},
+		{
+			name: "override",
+			iface: provisioner.NetworkInterfaceData{
+				MACAddress: "00:11:22:33:44:55",
+				SwitchPort: &metal3api.SwitchPort{
+					SwitchID: "11:22:33:44:55:66",
+					PortID:   "Ethernet2/1",
+				},
+			},
+			expected: map[string]any{
+				"switch_id": "11:22:33:44:55:66",
+				"port_id":   "Ethernet2/1",
+			},
+		},
+		{
+			name:  "chassis ID is not a MAC",
+			iface: provisioner.NetworkInterfaceData{MACAddress: "00:11:22:33:44:56"},
+		},
+		{
+			name:  "no LLDP",
+			iface: provisioner.NetworkInterfaceData{MACAddress: "00:11:22:33:44:57"},
+		},
+		{
+			name:  "unknown NIC",
+			iface: provisioner.NetworkInterfaceData{MACAddress: "00:11:22:33:44:58"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, localLinkConnection(tc.iface, nics))
+		})
+	}
+}
+
+func TestSwitchportConfig(t *testing.T) {
+	spec := &metal3api.HostNetworkAttachmentSpec{
+		Mode:         metal3api.SwitchportModeTrunk,
+		NativeVLAN:   100,
+		AllowedVLANs: []string{"200", "300-310"},
+		MTU:          ptr.To(9000),
+	}
+
+	config := switchportConfig(spec)
+	assert.Equal(t, map[string]any{
+		"mode":          "trunk",
+		"native_vlan":   100,
+		"allowed_vlans": []string{"200", "300-310"},
+		"mtu":           9000,
+	}, config)
+
+	var remote any
+	require.NoError(t, json.Unmarshal([]byte(`{"mode": "trunk", "native_vlan": 100, "allowed_vlans": ["200", "300-310"], "mtu": 9000}`), &remote))
+	assert.True(t, jsonEqual(config, remote))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"mode": "trunk", "native_vlan": 101, "allowed_vlans": ["200", "300-310"], "mtu": 9000}`), &remote))
+	assert.False(t, jsonEqual(config, remote))
+}
+
+func TestApplySwitchPortConfig(t *testing.T) {
+	nodeUUID := "33ce8659-7400-4c68-9535-d10766f07a58"
+	portUUID := "7b1a4e3c-7f4b-4b0a-9c1e-1d6b8e3f2a10"
+	attachment := &metal3api.HostNetworkAttachmentSpec{
+		Mode:       metal3api.SwitchportModeAccess,
+		NativeVLAN: 100,
+	}
+
+	cases := []struct {
+		name              string
+		extra             map[string]any
+		networkInterfaces []provisioner.NetworkInterfaceData
+		expectedChanged   bool
+		expectedOp        ports.UpdateOp
+	}{
+		{
+			name: "set configuration",
+			networkInterfaces: []provisioner.NetworkInterfaceData{
+				{MACAddress: "00:11:22:33:44:55", Attachment: attachment},
+			},
+			expectedChanged: true,
+			expectedOp:      ports.AddOp,
+		},
+		{
+			name: "configuration up to date",
+			extra: map[string]any{
+				"switchport": map[string]any{"mode": "access", "native_vlan": 100},
+			},
+			networkInterfaces: []provisioner.NetworkInterfaceData{
+				{MACAddress: "00:11:22:33:44:55", Attachment: attachment},
+			},
+		},
+		{
+			name: "remove configuration",
+			extra: map[string]any{
+				"switchport": map[string]any{"mode": "access", "native_vlan": 100},
+			},
+			networkInterfaces: []provisioner.NetworkInterfaceData{
+				{MACAddress: "00:11:22:33:44:66", Attachment: attachment},
+			},
+			expectedChanged: true,
+			expectedOp:      ports.RemoveOp,
+		},
+		{
+			name: "no network interfaces",
+			extra: map[string]any{
+				"switchport": map[string]any{"mode": "access", "native_vlan": 100},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			port := ports.Port{
+				UUID:     portUUID,
+				NodeUUID: nodeUUID,
+				Address:  "00:11:22:33:44:55",
+				Extra:    tc.extra,
+			}
+			ironic := testserver.NewIronic(t).Port(port)
+			ironic.ResponseJSON("/v1/ports/"+portUUID+":"+http.MethodPatch, port)
+			ironic.Start()
+			defer ironic.Stop()
+
+			host := makeHost()
+			auth := clients.AuthConfig{Type: clients.NoAuth}
+			prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+			require.NoError(t, err)
+
+			changed, err := prov.applySwitchPortConfig(t.Context(), &nodes.Node{UUID: nodeUUID}, tc.networkInterfaces)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedChanged, changed)
+
+			body, found := ironic.GetLastRequestFor("/v1/ports/"+portUUID, http.MethodPatch)
+			assert.Equal(t, tc.expectedChanged, found)
+			if found {
+				var updates []ports.UpdateOperation
+				require.NoError(t, json.Unmarshal([]byte(body), &updates))
+				require.Len(t, updates, 1)
+				assert.Equal(t, tc.expectedOp, updates[0].Op)
+				assert.Equal(t, "/extra/switchport", updates[0].Path)
+			}
+		})
+	}
+}
+
+func TestSetLocalLinkConnections(t *testing.T) {
+	nodeUUID := "33ce8659-7400-4c68-9535-d10766f07a58"
+	portUUID := "7b1a4e3c-7f4b-4b0a-9c1e-1d6b8e3f2a10"
+	hardwareData := &metal3api.HardwareData{
+		Spec: metal3api.HardwareDataSpec{
+			HardwareDetails: &metal3api.HardwareDetails{
+				NIC: []metal3api.NIC{
+					{
+						MAC: "00:11:22:33:44:55",
+						LLDP: &metal3api.LLDP{
+							SwitchID: "aa:bb:cc:dd:ee:ff",
+							PortID:   "Ethernet1/1",
+						},
+					},
+				},
+			},
+		},
+	}
+	networkInterfaces := []provisioner.NetworkInterfaceData{
+		{MACAddress: "00:11:22:33:44:55"},
+	}
+
+	cases := []struct {
+		name            string
+		current         map[string]any
+		expectedChanged bool
+	}{
+		{
+			name:            "set from LLDP",
+			expectedChanged: true,
+		},
+		{
+			name: "up to date",
+			current: map[string]any{
+				"switch_id": "aa:bb:cc:dd:ee:ff",
+				"port_id":   "Ethernet1/1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			port := ports.Port{
+				UUID:                portUUID,
+				NodeUUID:            nodeUUID,
+				Address:             "00:11:22:33:44:55",
+				LocalLinkConnection: tc.current,
+			}
+			ironic := testserver.NewIronic(t).Port(port)
+			ironic.ResponseJSON("/v1/ports/"+portUUID+":"+http.MethodPatch, port)
+			ironic.Start()
+			defer ironic.Stop()
+
+			host := makeHost()
+			auth := clients.AuthConfig{Type: clients.NoAuth}
+			prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+			require.NoError(t, err)
+
+			err = prov.setLocalLinkConnections(t.Context(), &nodes.Node{UUID: nodeUUID}, networkInterfaces, hardwareData)
+			require.NoError(t, err)
+
+			body, found := ironic.GetLastRequestFor("/v1/ports/"+portUUID, http.MethodPatch)
+			assert.Equal(t, tc.expectedChanged, found)
+			if found {
+				assert.JSONEq(t, `[{"op": "add", "path": "/local_link_connection", "value": {"switch_id": "aa:bb:cc:dd:ee:ff", "port_id": "Ethernet1/1"}}]`, body)
+			}
+		})
+	}
+}
+
+func TestDeprovisionClearsSwitchPortConfig(t *testing.T) {
+	nodeUUID := "33ce8659-7400-4c68-9535-d10766f07a58"
+	portUUID := "7b1a4e3c-7f4b-4b0a-9c1e-1d6b8e3f2a10"
+	port := ports.Port{
+		UUID:     portUUID,
+		NodeUUID: nodeUUID,
+		Address:  "00:11:22:33:44:55",
+		Extra: map[string]any{
+			"switchport": map[string]any{"mode": "access", "native_vlan": 100},
+		},
+	}
+	ironic := testserver.NewIronic(t).WithDefaultResponses().Node(nodes.Node{
+		ProvisionState: string(nodes.Active),
+		UUID:           nodeUUID,
+		AutomatedClean: ptr.To(true),
+	}).Port(port)
+	ironic.ResponseJSON("/v1/ports/"+portUUID+":"+http.MethodPatch, port)
+	ironic.Start()
+	defer ironic.Stop()
+
+	// The host has no network interfaces any more, the configuration of
+	// the tenant is removed nonetheless.
+	host := makeHost()
+	host.Status.Provisioning.ID = nodeUUID
+	auth := clients.AuthConfig{Type: clients.NoAuth}
+	prov, err := newProvisionerWithSettings(host, bmc.Credentials{}, nullEventPublisher, ironic.Endpoint(), auth)
+	require.NoError(t, err)
+
+	_, err = prov.Deprovision(t.Context(), false, metal3api.CleaningModeMetadata)
+	require.NoError(t, err)
+
+	body, found := ironic.GetLastRequestFor("/v1/ports/"+portUUID, http.MethodPatch)
+	require.True(t, found)
+	var updates []ports.UpdateOperation
+	require.NoError(t, json.Unmarshal([]byte(body), &updates))
+	require.Len(t, updates, 1)
+	assert.Equal(t, ports.RemoveOp, updates[0].Op)
+	assert.Equal(t, "/extra/switchport", updates[0].Path)
+}
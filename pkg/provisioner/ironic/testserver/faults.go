@@ -0,0 +1,138 @@
+package testserver
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Fault is a failure injected by the server in its responses, to test how
+// clients cope with a slow or unreliable Ironic.
+type Fault struct {
+	// Latency delays the response.
+	Latency time.Duration
+
+	// Code replaces the response with an error with this status code, e.g.
+	// http.StatusServiceUnavailable or http.StatusConflict.
+	Code int
+
+	// Drop closes the connection without sending a response.
+	Drop bool
+
+	// Every injects the fault in one matching request out of Every,
+	// starting with the first one. Zero injects it in all of them.
+	Every int
+
+	// Times is the number of times the fault is injected, zero meaning
+	// no limit.
+	Times int
+}
+
+type injectedFault struct {
+	Fault
+
+	method   string
+	re       *regexp.Regexp
+	requests int
+	injected int
+}
+
+// due counts a matching request and returns whether the fault is injected
+// in it.
+func (f *injectedFault) due() bool {
+	f.requests++
+	if f.Times > 0 && f.injected >= f.Times {
+		return false
+	}
+	if f.Every > 1 && (f.requests-1)%f.Every != 0 {
+		return false
+	}
+	f.injected++
+	return true
+}
+
+// WithFault injects a fault in the responses to the requests for the
+// specified pattern/method. Patterns use the syntax of AddDefaultResponse.
+// If httpMethod is empty, the fault is injected for any method. When
+// several faults match a request, the first one added that is due is
+// injected.
+func (m *MockServer) WithFault(patternWithVars string, httpMethod string, fault Fault) *MockServer {
+	m.t.Logf("%s: adding fault for [%s] %s: %+v", m.name, httpMethod, patternWithVars, fault)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.faults = append(m.faults, &injectedFault{
+		Fault:  fault,
+		method: httpMethod,
+		re:     compilePattern(patternWithVars),
+	})
+	return m
+}
+
+// InjectedFaults returns the number of faults injected in the responses so
+// far.
+func (m *MockServer) InjectedFaults() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.injectedFaults
+}
+
+// nextFault returns the fault to inject in the response to r, if any.
+func (m *MockServer) nextFault(r *http.Request) (Fault, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, fault := range m.faults {
+		if fault.method != "" && fault.method != r.Method {
+			continue
+		}
+		if !fault.re.MatchString(r.URL.Path) {
+			continue
+		}
+		if fault.due() {
+			m.injectedFaults++
+			return fault.Fault, true
+		}
+	}
+	return Fault{}, false
+}
+
+func (m *MockServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	fault, ok := m.nextFault(r)
+	if !ok {
+		m.mux.ServeHTTP(w, r)
+		return
+	}
+
+	if fault.Latency > 0 {
+		m.t.Logf("%s: delaying [%s] %s by %s", m.name, r.Method, r.URL, fault.Latency)
+		select {
+		case <-time.After(fault.Latency):
+		case <-r.Context().Done():
+			m.logRequest(r, "client gone")
+			return
+		}
+	}
+
+	switch {
+	case fault.Drop:
+		m.logRequest(r, "connection dropped")
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			m.t.Errorf("%s: cannot drop the connection of %s", m.name, r.URL)
+			return
+		}
+		conn, _, err := hijacker.Hijack()
+		if err != nil {
+			m.t.Errorf("%s: cannot drop the connection of %s: %s", m.name, r.URL, err)
+			return
+		}
+		conn.Close()
+	case fault.Code != 0:
+		m.logRequest(r, strconv.Itoa(fault.Code))
+		http.Error(w, "An injected error", fault.Code)
+	default:
+		m.mux.ServeHTTP(w, r)
+	}
+}
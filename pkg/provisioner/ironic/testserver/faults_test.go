@@ -0,0 +1,78 @@
+package testserver
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+)
+
+func get(t *testing.T, url string) (int, string, error) {
+	t.Helper()
+	resp, err := http.Get(url) // #nosec
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body), err
+}
+
+func TestFaultEveryAndTimes(t *testing.T) {
+	ironic := NewIronic(t).WithDefaultResponses().
+		WithFault("/v1/nodes/{id}", http.MethodGet, Fault{Code: http.StatusServiceUnavailable, Every: 2, Times: 2})
+	ironic.Start()
+	defer ironic.Stop()
+
+	var codes []int
+	for range 6 {
+		code, _, err := get(t, ironic.Endpoint()+"nodes/uuid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		codes = append(codes, code)
+	}
+
+	expected := []int{503, 200, 503, 200, 200, 200}
+	for i := range expected {
+		if codes[i] != expected[i] {
+			t.Fatalf("got %v, expected %v", codes, expected)
+		}
+	}
+	if ironic.InjectedFaults() != 2 {
+		t.Errorf("expected 2 injected faults, got %d", ironic.InjectedFaults())
+	}
+}
+
+func TestFaultDrop(t *testing.T) {
+	ironic := NewIronic(t).WithDefaultResponses().
+		WithFault("/v1/nodes/{id}", "", Fault{Drop: true, Times: 1})
+	ironic.Start()
+	defer ironic.Stop()
+
+	if _, _, err := get(t, ironic.Endpoint()+"nodes/uuid"); err == nil {
+		t.Error("expected the connection to be dropped")
+	}
+	if code, _, err := get(t, ironic.Endpoint()+"nodes/uuid"); err != nil || code != http.StatusOK {
+		t.Errorf("expected the second request to succeed, got %d %v", code, err)
+	}
+}
+
+func TestNodeProvisionStates(t *testing.T) {
+	ironic := NewIronic(t).NodeProvisionStates(nodes.Node{UUID: "uuid"},
+		nodes.Deploying, nodes.DeployFail, nodes.Active)
+	ironic.Start()
+	defer ironic.Stop()
+
+	for _, expected := range []string{`"deploying"`, `"deploy failed"`, `"active"`, `"active"`} {
+		_, body, err := get(t, ironic.Endpoint()+"nodes/uuid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(body, `"provision_state":`+expected) {
+			t.Errorf("expected provision state %s, got %s", expected, body)
+		}
+	}
+}
@@ -150,6 +150,42 @@ func (m *IronicMock) Node(node nodes.Node) *IronicMock {
 	return m
 }
 
+// NodeSequence configures the server so successive requests for
+// /v1/nodes/{name,uuid} return the nodes in order, the last one being
+// returned from then on. It simulates the transitions of a node, including
+// ones a real Ironic would not make.
+func (m *IronicMock) NodeSequence(sequence ...nodes.Node) *IronicMock {
+	payloads := make([]string, 0, len(sequence))
+	for _, node := range sequence {
+		content, err := json.Marshal(node)
+		if err != nil {
+			m.t.Error(err)
+		}
+		payloads = append(payloads, string(content))
+	}
+
+	node := sequence[0]
+	if node.UUID != "" {
+		m.ResponseSequence(m.buildURL(v1node+node.UUID, http.MethodGet), http.StatusOK, payloads...)
+	}
+	if node.Name != "" {
+		m.ResponseSequence(m.buildURL(v1node+node.Name, http.MethodGet), http.StatusOK, payloads...)
+	}
+	return m
+}
+
+// NodeProvisionStates configures the server so successive requests for
+// /v1/nodes/{name,uuid} return the node in each of the provision states in
+// order, e.g. deploying, deploy failed and then active.
+func (m *IronicMock) NodeProvisionStates(node nodes.Node, states ...nodes.ProvisionState) *IronicMock {
+	sequence := make([]nodes.Node, 0, len(states))
+	for _, state := range states {
+		node.ProvisionState = string(state)
+		sequence = append(sequence, node)
+	}
+	return m.NodeSequence(sequence...)
+}
+
 // NodeUpdateError configures the server with an error response for [PATCH] /v1/nodes/{id}.
 func (m *IronicMock) NodeUpdateError(id string, errorCode int) *IronicMock {
 	m.ResponseWithCode(m.buildURL(v1node+id, http.MethodPatch), "", errorCode)
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -29,6 +30,9 @@ func New(t *testing.T, name string) *MockServer {
 type response struct {
 	code    int
 	payload string
+	// next are the payloads of the following requests, the last one is
+	// repeated.
+	next []string
 }
 
 type defaultResponse struct {
@@ -54,6 +58,10 @@ type MockServer struct {
 	server            *httptest.Server
 	responsesByMethod map[string]map[string]response
 	defaultResponses  []defaultResponse
+
+	lock           sync.Mutex
+	faults         []*injectedFault
+	injectedFaults int
 }
 
 // Endpoint returns the URL to the server.
@@ -92,7 +100,18 @@ func (m *MockServer) Handler(pattern string, handlerFunc http.HandlerFunc) *Mock
 
 func (m *MockServer) buildHandler(_ string) func(http.ResponseWriter, *http.Request) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
-		if resp, ok := m.responsesByMethod[r.URL.Path][r.Method]; ok {
+		m.lock.Lock()
+		resp, ok := m.responsesByMethod[r.URL.Path][r.Method]
+		if ok && len(resp.next) > 0 {
+			m.responsesByMethod[r.URL.Path][r.Method] = response{
+				code:    resp.code,
+				payload: resp.next[0],
+				next:    resp.next[1:],
+			}
+		}
+		m.lock.Unlock()
+
+		if ok {
 			m.sendData(w, r, resp.code, resp.payload)
 			return
 		}
@@ -137,6 +156,23 @@ func (m *MockServer) ResponseWithCode(patternWithMethod string, payload string,
 	return m
 }
 
+// ResponseSequence attaches a handler function that returns the given
+// payloads, one per request in order, from requests to the URL pattern along
+// with the specified code. The last payload is returned to all the following
+// requests.
+func (m *MockServer) ResponseSequence(patternWithMethod string, code int, payloads ...string) *MockServer {
+	if len(payloads) == 0 {
+		m.t.Fatal("ResponseSequence needs at least one payload")
+	}
+	m.ResponseWithCode(patternWithMethod, payloads[0], code)
+
+	pattern, method := m.parsePattern(patternWithMethod)
+	resp := m.responsesByMethod[pattern][method]
+	resp.next = payloads[1:]
+	m.responsesByMethod[pattern][method] = resp
+	return m
+}
+
 // ResponseJSON marshals the JSON object as payload returned by the response
 // handler.
 func (m *MockServer) ResponseJSON(pattern string, payload any) *MockServer {
@@ -161,7 +197,7 @@ func (m *MockServer) ErrorResponse(pattern string, errorCode int) *MockServer {
 
 // Start runs the server.
 func (m *MockServer) Start() *MockServer {
-	m.server = httptest.NewServer(m.mux)
+	m.server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
 	// catch all handler
 	m.mux.HandleFunc("/", m.defaultHandler)
 	return m
@@ -201,11 +237,11 @@ func (m *MockServer) GetLastRequestFor(pattern string, method string) (string, b
 // Pattern variables can be reused in the payload, so that they will be substituted with the actual value when sending the response
 // If httpMethod is empty, the response will be applied for any method.
 func (m *MockServer) AddDefaultResponse(patternWithVars string, httpMethod string, code int, payload string) *MockServer {
-	pattern := "^" + regexp.MustCompile("{(.[^}]*)}").ReplaceAllString(patternWithVars, "(?P<$1>.[^/]*)") + "$"
-	m.t.Logf("%s: adding default response for %s (%s) -> {%d, %s}", m.name, patternWithVars, pattern, code, payload)
+	re := compilePattern(patternWithVars)
+	m.t.Logf("%s: adding default response for %s (%s) -> {%d, %s}", m.name, patternWithVars, re, code, payload)
 
 	defaultResp := defaultResponse{
-		re:     regexp.MustCompile(pattern),
+		re:     re,
 		method: httpMethod,
 		response: response{
 			code:    code,
@@ -217,6 +253,13 @@ func (m *MockServer) AddDefaultResponse(patternWithVars string, httpMethod strin
 	return m
 }
 
+// compilePattern turns a pattern with variables in curly braces into a
+// regular expression matching the whole path.
+func compilePattern(patternWithVars string) *regexp.Regexp {
+	pattern := "^" + regexp.MustCompile("{(.[^}]*)}").ReplaceAllString(patternWithVars, "(?P<$1>.[^/]*)") + "$"
+	return regexp.MustCompile(pattern)
+}
+
 func (m *MockServer) defaultHandler(w http.ResponseWriter, r *http.Request) {
 	url := r.URL.Path
 	method := r.Method
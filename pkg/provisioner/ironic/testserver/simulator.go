@@ -0,0 +1,904 @@
+package testserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/inventory"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+)
+
+// IronicSimulator is an in-process Ironic keeping the state of its nodes and
+// ports, so that the provisioner and the controllers can be driven through
+// whole lifecycles without programming every response.
+//
+// A transition of the provision state goes through the transient states
+// Ironic would report, one per request for the node, e.g. a deployment is
+// deploying when first read and active when read again. Power changes
+// complete the same way. Faults can be injected with WithFault like with
+// any MockServer.
+type IronicSimulator struct {
+	*MockServer
+
+	db            sync.Mutex
+	nodes         map[string]map[string]any
+	ports         map[string]map[string]any
+	transitions   map[string]*transition
+	inventories   map[string]nodes.InventoryData
+	inspections   map[string]nodes.InventoryData
+	bios          map[string][]nodes.BIOSSetting
+	firmware      map[string][]nodes.FirmwareComponent
+	vmedia        map[string][]map[string]any
+	subscriptions map[string]map[string]any
+	failures      map[string]map[nodes.TargetProvisionState]string
+	nextID        int
+}
+
+// simulatorDrivers are the drivers enabled in the simulator.
+var simulatorDrivers = []string{"idrac", "ilo", "ipmi", "irmc", "redfish"}
+
+// NewIronicSimulator builds an Ironic simulator with no nodes.
+func NewIronicSimulator(t *testing.T) *IronicSimulator {
+	t.Helper()
+	s := &IronicSimulator{
+		MockServer:    New(t, "ironic-simulator"),
+		nodes:         make(map[string]map[string]any),
+		ports:         make(map[string]map[string]any),
+		transitions:   make(map[string]*transition),
+		inventories:   make(map[string]nodes.InventoryData),
+		inspections:   make(map[string]nodes.InventoryData),
+		bios:          make(map[string][]nodes.BIOSSetting),
+		firmware:      make(map[string][]nodes.FirmwareComponent),
+		vmedia:        make(map[string][]map[string]any),
+		subscriptions: make(map[string]map[string]any),
+		failures:      make(map[string]map[nodes.TargetProvisionState]string),
+	}
+	s.AddDefaultResponse("/v1/?", "", http.StatusOK, fmt.Sprintf(versionedRootResult, "1.95"))
+
+	s.Handler("GET /v1/drivers", s.listDrivers)
+	s.Handler("GET /v1/drivers/{driver}", s.getDriver)
+
+	s.Handler("GET /v1/nodes", s.listNodes)
+	s.Handler("POST /v1/nodes", s.createNode)
+	s.Handler("GET /v1/nodes/{node}", s.getNode)
+	s.Handler("PATCH /v1/nodes/{node}", s.updateNode)
+	s.Handler("DELETE /v1/nodes/{node}", s.deleteNode)
+	s.Handler("GET /v1/nodes/{node}/validate", s.validateNode)
+	s.Handler("PUT /v1/nodes/{node}/states/provision", s.changeProvisionState)
+	s.Handler("PUT /v1/nodes/{node}/states/power", s.changePowerState)
+	s.Handler("PUT /v1/nodes/{node}/states/raid", s.setRAIDConfig)
+	s.Handler("PUT /v1/nodes/{node}/maintenance", s.setMaintenance)
+	s.Handler("DELETE /v1/nodes/{node}/maintenance", s.unsetMaintenance)
+	s.Handler("GET /v1/nodes/{node}/bios", s.listBIOSSettings)
+	s.Handler("GET /v1/nodes/{node}/firmware", s.listFirmware)
+	s.Handler("GET /v1/nodes/{node}/inventory", s.getInventory)
+	s.Handler("GET /v1/nodes/{node}/vmedia", s.getVirtualMedia)
+	s.Handler("POST /v1/nodes/{node}/vmedia", s.attachVirtualMedia)
+	s.Handler("DELETE /v1/nodes/{node}/vmedia", s.detachVirtualMedia)
+	s.Handler("POST /v1/nodes/{node}/vendor_passthru", s.vendorPassthru)
+	s.Handler("DELETE /v1/nodes/{node}/vendor_passthru", s.vendorPassthru)
+	s.Handler("GET /v1/nodes/{node}/ports", s.listNodePorts)
+
+	s.Handler("GET /v1/ports", s.listPorts)
+	s.Handler("POST /v1/ports", s.createPort)
+	s.Handler("PATCH /v1/ports/{port}", s.updatePort)
+	s.Handler("DELETE /v1/ports/{port}", s.deletePort)
+	return s
+}
+
+// FailNext makes the next change of the node with the given name or UUID to
+// the target provision state fail with message. The node ends up in the
+// failed state of the transition, e.g. deploy failed for TargetActive.
+func (s *IronicSimulator) FailNext(node string, target nodes.TargetProvisionState, message string) *IronicSimulator {
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	if s.failures[node] == nil {
+		s.failures[node] = make(map[nodes.TargetProvisionState]string)
+	}
+	s.failures[node][target] = message
+	return s
+}
+
+// SetInventory sets the inventory found by the inspection of the node with
+// the given name or UUID. By default the inventory describes a small server
+// with a NIC for each port of the node.
+func (s *IronicSimulator) SetInventory(node string, data nodes.InventoryData) *IronicSimulator {
+	s.db.Lock()
+	defer s.db.Unlock()
+	s.inventories[node] = data
+	return s
+}
+
+// SetBIOSSettings sets the BIOS settings of the node with the given name or
+// UUID.
+func (s *IronicSimulator) SetBIOSSettings(node string, settings []nodes.BIOSSetting) *IronicSimulator {
+	s.db.Lock()
+	defer s.db.Unlock()
+	s.bios[node] = settings
+	return s
+}
+
+// SetFirmware sets the firmware components of the node with the given name
+// or UUID.
+func (s *IronicSimulator) SetFirmware(node string, components []nodes.FirmwareComponent) *IronicSimulator {
+	s.db.Lock()
+	defer s.db.Unlock()
+	s.firmware[node] = components
+	return s
+}
+
+// GetNode returns the node with the given name or UUID as currently stored,
+// without advancing its transitions.
+func (s *IronicSimulator) GetNode(ident string) (node nodes.Node, found bool) {
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	doc := s.findNode(ident)
+	if doc == nil {
+		return node, false
+	}
+	content, err := json.Marshal(doc)
+	if err == nil {
+		err = json.Unmarshal(content, &node)
+	}
+	if err != nil {
+		s.t.Error(err)
+	}
+	return node, true
+}
+
+// ListNodes returns all the nodes as currently stored.
+func (s *IronicSimulator) ListNodes() []nodes.Node {
+	s.db.Lock()
+	uuids := make([]string, 0, len(s.nodes))
+	for uuid := range s.nodes {
+		uuids = append(uuids, uuid)
+	}
+	s.db.Unlock()
+	slices.Sort(uuids)
+
+	result := make([]nodes.Node, 0, len(uuids))
+	for _, uuid := range uuids {
+		if node, ok := s.GetNode(uuid); ok {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// newID returns a new identifier. Like in the mock, it does not have to be
+// a UUID.
+func (s *IronicSimulator) newID(kind string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", kind, s.nextID)
+}
+
+// findNode returns the node with the given name or UUID.
+func (s *IronicSimulator) findNode(ident string) map[string]any {
+	if node, ok := s.nodes[ident]; ok {
+		return node
+	}
+	for _, node := range s.nodes {
+		if ident != "" && node["name"] == ident {
+			return node
+		}
+	}
+	return nil
+}
+
+// forNode returns the value set for the node in a map keyed by node name
+// or UUID.
+func forNode[T any](values map[string]T, node map[string]any) (value T, found bool) {
+	if value, found = values[stringField(node, "uuid")]; found {
+		return value, found
+	}
+	if name := stringField(node, "name"); name != "" {
+		value, found = values[name]
+	}
+	return value, found
+}
+
+func stringField(doc map[string]any, field string) string {
+	value, _ := doc[field].(string)
+	return value
+}
+
+func mapField(doc map[string]any, field string) map[string]any {
+	value, _ := doc[field].(map[string]any)
+	return value
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// readBody decodes the body of the request into v, leaving it in place so
+// that the request is logged in full.
+func readBody(r *http.Request, v any) error {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// sendError sends an error in the format of the Ironic API.
+func (s *IronicSimulator) sendError(w http.ResponseWriter, r *http.Request, code int, format string, args ...any) {
+	fault, _ := json.Marshal(map[string]any{
+		"faultstring": fmt.Sprintf(format, args...),
+		"faultcode":   "Client",
+		"debuginfo":   nil,
+	})
+	s.SendJSONResponse(map[string]string{"error_message": string(fault)}, code, w, r)
+}
+
+// lockedNode locks the database and returns the node of the request, or
+// sends an error and returns nil.
+func (s *IronicSimulator) lockedNode(w http.ResponseWriter, r *http.Request) map[string]any {
+	s.db.Lock()
+	node := s.findNode(r.PathValue("node"))
+	if node == nil {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusNotFound, "Node %s could not be found.", r.PathValue("node"))
+	}
+	return node
+}
+
+func (s *IronicSimulator) listDrivers(w http.ResponseWriter, r *http.Request) {
+	drivers := make([]map[string]any, 0, len(simulatorDrivers))
+	for _, driver := range simulatorDrivers {
+		drivers = append(drivers, map[string]any{"name": driver, "hosts": []string{"simulator"}})
+	}
+	s.SendJSONResponse(map[string]any{"drivers": drivers}, http.StatusOK, w, r)
+}
+
+func (s *IronicSimulator) getDriver(w http.ResponseWriter, r *http.Request) {
+	driver := r.PathValue("driver")
+	if !slices.Contains(simulatorDrivers, driver) {
+		s.sendError(w, r, http.StatusNotFound, "Driver %s could not be found.", driver)
+		return
+	}
+	s.SendJSONResponse(map[string]any{
+		"name":                       driver,
+		"hosts":                      []string{"simulator"},
+		"enabled_deploy_interfaces":  []string{"direct", "ramdisk", "custom-agent"},
+		"enabled_inspect_interfaces": []string{"agent", "no-inspect", driver},
+	}, http.StatusOK, w, r)
+}
+
+// nodeListIgnoredParams are the parameters of a node or port list that are
+// not filters.
+var nodeListIgnoredParams = []string{"fields", "limit", "marker", "sort_dir", "sort_key", "detail"}
+
+func (s *IronicSimulator) listNodes(w http.ResponseWriter, r *http.Request) {
+	s.db.Lock()
+	result := []map[string]any{}
+	for _, node := range s.nodes {
+		if matchesQuery(node, r, nil) {
+			result = append(result, node)
+		}
+	}
+	slices.SortFunc(result, func(a, b map[string]any) int {
+		return strings.Compare(stringField(a, "uuid"), stringField(b, "uuid"))
+	})
+	content, err := json.Marshal(map[string]any{"nodes": result})
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusOK, string(content))
+}
+
+// matchesQuery checks the fields of a document against the filters of a
+// list request. Filters named in aliases are checked against other fields.
+func matchesQuery(doc map[string]any, r *http.Request, aliases map[string]func(map[string]any, string) bool) bool {
+	for param, values := range r.URL.Query() {
+		if slices.Contains(nodeListIgnoredParams, param) || len(values) == 0 {
+			continue
+		}
+		if match, ok := aliases[param]; ok {
+			if !match(doc, values[0]) {
+				return false
+			}
+			continue
+		}
+		value := doc[param]
+		if value == nil {
+			value = ""
+		}
+		if fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *IronicSimulator) createNode(w http.ResponseWriter, r *http.Request) {
+	node := map[string]any{}
+	if err := readBody(r, &node); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid node: %s", err)
+		return
+	}
+
+	s.db.Lock()
+	name := stringField(node, "name")
+	if name != "" && s.findNode(name) != nil {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusConflict, "A node with name %s already exists.", name)
+		return
+	}
+	uuid := stringField(node, "uuid")
+	if uuid == "" {
+		uuid = s.newID("node")
+	}
+	defaults := map[string]any{
+		"uuid":                   uuid,
+		"provision_state":        string(nodes.Enroll),
+		"target_provision_state": nil,
+		"power_state":            nil,
+		"target_power_state":     nil,
+		"maintenance":            false,
+		"driver_info":            map[string]any{},
+		"driver_internal_info":   map[string]any{},
+		"instance_info":          map[string]any{},
+		"properties":             map[string]any{},
+		"extra":                  map[string]any{},
+		"raid_config":            map[string]any{},
+		"target_raid_config":     map[string]any{},
+		"conductor_group":        "",
+		"created_at":             now(),
+		"provision_updated_at":   now(),
+	}
+	for field, value := range defaults {
+		if _, ok := node[field]; !ok || field == "uuid" {
+			node[field] = value
+		}
+	}
+	s.nodes[uuid] = node
+	content, err := json.Marshal(node)
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusCreated, string(content))
+}
+
+func (s *IronicSimulator) getNode(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	s.advance(node)
+	content, err := json.Marshal(node)
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusOK, string(content))
+}
+
+func (s *IronicSimulator) updateNode(w http.ResponseWriter, r *http.Request) {
+	var patch []nodes.UpdateOperation
+	if err := readBody(r, &patch); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid patch: %s", err)
+		return
+	}
+
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	if _, busy := s.transitions[stringField(node, "uuid")]; busy {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusConflict, "Node %s is locked by host simulator, please retry after the current operation is completed.", stringField(node, "uuid"))
+		return
+	}
+	updated, err := applyPatch(node, patch)
+	if err != nil {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "%s", err)
+		return
+	}
+	updated["updated_at"] = now()
+	s.nodes[stringField(node, "uuid")] = updated
+	content, err := json.Marshal(updated)
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusOK, string(content))
+}
+
+// applyPatch applies a JSON patch to a copy of a document.
+func applyPatch(doc map[string]any, patch []nodes.UpdateOperation) (map[string]any, error) {
+	var updated map[string]any
+	content, err := json.Marshal(doc)
+	if err == nil {
+		err = json.Unmarshal(content, &updated)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range patch {
+		path := strings.Split(strings.TrimPrefix(op.Path, "/"), "/")
+		parent := updated
+		for _, field := range path[:len(path)-1] {
+			child, ok := parent[field].(map[string]any)
+			if !ok {
+				if op.Op == nodes.RemoveOp {
+					return nil, fmt.Errorf("can't remove non-existent object '%s'", op.Path)
+				}
+				child = map[string]any{}
+				parent[field] = child
+			}
+			parent = child
+		}
+
+		last := path[len(path)-1]
+		switch op.Op {
+		case nodes.AddOp, nodes.ReplaceOp:
+			var value any
+			content, err := json.Marshal(op.Value)
+			if err == nil {
+				err = json.Unmarshal(content, &value)
+			}
+			if err != nil {
+				return nil, err
+			}
+			parent[last] = value
+		case nodes.RemoveOp:
+			if _, ok := parent[last]; !ok {
+				return nil, fmt.Errorf("can't remove non-existent object '%s'", op.Path)
+			}
+			if len(path) == 1 {
+				// Top-level fields are reset rather than removed
+				parent[last] = nil
+			} else {
+				delete(parent, last)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported patch operation %s", op.Op)
+		}
+	}
+	return updated, nil
+}
+
+// deletableStates are the provision states in which a node can be deleted.
+var deletableStates = []nodes.ProvisionState{
+	nodes.Enroll, nodes.Manageable, nodes.Available, nodes.InspectFail, nodes.CleanFail, nodes.AdoptFail, nodes.Error,
+}
+
+func (s *IronicSimulator) deleteNode(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	uuid := stringField(node, "uuid")
+	state := nodes.ProvisionState(stringField(node, "provision_state"))
+	if !slices.Contains(deletableStates, state) && !(state == nodes.Active && node["maintenance"] == true) {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusConflict, "Can not delete node %s while it is in state \"%s\".", uuid, state)
+		return
+	}
+	delete(s.nodes, uuid)
+	delete(s.transitions, uuid)
+	delete(s.vmedia, uuid)
+	delete(s.inspections, uuid)
+	for id, port := range s.ports {
+		if port["node_uuid"] == uuid {
+			delete(s.ports, id)
+		}
+	}
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusNoContent, "")
+}
+
+func (s *IronicSimulator) validateNode(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	deploy := map[string]any{"result": true}
+	deployInterface := stringField(node, "deploy_interface")
+	if stringField(mapField(node, "instance_info"), "image_source") == "" &&
+		deployInterface != "ramdisk" && deployInterface != "custom-agent" {
+		deploy = map[string]any{"result": false, "reason": "Some parameters were missing in node's instance_info. Missing are: ['image_source']"}
+	}
+	s.db.Unlock()
+
+	s.SendJSONResponse(map[string]any{
+		"boot":       map[string]any{"result": true},
+		"deploy":     deploy,
+		"inspect":    map[string]any{"result": true},
+		"management": map[string]any{"result": true},
+		"power":      map[string]any{"result": true},
+	}, http.StatusOK, w, r)
+}
+
+func (s *IronicSimulator) setRAIDConfig(w http.ResponseWriter, r *http.Request) {
+	config := map[string]any{}
+	if err := readBody(r, &config); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid RAID configuration: %s", err)
+		return
+	}
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	node["target_raid_config"] = config
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusNoContent, "")
+}
+
+func (s *IronicSimulator) setMaintenance(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		Reason string `json:"reason"`
+	}{}
+	if err := readBody(r, &body); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid maintenance request: %s", err)
+		return
+	}
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	node["maintenance"] = true
+	node["maintenance_reason"] = body.Reason
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusAccepted, "")
+}
+
+func (s *IronicSimulator) unsetMaintenance(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	node["maintenance"] = false
+	node["maintenance_reason"] = nil
+	node["fault"] = nil
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusAccepted, "")
+}
+
+// defaultBIOSSettings are the BIOS settings of the nodes by default.
+var defaultBIOSSettings = []nodes.BIOSSetting{
+	{Name: "L2Cache", Value: "10x256 KB"},
+	{Name: "NumCores", Value: "10"},
+	{Name: "ProcVirtualization", Value: "Enabled"},
+}
+
+func (s *IronicSimulator) biosSettings(node map[string]any) []nodes.BIOSSetting {
+	if settings, ok := forNode(s.bios, node); ok {
+		return settings
+	}
+	return defaultBIOSSettings
+}
+
+func (s *IronicSimulator) listBIOSSettings(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	settings := s.biosSettings(node)
+	s.db.Unlock()
+
+	s.SendJSONResponse(map[string]any{"bios": settings}, http.StatusOK, w, r)
+}
+
+func (s *IronicSimulator) firmwareComponents(node map[string]any) []nodes.FirmwareComponent {
+	if components, ok := forNode(s.firmware, node); ok {
+		return components
+	}
+	return []nodes.FirmwareComponent{
+		{Component: "bios", InitialVersion: "1.0.0", CurrentVersion: "1.0.0"},
+		{Component: "bmc", InitialVersion: "1.0.0", CurrentVersion: "1.0.0"},
+	}
+}
+
+func (s *IronicSimulator) listFirmware(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	components := s.firmwareComponents(node)
+	s.db.Unlock()
+
+	s.SendJSONResponse(map[string]any{"firmware": components}, http.StatusOK, w, r)
+}
+
+func (s *IronicSimulator) getInventory(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	data, found := s.inspections[stringField(node, "uuid")]
+	s.db.Unlock()
+
+	if !found {
+		s.sendError(w, r, http.StatusNotFound, "Inventory not found for node %s", r.PathValue("node"))
+		return
+	}
+	s.SendJSONResponse(data, http.StatusOK, w, r)
+}
+
+// defaultInventory describes a small server with a NIC for each port of the
+// node.
+func (s *IronicSimulator) defaultInventory(node map[string]any) nodes.InventoryData {
+	uuid := stringField(node, "uuid")
+	data := nodes.InventoryData{
+		Inventory: inventory.InventoryType{
+			Hostname: stringField(node, "name"),
+			CPU: inventory.CPUType{
+				Architecture: "x86_64",
+				Count:        8,
+				Frequency:    "2400.000",
+				ModelName:    "Simulated CPU",
+			},
+			Memory: inventory.MemoryType{PhysicalMb: 16384, Total: 16 * 1024 * 1024 * 1024},
+			Disks: []inventory.RootDiskType{
+				{Name: "/dev/sda", Model: "Simulated Disk", Size: 500 * 1024 * 1024 * 1024, Serial: uuid + "-sda"},
+			},
+			SystemVendor: inventory.SystemVendorType{
+				Manufacturer: "Metal3",
+				ProductName:  "Ironic Simulator",
+				SerialNumber: uuid,
+			},
+			Boot: inventory.BootInfoType{CurrentBootMode: "uefi"},
+		},
+	}
+	data.PluginData.RawMessage = json.RawMessage("{}")
+
+	ids := make([]string, 0, len(s.ports))
+	for id, port := range s.ports {
+		if port["node_uuid"] == uuid {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	for i, id := range ids {
+		data.Inventory.Interfaces = append(data.Inventory.Interfaces, inventory.InterfaceType{
+			Name:        fmt.Sprintf("eth%d", i),
+			MACAddress:  stringField(s.ports[id], "address"),
+			IPV4Address: fmt.Sprintf("192.168.111.%d", 20+i),
+			HasCarrier:  true,
+			SpeedMbps:   10000,
+		})
+	}
+	return data
+}
+
+func (s *IronicSimulator) getVirtualMedia(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	media := s.vmedia[stringField(node, "uuid")]
+	if media == nil {
+		media = []map[string]any{}
+	}
+	content, err := json.Marshal(media)
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusOK, string(content))
+}
+
+func (s *IronicSimulator) attachVirtualMedia(w http.ResponseWriter, r *http.Request) {
+	opts := nodes.AttachVirtualMediaOpts{}
+	if err := readBody(r, &opts); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid virtual media: %s", err)
+		return
+	}
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	uuid := stringField(node, "uuid")
+	s.vmedia[uuid] = append(s.vmedia[uuid], map[string]any{
+		"image":       opts.ImageURL,
+		"inserted":    true,
+		"media_types": []string{string(opts.DeviceType)},
+	})
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusNoContent, "")
+}
+
+func (s *IronicSimulator) detachVirtualMedia(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	delete(s.vmedia, stringField(node, "uuid"))
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusNoContent, "")
+}
+
+func (s *IronicSimulator) vendorPassthru(w http.ResponseWriter, r *http.Request) {
+	body := map[string]any{}
+	if err := readBody(r, &body); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid vendor passthru call: %s", err)
+		return
+	}
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	defer s.db.Unlock()
+
+	switch method := r.URL.Query().Get("method"); method {
+	case "create_subscription":
+		id := s.newID("subscription")
+		body["Id"] = id
+		body["node_uuid"] = stringField(node, "uuid")
+		s.subscriptions[id] = body
+		s.SendJSONResponse(body, http.StatusOK, w, r)
+	case "delete_subscription":
+		id, _ := body["id"].(string)
+		if _, ok := s.subscriptions[id]; !ok {
+			s.sendError(w, r, http.StatusNotFound, "Subscription %s could not be found.", id)
+			return
+		}
+		delete(s.subscriptions, id)
+		s.sendData(w, r, http.StatusNoContent, "")
+	default:
+		s.sendError(w, r, http.StatusBadRequest, "Unsupported vendor passthru method %s", method)
+	}
+}
+
+// portAliases match the filters of a port list that are not fields of the
+// port.
+func (s *IronicSimulator) portAliases() map[string]func(map[string]any, string) bool {
+	return map[string]func(map[string]any, string) bool{
+		"node": func(port map[string]any, ident string) bool {
+			node := s.findNode(ident)
+			return node != nil && port["node_uuid"] == node["uuid"]
+		},
+	}
+}
+
+func (s *IronicSimulator) sendPorts(w http.ResponseWriter, r *http.Request, nodeUUID string) {
+	s.db.Lock()
+	result := []map[string]any{}
+	for _, port := range s.ports {
+		if nodeUUID != "" && port["node_uuid"] != nodeUUID {
+			continue
+		}
+		if matchesQuery(port, r, s.portAliases()) {
+			result = append(result, port)
+		}
+	}
+	slices.SortFunc(result, func(a, b map[string]any) int {
+		return strings.Compare(stringField(a, "uuid"), stringField(b, "uuid"))
+	})
+	content, err := json.Marshal(map[string]any{"ports": result})
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusOK, string(content))
+}
+
+func (s *IronicSimulator) listPorts(w http.ResponseWriter, r *http.Request) {
+	s.sendPorts(w, r, "")
+}
+
+func (s *IronicSimulator) listNodePorts(w http.ResponseWriter, r *http.Request) {
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	uuid := stringField(node, "uuid")
+	s.db.Unlock()
+
+	s.sendPorts(w, r, uuid)
+}
+
+func (s *IronicSimulator) createPort(w http.ResponseWriter, r *http.Request) {
+	port := map[string]any{}
+	if err := readBody(r, &port); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid port: %s", err)
+		return
+	}
+
+	s.db.Lock()
+	address := strings.ToLower(stringField(port, "address"))
+	node := s.findNode(stringField(port, "node_uuid"))
+	if node == nil {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "Node %s could not be found.", stringField(port, "node_uuid"))
+		return
+	}
+	for _, existing := range s.ports {
+		if existing["address"] == address {
+			s.db.Unlock()
+			s.sendError(w, r, http.StatusConflict, "A port with MAC address %s already exists.", address)
+			return
+		}
+	}
+	uuid := s.newID("port")
+	port["uuid"] = uuid
+	port["address"] = address
+	port["node_uuid"] = node["uuid"]
+	port["created_at"] = now()
+	if _, ok := port["pxe_enabled"]; !ok {
+		port["pxe_enabled"] = true
+	}
+	s.ports[uuid] = port
+	content, err := json.Marshal(port)
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusCreated, string(content))
+}
+
+func (s *IronicSimulator) updatePort(w http.ResponseWriter, r *http.Request) {
+	var patch []nodes.UpdateOperation
+	if err := readBody(r, &patch); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid patch: %s", err)
+		return
+	}
+
+	s.db.Lock()
+	port, ok := s.ports[r.PathValue("port")]
+	if !ok {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusNotFound, "Port %s could not be found.", r.PathValue("port"))
+		return
+	}
+	updated, err := applyPatch(port, patch)
+	if err != nil {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "%s", err)
+		return
+	}
+	s.ports[r.PathValue("port")] = updated
+	content, err := json.Marshal(updated)
+	s.db.Unlock()
+
+	if err != nil {
+		s.t.Error(err)
+	}
+	s.sendData(w, r, http.StatusOK, string(content))
+}
+
+func (s *IronicSimulator) deletePort(w http.ResponseWriter, r *http.Request) {
+	s.db.Lock()
+	_, ok := s.ports[r.PathValue("port")]
+	delete(s.ports, r.PathValue("port"))
+	s.db.Unlock()
+
+	if !ok {
+		s.sendError(w, r, http.StatusNotFound, "Port %s could not be found.", r.PathValue("port"))
+		return
+	}
+	s.sendData(w, r, http.StatusNoContent, "")
+}
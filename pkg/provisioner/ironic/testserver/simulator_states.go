@@ -0,0 +1,355 @@
+package testserver
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+)
+
+const (
+	powerOn  = "power on"
+	powerOff = "power off"
+)
+
+// transition is a change of provision state in progress.
+type transition struct {
+	// through are the transient states still to go through.
+	through []nodes.ProvisionState
+	// to is the state the transition ends in.
+	to nodes.ProvisionState
+	// failure is the error the transition ends with, if set.
+	failure string
+	// failed is the state the transition ends in on failure.
+	failed nodes.ProvisionState
+	// done applies the effects of a successful transition to the node.
+	done func(node map[string]any)
+}
+
+// plan returns the transition of a node from the current provision state
+// for the target, or nil if Ironic does not allow it.
+func (s *IronicSimulator) plan(node map[string]any, opts nodes.ProvisionStateOpts) *transition {
+	current := nodes.ProvisionState(stringField(node, "provision_state"))
+	uuid := stringField(node, "uuid")
+	allowed := func(states ...nodes.ProvisionState) bool {
+		return slices.Contains(states, current)
+	}
+
+	switch opts.Target {
+	case nodes.TargetManage:
+		if current == nodes.Enroll {
+			return &transition{
+				through: []nodes.ProvisionState{nodes.Verifying},
+				to:      nodes.Manageable,
+				failed:  nodes.Enroll,
+				done: func(node map[string]any) {
+					if node["power_state"] == nil {
+						node["power_state"] = powerOff
+					}
+				},
+			}
+		}
+		if allowed(nodes.Available, nodes.InspectFail, nodes.CleanFail, nodes.AdoptFail) {
+			return &transition{to: nodes.Manageable}
+		}
+
+	case nodes.TargetInspect:
+		if allowed(nodes.Manageable, nodes.InspectFail) {
+			return &transition{
+				through: []nodes.ProvisionState{nodes.Inspecting, nodes.InspectWait},
+				to:      nodes.Manageable,
+				failed:  nodes.InspectFail,
+				done: func(node map[string]any) {
+					data, ok := forNode(s.inventories, node)
+					if !ok {
+						data = s.defaultInventory(node)
+					}
+					s.inspections[uuid] = data
+					node["inspection_finished_at"] = now()
+					node["power_state"] = powerOff
+				},
+			}
+		}
+
+	case nodes.TargetProvide:
+		if allowed(nodes.Manageable) {
+			t := &transition{to: nodes.Available, failed: nodes.CleanFail}
+			if automated, ok := node["automated_clean"].(bool); !ok || automated {
+				t.through = []nodes.ProvisionState{nodes.Cleaning, nodes.CleanWait}
+				t.done = func(node map[string]any) { node["power_state"] = powerOff }
+			}
+			return t
+		}
+
+	case nodes.TargetClean:
+		if allowed(nodes.Manageable) {
+			return &transition{
+				through: []nodes.ProvisionState{nodes.Cleaning, nodes.CleanWait},
+				to:      nodes.Manageable,
+				failed:  nodes.CleanFail,
+				done: func(node map[string]any) {
+					for _, step := range opts.CleanSteps {
+						s.applyStep(node, string(step.Interface), step.Step, step.Args)
+					}
+					node["power_state"] = powerOff
+				},
+			}
+		}
+
+	case nodes.TargetActive, nodes.TargetRebuild:
+		from := []nodes.ProvisionState{nodes.Available, nodes.DeployFail}
+		if opts.Target == nodes.TargetRebuild {
+			from = []nodes.ProvisionState{nodes.Active, nodes.DeployFail}
+		}
+		if allowed(from...) {
+			return &transition{
+				through: []nodes.ProvisionState{nodes.Deploying, nodes.DeployWait, nodes.Deploying},
+				to:      nodes.Active,
+				failed:  nodes.DeployFail,
+				done:    func(node map[string]any) { node["power_state"] = powerOn },
+			}
+		}
+
+	case nodes.TargetDeleted:
+		if allowed(nodes.Active, nodes.DeployFail, nodes.DeployWait, nodes.Error, nodes.ServiceFail) {
+			t := &transition{
+				through: []nodes.ProvisionState{nodes.Deleting},
+				to:      nodes.Available,
+				failed:  nodes.CleanFail,
+				done: func(node map[string]any) {
+					node["instance_info"] = map[string]any{}
+					node["power_state"] = powerOff
+				},
+			}
+			if automated, ok := node["automated_clean"].(bool); !ok || automated {
+				t.through = append(t.through, nodes.Cleaning, nodes.CleanWait)
+			}
+			return t
+		}
+
+	case nodes.TargetService:
+		if allowed(nodes.Active, nodes.ServiceFail) {
+			return &transition{
+				through: []nodes.ProvisionState{nodes.Servicing, nodes.ServiceWait},
+				to:      nodes.Active,
+				failed:  nodes.ServiceFail,
+				done: func(node map[string]any) {
+					for _, step := range opts.ServiceSteps {
+						s.applyStep(node, string(step.Interface), step.Step, step.Args)
+					}
+					node["power_state"] = powerOn
+				},
+			}
+		}
+
+	case nodes.TargetAdopt:
+		if allowed(nodes.Manageable, nodes.AdoptFail) {
+			return &transition{
+				through: []nodes.ProvisionState{nodes.Adopting},
+				to:      nodes.Active,
+				failed:  nodes.AdoptFail,
+			}
+		}
+
+	case nodes.TargetAbort:
+		aborted := map[nodes.ProvisionState]nodes.ProvisionState{
+			nodes.InspectWait: nodes.InspectFail,
+			nodes.CleanWait:   nodes.CleanFail,
+			nodes.DeployWait:  nodes.DeployFail,
+			nodes.ServiceWait: nodes.ServiceFail,
+		}
+		if failed, ok := aborted[current]; ok {
+			return &transition{
+				to:      failed,
+				failure: fmt.Sprintf("%s aborted by request.", current),
+				failed:  failed,
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyStep applies the effects of a clean or service step to the node.
+func (s *IronicSimulator) applyStep(node map[string]any, iface, step string, args map[string]any) {
+	uuid := stringField(node, "uuid")
+	var settings []map[string]any
+	list, _ := args["settings"].([]any)
+	for _, item := range list {
+		if setting, ok := item.(map[string]any); ok {
+			settings = append(settings, setting)
+		}
+	}
+
+	switch {
+	case iface == "bios" && step == "apply_configuration":
+		bios := slices.Clone(s.biosSettings(node))
+		for _, setting := range settings {
+			name := fmt.Sprint(setting["name"])
+			value := fmt.Sprint(setting["value"])
+			index := slices.IndexFunc(bios, func(existing nodes.BIOSSetting) bool { return existing.Name == name })
+			if index < 0 {
+				bios = append(bios, nodes.BIOSSetting{Name: name, Value: value})
+			} else {
+				bios[index].Value = value
+			}
+		}
+		s.bios[uuid] = bios
+
+	case iface == "firmware" && step == "update":
+		// The new version of a component is the file name of its image.
+		components := slices.Clone(s.firmwareComponents(node))
+		for _, setting := range settings {
+			component := fmt.Sprint(setting["component"])
+			version := path.Base(fmt.Sprint(setting["url"]))
+			index := slices.IndexFunc(components, func(existing nodes.FirmwareComponent) bool { return existing.Component == component })
+			if index < 0 {
+				components = append(components, nodes.FirmwareComponent{Component: component, InitialVersion: version})
+				index = len(components) - 1
+			}
+			components[index].CurrentVersion = version
+			components[index].LastVersionFlashed = version
+		}
+		s.firmware[uuid] = components
+
+	case iface == "raid" && step == "create_configuration":
+		node["raid_config"] = node["target_raid_config"]
+
+	case iface == "raid" && step == "delete_configuration":
+		node["raid_config"] = map[string]any{}
+	}
+}
+
+// advance moves the transitions of the node one step further.
+func (s *IronicSimulator) advance(node map[string]any) {
+	if target := stringField(node, "target_power_state"); target != "" {
+		node["power_state"] = target
+		if target != powerOff {
+			node["power_state"] = powerOn
+		}
+		node["target_power_state"] = nil
+	}
+
+	uuid := stringField(node, "uuid")
+	t, ok := s.transitions[uuid]
+	if !ok {
+		return
+	}
+	if len(t.through) > 0 {
+		node["provision_state"] = string(t.through[0])
+		t.through = t.through[1:]
+		return
+	}
+	delete(s.transitions, uuid)
+	s.finish(node, t)
+}
+
+// finish ends a transition of the node.
+func (s *IronicSimulator) finish(node map[string]any, t *transition) {
+	node["target_provision_state"] = nil
+	node["provision_updated_at"] = now()
+	if t.failure != "" {
+		node["provision_state"] = string(t.failed)
+		node["last_error"] = t.failure
+		return
+	}
+	node["provision_state"] = string(t.to)
+	node["last_error"] = nil
+	if t.done != nil {
+		t.done(node)
+	}
+}
+
+func (s *IronicSimulator) changeProvisionState(w http.ResponseWriter, r *http.Request) {
+	opts := nodes.ProvisionStateOpts{}
+	if err := readBody(r, &opts); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid provision state change: %s", err)
+		return
+	}
+
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	uuid := stringField(node, "uuid")
+	if _, busy := s.transitions[uuid]; busy && opts.Target != nodes.TargetAbort {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusConflict, "Node %s is locked by host simulator, please retry after the current operation is completed.", uuid)
+		return
+	}
+	if node["maintenance"] == true {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "The node %s is in maintenance mode.", uuid)
+		return
+	}
+
+	t := s.plan(node, opts)
+	if t == nil {
+		state := stringField(node, "provision_state")
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "The requested action \"%s\" can not be performed on node \"%s\" while it is in state \"%s\".",
+			opts.Target, uuid, state)
+		return
+	}
+	delete(s.transitions, uuid)
+	for _, ident := range []string{uuid, stringField(node, "name")} {
+		if message, ok := s.failures[ident][opts.Target]; ok {
+			delete(s.failures[ident], opts.Target)
+			t.failure = message
+			break
+		}
+	}
+
+	node["target_provision_state"] = string(t.to)
+	node["last_error"] = nil
+	node["provision_updated_at"] = now()
+	if len(t.through) == 0 {
+		s.finish(node, t)
+	} else {
+		node["provision_state"] = string(t.through[0])
+		t.through = t.through[1:]
+		s.transitions[uuid] = t
+	}
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusAccepted, "")
+}
+
+func (s *IronicSimulator) changePowerState(w http.ResponseWriter, r *http.Request) {
+	opts := nodes.PowerStateOpts{}
+	if err := readBody(r, &opts); err != nil {
+		s.sendError(w, r, http.StatusBadRequest, "Invalid power state change: %s", err)
+		return
+	}
+
+	node := s.lockedNode(w, r)
+	if node == nil {
+		return
+	}
+	uuid := stringField(node, "uuid")
+	if _, busy := s.transitions[uuid]; busy {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusConflict, "Node %s is locked by host simulator, please retry after the current operation is completed.", uuid)
+		return
+	}
+	if node["disable_power_off"] == true && (opts.Target == nodes.PowerOff || opts.Target == nodes.SoftPowerOff) {
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "Powering off is disabled for node %s", uuid)
+		return
+	}
+	switch opts.Target {
+	case nodes.PowerOff, nodes.SoftPowerOff:
+		node["target_power_state"] = powerOff
+	case nodes.PowerOn, nodes.Rebooting, nodes.SoftRebooting:
+		node["target_power_state"] = powerOn
+	default:
+		s.db.Unlock()
+		s.sendError(w, r, http.StatusBadRequest, "Invalid power state %s", opts.Target)
+		return
+	}
+	s.db.Unlock()
+
+	s.sendData(w, r, http.StatusAccepted, "")
+}
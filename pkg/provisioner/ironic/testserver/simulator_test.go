@@ -0,0 +1,166 @@
+package testserver
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/noauth"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/nodes"
+	"github.com/gophercloud/gophercloud/v2/openstack/baremetal/v1/ports"
+)
+
+func newSimulatorClient(t *testing.T) (*IronicSimulator, *gophercloud.ServiceClient) {
+	t.Helper()
+	simulator := NewIronicSimulator(t)
+	simulator.Start()
+	t.Cleanup(simulator.Stop)
+
+	client, err := noauth.NewBareMetalNoAuth(noauth.EndpointOpts{IronicEndpoint: simulator.Endpoint()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Microversion = "1.89"
+	return simulator, client
+}
+
+// waitForState gets the node until it reaches the state, failing after a
+// few more requests than any transition takes.
+func waitForState(t *testing.T, client *gophercloud.ServiceClient, uuid string, state nodes.ProvisionState) *nodes.Node {
+	t.Helper()
+	var seen []string
+	for range 10 {
+		node, err := nodes.Get(t.Context(), client, uuid).Extract()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node.ProvisionState == string(state) {
+			return node
+		}
+		seen = append(seen, node.ProvisionState)
+	}
+	t.Fatalf("node %s did not reach %s, went through %v", uuid, state, seen)
+	return nil
+}
+
+func changeState(t *testing.T, client *gophercloud.ServiceClient, uuid string, target nodes.TargetProvisionState) error {
+	t.Helper()
+	return nodes.ChangeProvisionState(t.Context(), client, uuid, nodes.ProvisionStateOpts{Target: target}).ExtractErr()
+}
+
+func TestSimulatorEnrollAndInspect(t *testing.T) {
+	simulator, client := newSimulatorClient(t)
+
+	node, err := nodes.Create(t.Context(), client, nodes.CreateOpts{
+		Name:   "myns~host",
+		Driver: "ipmi",
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.ProvisionState != string(nodes.Enroll) {
+		t.Fatalf("expected a new node to be enrolled, got %s", node.ProvisionState)
+	}
+	_, err = ports.Create(t.Context(), client, ports.CreateOpts{NodeUUID: node.UUID, Address: "52:54:00:00:00:01"}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ports.Create(t.Context(), client, ports.CreateOpts{NodeUUID: node.UUID, Address: "52:54:00:00:00:01"}).Extract()
+	if !gophercloud.ResponseCodeIs(err, 409) {
+		t.Errorf("expected a conflict creating a duplicate port, got %v", err)
+	}
+
+	if err := changeState(t, client, node.UUID, nodes.TargetManage); err != nil {
+		t.Fatal(err)
+	}
+	if err := changeState(t, client, node.UUID, nodes.TargetProvide); !gophercloud.ResponseCodeIs(err, 409) {
+		t.Errorf("expected a conflict while verifying, got %v", err)
+	}
+	waitForState(t, client, node.UUID, nodes.Manageable)
+
+	if err := changeState(t, client, node.UUID, nodes.TargetInspect); err != nil {
+		t.Fatal(err)
+	}
+	waitForState(t, client, node.UUID, nodes.Manageable)
+
+	data, err := nodes.GetInventory(t.Context(), client, node.UUID).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Inventory.Interfaces) != 1 || data.Inventory.Interfaces[0].MACAddress != "52:54:00:00:00:01" {
+		t.Errorf("expected the inventory to list the port, got %+v", data.Inventory.Interfaces)
+	}
+
+	stored, found := simulator.GetNode("myns~host")
+	if !found || stored.UUID != node.UUID {
+		t.Errorf("expected to find node %s by name, got %+v", node.UUID, stored)
+	}
+}
+
+func TestSimulatorUpdateNode(t *testing.T) {
+	_, client := newSimulatorClient(t)
+
+	node, err := nodes.Create(t.Context(), client, nodes.CreateOpts{Driver: "ipmi"}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err = nodes.Update(t.Context(), client, node.UUID, nodes.UpdateOpts{
+		nodes.UpdateOperation{Op: nodes.AddOp, Path: "/instance_info/image_source", Value: "http://image"},
+		nodes.UpdateOperation{Op: nodes.ReplaceOp, Path: "/automated_clean", Value: false},
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.InstanceInfo["image_source"] != "http://image" {
+		t.Errorf("expected image_source to be set, got %v", node.InstanceInfo)
+	}
+	if node.AutomatedClean == nil || *node.AutomatedClean {
+		t.Errorf("expected automated cleaning to be disabled")
+	}
+
+	node, err = nodes.Update(t.Context(), client, node.UUID, nodes.UpdateOpts{
+		nodes.UpdateOperation{Op: nodes.RemoveOp, Path: "/instance_info/image_source"},
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := node.InstanceInfo["image_source"]; ok {
+		t.Errorf("expected image_source to be removed, got %v", node.InstanceInfo)
+	}
+}
+
+func TestSimulatorFailNextAndAbort(t *testing.T) {
+	simulator, client := newSimulatorClient(t)
+
+	node, err := nodes.Create(t.Context(), client, nodes.CreateOpts{Driver: "ipmi"}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulator.FailNext(node.UUID, nodes.TargetManage, "BMC unreachable")
+	if err := changeState(t, client, node.UUID, nodes.TargetManage); err != nil {
+		t.Fatal(err)
+	}
+	node = waitForState(t, client, node.UUID, nodes.Enroll)
+	if node.LastError != "BMC unreachable" {
+		t.Errorf("expected the injected failure, got %q", node.LastError)
+	}
+
+	if err := changeState(t, client, node.UUID, nodes.TargetManage); err != nil {
+		t.Fatal(err)
+	}
+	waitForState(t, client, node.UUID, nodes.Manageable)
+	if err := changeState(t, client, node.UUID, nodes.TargetInspect); err != nil {
+		t.Fatal(err)
+	}
+	waitForState(t, client, node.UUID, nodes.InspectWait)
+	if err := changeState(t, client, node.UUID, nodes.TargetAbort); err != nil {
+		t.Fatal(err)
+	}
+	node = waitForState(t, client, node.UUID, nodes.InspectFail)
+	if node.LastError == "" {
+		t.Error("expected the abort to be recorded")
+	}
+
+	if err := changeState(t, client, node.UUID, nodes.TargetActive); !gophercloud.ResponseCodeIs(err, 400) {
+		t.Errorf("expected deploying from inspect failed to be refused, got %v", err)
+	}
+}
@@ -31,6 +31,14 @@ type HostData struct {
 	DisableCertificateVerification bool
 	BootMACAddress                 string
 	ProvisionerID                  string
+	// Shard is the shard of the provisioner that registered the host, if any
+	Shard string
+	// BMCCABundle holds the PEM encoded CA certificates the certificate of
+	// the BMC is verified with, if any
+	BMCCABundle []byte
+	// BMCCertificateFingerprint is the pinned SHA-256 fingerprint of the
+	// certificate of the BMC, if any
+	BMCCertificateFingerprint string
 }
 
 func BuildHostData(host metal3api.BareMetalHost, bmcCreds bmc.Credentials) HostData {
@@ -41,6 +49,8 @@ func BuildHostData(host metal3api.BareMetalHost, bmcCreds bmc.Credentials) HostD
 		DisableCertificateVerification: host.Spec.BMC.DisableCertificateVerification,
 		BootMACAddress:                 host.Spec.BootMACAddress,
 		ProvisionerID:                  host.Status.Provisioning.ID,
+		Shard:                          host.Status.Provisioning.Shard,
+		BMCCertificateFingerprint:      host.Spec.BMC.CertificateFingerprint,
 	}
 }
 
@@ -49,6 +59,7 @@ func BuildHostDataNoBMC(host metal3api.BareMetalHost) HostData {
 	return HostData{
 		ObjectMeta:    *host.ObjectMeta.DeepCopy(),
 		ProvisionerID: host.Status.Provisioning.ID,
+		Shard:         host.Status.Provisioning.Shard,
 	}
 }
 
@@ -77,6 +88,18 @@ type PreprovisioningImage struct {
 	Format metal3api.ImageFormat
 }
 
+// NetworkInterfaceData describes the switch port configuration requested
+// for one of the host's network interfaces.
+type NetworkInterfaceData struct {
+	// MACAddress identifies the port of the interface.
+	MACAddress string
+	// SwitchPort overrides the switch port discovered through LLDP.
+	SwitchPort *metal3api.SwitchPort
+	// Attachment is the switch port configuration to apply while the
+	// host is provisioned. It is nil if no attachment is requested.
+	Attachment *metal3api.HostNetworkAttachmentSpec
+}
+
 type ManagementAccessData struct {
 	BootMode                   metal3api.BootMode
 	AutomatedCleaningMode      metal3api.AutomatedCleaningMode
@@ -91,6 +114,9 @@ type ManagementAccessData struct {
 	HardwareData               *metal3api.HardwareData
 	DisableInspection          bool
 	InspectionMode             metal3api.InspectionMode
+	NetworkInterfaces          []NetworkInterfaceData
+	ConductorGroup             string
+	NodeShard                  string
 }
 
 type AdoptData struct {
@@ -125,13 +151,14 @@ type ServicingData struct {
 }
 
 type ProvisionData struct {
-	Image           metal3api.Image
-	HostConfig      HostConfigData
-	BootMode        metal3api.BootMode
-	HardwareProfile profile.Profile
-	RootDeviceHints *metal3api.RootDeviceHints
-	CustomDeploy    *metal3api.CustomDeploy
-	ImagePullSecret string
+	Image             metal3api.Image
+	HostConfig        HostConfigData
+	BootMode          metal3api.BootMode
+	HardwareProfile   profile.Profile
+	RootDeviceHints   *metal3api.RootDeviceHints
+	CustomDeploy      *metal3api.CustomDeploy
+	ImagePullSecret   string
+	NetworkInterfaces []NetworkInterfaceData
 }
 
 type HTTPHeaders []map[string]string
@@ -251,6 +278,28 @@ const (
 	HealthCritical = "Critical"
 )
 
+// Sharded is implemented by provisioners spreading hosts across several
+// instances of their backend. The controller records the shard of a host on
+// registration and passes it back in HostData.
+type Sharded interface {
+	// Shard returns the name of the instance managing the host, or an
+	// empty string when sharding is not configured.
+	Shard() string
+}
+
+// CredentialsRotator is implemented by provisioners that can change the
+// password of the BMC account they use for a host.
+type CredentialsRotator interface {
+	// ChangeBMCPassword sets a new password for the account of the current
+	// credentials on the BMC. The provisioner keeps using the current
+	// credentials until the host is registered again.
+	ChangeBMCPassword(ctx context.Context, current bmc.Credentials, newPassword string) error
+	// BMCAcceptsCredentials returns whether the BMC accepts the
+	// credentials, to find out which password it has after an interrupted
+	// rotation.
+	BMCAcceptsCredentials(ctx context.Context, creds bmc.Credentials) (bool, error)
+}
+
 // Result holds the response from a call in the Provisioner API.
 type Result struct {
 	// Dirty indicates whether the host object needs to be saved.
@@ -0,0 +1,240 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageFields returns the fields of a message of the plugin protocol other
+// than skip, by their JSON name, as the JSON value to record. Every field is
+// returned, with its zero value if unset, so that recordings compare them.
+// The bytes fields hold metal3.io objects in their JSON encoding, which is
+// recorded as is.
+func messageFields(msg protoreflect.Message, skip protoreflect.Name) map[string]any {
+	fields := map[string]any{}
+	descriptors := msg.Descriptor().Fields()
+	for i := range descriptors.Len() {
+		fd := descriptors.Get(i)
+		if fd.Name() == skip {
+			continue
+		}
+		fields[fd.JSONName()] = fieldValue(fd, msg.Get(fd), msg.Has(fd))
+	}
+	return fields
+}
+
+func fieldValue(fd protoreflect.FieldDescriptor, value protoreflect.Value, isSet bool) any {
+	switch {
+	case fd.IsList():
+		list := value.List()
+		if list.Len() == 0 {
+			return nil
+		}
+		items := make([]any, 0, list.Len())
+		for i := range list.Len() {
+			items = append(items, singularValue(fd, list.Get(i)))
+		}
+		return items
+	case fd.IsMap():
+		entries := value.Map()
+		if entries.Len() == 0 {
+			return nil
+		}
+		items := make(map[string]any, entries.Len())
+		entries.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			items[key.String()] = singularValue(fd.MapValue(), value)
+			return true
+		})
+		return items
+	case fd.Kind() == protoreflect.MessageKind && !isSet:
+		return nil
+	default:
+		return singularValue(fd, value)
+	}
+}
+
+func singularValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return messageFields(value.Message(), "")
+	case protoreflect.BytesKind:
+		encoded := value.Bytes()
+		if len(encoded) == 0 {
+			return nil
+		}
+		var decoded any
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			// The protocol only carries JSON in bytes fields
+			return string(encoded)
+		}
+		return decoded
+	case protoreflect.EnumKind:
+		if enumValue := fd.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return int32(value.Enum())
+	default:
+		return value.Interface()
+	}
+}
+
+// setMessageFields sets the fields of msg from their recorded JSON values,
+// as returned by messageFields.
+func setMessageFields(msg protoreflect.Message, fields map[string]any) error {
+	descriptors := msg.Descriptor().Fields()
+	for name, recorded := range fields {
+		fd := descriptors.ByJSONName(name)
+		if fd == nil {
+			return fmt.Errorf("unknown field %s of %s", name, msg.Descriptor().Name())
+		}
+		if recorded == nil {
+			continue
+		}
+		if err := setField(msg, fd, recorded); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, recorded any) error {
+	switch {
+	case fd.IsList():
+		items, ok := recorded.([]any)
+		if !ok {
+			return fmt.Errorf("expected a list, got %v", recorded)
+		}
+		list := msg.Mutable(fd).List()
+		for _, item := range items {
+			var value protoreflect.Value
+			if fd.Kind() == protoreflect.MessageKind {
+				value = list.NewElement()
+				if err := setMessage(value.Message(), item); err != nil {
+					return err
+				}
+			} else {
+				var err error
+				if value, err = scalarValue(fd, item); err != nil {
+					return err
+				}
+			}
+			list.Append(value)
+		}
+	case fd.IsMap():
+		items, ok := recorded.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected an object, got %v", recorded)
+		}
+		entries := msg.Mutable(fd).Map()
+		for key, item := range items {
+			if item == nil {
+				continue
+			}
+			value, err := scalarValue(fd.MapValue(), item)
+			if err != nil {
+				return err
+			}
+			entries.Set(protoreflect.ValueOfString(key).MapKey(), value)
+		}
+	case fd.Kind() == protoreflect.MessageKind:
+		return setMessage(msg.Mutable(fd).Message(), recorded)
+	default:
+		value, err := scalarValue(fd, recorded)
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, value)
+	}
+	return nil
+}
+
+func setMessage(msg protoreflect.Message, recorded any) error {
+	fields, ok := recorded.(map[string]any)
+	if !ok {
+		return fmt.Errorf("expected an object, got %v", recorded)
+	}
+	return setMessageFields(msg, fields)
+}
+
+func scalarValue(fd protoreflect.FieldDescriptor, recorded any) (protoreflect.Value, error) {
+	invalid := func() (protoreflect.Value, error) {
+		return protoreflect.Value{}, fmt.Errorf("invalid %s value %v", fd.Kind(), recorded)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		value, ok := recorded.(bool)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfBool(value), nil
+	case protoreflect.StringKind:
+		value, ok := recorded.(string)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		encoded, err := json.Marshal(recorded)
+		if err != nil {
+			return invalid()
+		}
+		return protoreflect.ValueOfBytes(encoded), nil
+	case protoreflect.EnumKind:
+		if name, ok := recorded.(string); ok {
+			enumValue := fd.Enum().Values().ByName(protoreflect.Name(name))
+			if enumValue == nil {
+				return invalid()
+			}
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		number, ok := recorded.(float64)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(number)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		number, ok := recorded.(float64)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfInt32(int32(number)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		number, ok := recorded.(float64)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfInt64(int64(number)), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		number, ok := recorded.(float64)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfUint32(uint32(number)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		number, ok := recorded.(float64)
+		if !ok {
+			return invalid()
+		}
+		return protoreflect.ValueOfUint64(uint64(number)), nil
+	default:
+		return invalid()
+	}
+}
@@ -0,0 +1,124 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// bufferSize is the size of the in-memory connection to the plugin server.
+const bufferSize = 1024 * 1024
+
+// Recorder is a provisioner.Factory calling the provisioners of another
+// factory and recording each call.
+//
+// The provisioners of the recorder implement the optional interfaces that
+// the plugin protocol carries: capabilities, provisioner.Sharded and
+// provisioner.CredentialsRotator.
+type Recorder struct {
+	provisioner.Factory
+
+	server *grpc.Server
+	plugin *grpcplugin.Plugin
+	log    logr.Logger
+
+	lock sync.Mutex
+	w    io.Writer
+}
+
+// NewRecorder returns a Recorder calling the provisioners of factory, named
+// name in their errors, and writing the calls to w. The factory is expected
+// to be configured already, only the logger of config is used.
+func NewRecorder(ctx context.Context, name string, factory provisioner.Factory, w io.Writer, config provisioner.PluginConfig) (*Recorder, error) {
+	r := &Recorder{w: w, log: config.Logger}
+
+	lis := bufconn.Listen(bufferSize)
+	r.server = grpcplugin.NewServer(name, func(provisioner.PluginConfig) (provisioner.Factory, error) {
+		return factory, nil
+	}, config.Logger)
+	go func() {
+		if err := r.server.Serve(lis); err != nil {
+			r.log.Error(err, "recording provisioner server stopped")
+		}
+	}()
+
+	plugin, err := grpcplugin.Dial(ctx, "passthrough:///"+pluginName, name,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithUnaryInterceptor(r.intercept))
+	if err != nil {
+		r.server.Stop()
+		return nil, err
+	}
+	r.plugin = plugin
+
+	r.Factory, err = plugin.NewFactory(config)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Close stops recording.
+func (r *Recorder) Close() error {
+	err := r.plugin.Close()
+	r.server.Stop()
+	return err
+}
+
+// intercept records the calls to the provisioners that reached them.
+func (r *Recorder) intercept(ctx context.Context, fullMethod string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := invoker(ctx, fullMethod, req, reply, cc, opts...); err != nil {
+		return err
+	}
+
+	callReq, isCall := req.(hostRequest)
+	callResp, hasResponse := reply.(proto.Message)
+	if !isCall || !hasResponse {
+		// Handshake and Configure
+		return nil
+	}
+	host, err := hostKey(callReq.GetHost())
+	if err != nil {
+		return err
+	}
+	call, err := encodeCall(methodName(fullMethod), host, callReq, callResp)
+	if err != nil {
+		r.log.Error(err, "failed to record a provisioner call", "method", methodName(fullMethod), "host", host)
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := WriteCall(r.w, call); err != nil {
+		// Losing a call makes the recording useless, but must not
+		// break the provisioning.
+		r.log.Error(err, "failed to record a provisioner call", "method", call.Method, "host", host)
+	}
+	return nil
+}
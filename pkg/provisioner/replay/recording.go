@@ -0,0 +1,326 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replay records the calls made by the controllers to a provisioner
+// and replays them without the provisioner, so that a sequence seen against
+// a real backend can be turned into a deterministic controller test.
+//
+// The calls go through the gRPC plugin protocol in process, which already
+// encodes every method of provisioner.Provisioner. A recording is a file
+// with one JSON-encoded Call per line, whose arguments and values are the
+// fields of the request and response messages of the method.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin/api/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pluginName is the address the recorded and replayed provisioners are
+// dialed at.
+const pluginName = "replay"
+
+// maxLineSize bounds a line of a recording, hardware details can be large.
+const maxLineSize = 16 * 1024 * 1024
+
+// Call is a call to a provisioner and its outcome.
+type Call struct {
+	// Method is the method of provisioner.Provisioner, or NewProvisioner
+	// for the creation of the provisioner by the factory.
+	Method string `json:"method"`
+	// Host is the namespace and name of the host.
+	Host string `json:"host"`
+	// Arguments are the fields of the request of the method in the plugin
+	// protocol, other than the host, by their JSON name. When replaying,
+	// only the fields present here are compared.
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	// Values are the fields of the response of the method in the plugin
+	// protocol other than its outcome, the return values other than the
+	// Result and the error.
+	Values json.RawMessage `json:"values,omitempty"`
+	Result Result          `json:"result,omitzero"`
+	// Events are the events published by the provisioner during the call.
+	Events []Event `json:"events,omitempty"`
+	Error  *Error  `json:"error,omitempty"`
+}
+
+// Result mirrors provisioner.Result.
+type Result struct {
+	Dirty        bool            `json:"dirty,omitempty"`
+	RequeueAfter metav1.Duration `json:"requeueAfter,omitzero"`
+	ErrorMessage string          `json:"errorMessage,omitempty"`
+}
+
+// Event is an event published by the provisioner.
+type Event struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// Error is the error returned by the provisioner. Kind names the error of
+// the provisioner package it wraps, if any, e.g. ERROR_KIND_NOT_READY.
+type Error struct {
+	Kind    string `json:"kind,omitempty"`
+	Message string `json:"message"`
+}
+
+// ReadCalls reads a recording.
+func ReadCalls(r io.Reader) ([]Call, error) {
+	var calls []Call
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		call := Call{}
+		if err := json.Unmarshal(scanner.Bytes(), &call); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		calls = append(calls, call)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the recording: %w", err)
+	}
+	return calls, nil
+}
+
+// LoadFile reads the recording in filename.
+func LoadFile(filename string) ([]Call, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	calls, err := ReadCalls(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", filename, err)
+	}
+	return calls, nil
+}
+
+// WriteCall appends a call to a recording.
+func WriteCall(w io.Writer, call Call) error {
+	encoded, err := json.Marshal(call)
+	if err != nil {
+		return fmt.Errorf("failed to encode the call: %w", err)
+	}
+	_, err = w.Write(append(encoded, '\n'))
+	return err
+}
+
+// hostKey returns the namespace and name of the host of a call.
+func hostKey(host *v1alpha1.HostData) (string, error) {
+	objectMeta := metav1.ObjectMeta{}
+	if len(host.GetObjectMeta()) > 0 {
+		if err := json.Unmarshal(host.GetObjectMeta(), &objectMeta); err != nil {
+			return "", fmt.Errorf("failed to decode the metadata of the host: %w", err)
+		}
+	}
+	return objectMeta.Namespace + "/" + objectMeta.Name, nil
+}
+
+// methodName returns the name of the method of a gRPC full method name.
+func methodName(fullMethod string) string {
+	return path.Base(fullMethod)
+}
+
+// hostRequest is a request of the plugin protocol for a host.
+type hostRequest interface {
+	proto.Message
+	GetHost() *v1alpha1.HostData
+}
+
+// outcomeOf returns the outcome carried by the response of a call.
+func outcomeOf(resp proto.Message) *v1alpha1.Outcome {
+	switch resp := resp.(type) {
+	case *v1alpha1.Outcome:
+		return resp
+	case interface{ GetOutcome() *v1alpha1.Outcome }:
+		return resp.GetOutcome()
+	default:
+		return nil
+	}
+}
+
+// encodeFields encodes the fields of a message but skip, nil if there are
+// none.
+func encodeFields(msg proto.Message, skip protoreflect.Name) (json.RawMessage, error) {
+	fields := messageFields(msg.ProtoReflect(), skip)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
+
+// requestArguments returns the arguments of a call, the fields of its
+// request other than the host.
+func requestArguments(req hostRequest) (json.RawMessage, error) {
+	return encodeFields(req, "host")
+}
+
+func encodeCall(method, host string, req hostRequest, resp proto.Message) (Call, error) {
+	arguments, err := requestArguments(req)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to encode the arguments: %w", err)
+	}
+	call := Call{
+		Method:    method,
+		Host:      host,
+		Arguments: redactArguments(method, arguments),
+	}
+	if _, isOutcome := resp.(*v1alpha1.Outcome); !isOutcome {
+		if call.Values, err = encodeFields(resp, "outcome"); err != nil {
+			return Call{}, fmt.Errorf("failed to encode the values: %w", err)
+		}
+	}
+
+	outcome := outcomeOf(resp)
+	if result := outcome.GetResult(); result != nil {
+		call.Result = Result{
+			Dirty:        result.GetDirty(),
+			RequeueAfter: metav1.Duration{Duration: time.Duration(result.GetRequeueAfterNanoseconds())},
+			ErrorMessage: result.GetErrorMessage(),
+		}
+	}
+	for _, event := range outcome.GetEvents() {
+		call.Events = append(call.Events, Event{Reason: event.GetReason(), Message: event.GetMessage()})
+	}
+	if encoded := outcome.GetError(); encoded != nil {
+		call.Error = &Error{Message: encoded.GetMessage()}
+		if encoded.GetKind() != v1alpha1.ErrorKind_ERROR_KIND_UNSPECIFIED {
+			call.Error.Kind = encoded.GetKind().String()
+		}
+	}
+	return call, nil
+}
+
+func (c *Call) outcome() *v1alpha1.Outcome {
+	outcome := &v1alpha1.Outcome{
+		Result: &v1alpha1.Result{
+			Dirty:                   c.Result.Dirty,
+			RequeueAfterNanoseconds: int64(c.Result.RequeueAfter.Duration),
+			ErrorMessage:            c.Result.ErrorMessage,
+		},
+	}
+	for _, event := range c.Events {
+		outcome.Events = append(outcome.Events, &v1alpha1.Event{Reason: event.Reason, Message: event.Message})
+	}
+	if c.Error != nil {
+		outcome.Error = &v1alpha1.Error{
+			Kind:    v1alpha1.ErrorKind(v1alpha1.ErrorKind_value[c.Error.Kind]),
+			Message: c.Error.Message,
+		}
+	}
+	return outcome
+}
+
+// setResponse fills the response of a call with the recorded outcome and
+// values.
+func (c *Call) setResponse(resp proto.Message) error {
+	if outcome, isOutcome := resp.(*v1alpha1.Outcome); isOutcome {
+		proto.Merge(outcome, c.outcome())
+		return nil
+	}
+
+	msg := resp.ProtoReflect()
+	outcomeField := msg.Descriptor().Fields().ByName("outcome")
+	if outcomeField == nil {
+		return fmt.Errorf("%s has no outcome", msg.Descriptor().Name())
+	}
+	msg.Set(outcomeField, protoreflect.ValueOfMessage(c.outcome().ProtoReflect()))
+
+	if len(c.Values) == 0 {
+		return nil
+	}
+	var values map[string]any
+	if err := json.Unmarshal(c.Values, &values); err != nil {
+		return fmt.Errorf("invalid recorded values: %w", err)
+	}
+	delete(values, "outcome")
+	if err := setMessageFields(msg, values); err != nil {
+		return fmt.Errorf("invalid recorded values: %w", err)
+	}
+	return nil
+}
+
+// volatileMetadata are the fields of object metadata that differ between
+// clusters and test runs. Owner references carry the UID of the owner.
+var volatileMetadata = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "ownerReferences"}
+
+// redactArguments drops from the arguments the host configuration and image
+// pull secret of Provision and the passwords of the credentials rotation
+// calls, which may hold secrets, and the volatile fields of the object
+// metadata they contain.
+func redactArguments(method string, arguments []byte) json.RawMessage {
+	if len(arguments) == 0 {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(arguments, &decoded); err != nil {
+		return arguments
+	}
+
+	if args, ok := decoded.(map[string]any); ok && method == "Provision" {
+		if data, ok := args["data"].(map[string]any); ok {
+			delete(data, "hostConfig")
+			delete(data, "imagePullSecret")
+		}
+	}
+	if args, ok := decoded.(map[string]any); ok && (method == "ChangeBMCPassword" || method == "BMCAcceptsCredentials") {
+		delete(args, "newPassword")
+		for _, field := range []string{"current", "credentials"} {
+			if creds, ok := args[field].(map[string]any); ok {
+				delete(creds, "password")
+			}
+		}
+	}
+	dropVolatileMetadata(decoded)
+
+	redacted, err := json.Marshal(decoded)
+	if err != nil {
+		return arguments
+	}
+	return redacted
+}
+
+func dropVolatileMetadata(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if metadata, ok := field.(map[string]any); ok && key == "metadata" {
+				for _, volatile := range volatileMetadata {
+					delete(metadata, volatile)
+				}
+			}
+			dropVolatileMetadata(field)
+		}
+	case []any:
+		for _, item := range value {
+			dropVolatileMetadata(item)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/replay"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var hostData = provisioner.HostData{ObjectMeta: metav1.ObjectMeta{Name: "host", Namespace: "metal3"}}
+
+type event struct {
+	reason, message string
+}
+
+// outcome is what the controller sees of a sequence of calls.
+type outcome struct {
+	register    provisioner.Result
+	provID      string
+	powerOn     provisioner.Result
+	provision   provisioner.Result
+	provisionOK bool
+	events      []event
+}
+
+func runCalls(t *testing.T, factory provisioner.Factory) outcome {
+	t.Helper()
+	ctx := context.Background()
+	out := outcome{}
+	prov, err := factory.NewProvisioner(ctx, hostData, func(reason, message string) {
+		out.events = append(out.events, event{reason, message})
+	})
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+
+	out.register, out.provID, err = prov.Register(ctx, provisioner.ManagementAccessData{State: metal3api.StateRegistering}, false, false)
+	if err != nil {
+		t.Fatalf("Register returned unexpected error: %v", err)
+	}
+	out.powerOn, err = prov.PowerOn(ctx, false)
+	if err != nil {
+		t.Fatalf("PowerOn returned unexpected error: %v", err)
+	}
+	out.provision, err = prov.Provision(ctx, provisioner.ProvisionData{
+		Image:           metal3api.Image{URL: "http://image"},
+		HostConfig:      fixture.NewHostConfigData("user", "network", "meta"),
+		ImagePullSecret: "secret",
+	}, false)
+	out.provisionOK = err == nil
+	return out
+}
+
+func record(t *testing.T, state *fixture.Fixture) []replay.Call {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	recorder, err := replay.NewRecorder(context.Background(), "fixture", state, buf, provisioner.PluginConfig{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("NewRecorder returned unexpected error: %v", err)
+	}
+	runCalls(t, recorder)
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close returned unexpected error: %v", err)
+	}
+
+	calls, err := replay.ReadCalls(buf)
+	if err != nil {
+		t.Fatalf("ReadCalls returned unexpected error: %v", err)
+	}
+	return calls
+}
+
+func newReplayer(t *testing.T, calls []replay.Call) *replay.Replayer {
+	t.Helper()
+	replayer, err := replay.NewReplayer(context.Background(), "fixture", calls, logr.Discard())
+	if err != nil {
+		t.Fatalf("NewReplayer returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = replayer.Close() })
+	return replayer
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := record(t, &fixture.Fixture{})
+
+	var methods []string
+	for _, call := range calls {
+		methods = append(methods, call.Method)
+		if call.Host != "metal3/host" {
+			t.Errorf("unexpected host %q in %s", call.Host, call.Method)
+		}
+	}
+	expectedMethods := []string{"NewProvisioner", "Register", "PowerOn", "Provision"}
+	if !reflect.DeepEqual(methods, expectedMethods) {
+		t.Fatalf("recorded %v, want %v", methods, expectedMethods)
+	}
+
+	provisionArgs := string(calls[3].Arguments)
+	if strings.Contains(provisionArgs, "hostConfig") || strings.Contains(provisionArgs, "secret") {
+		t.Errorf("the host configuration and pull secret were recorded: %s", provisionArgs)
+	}
+
+	// Replaying against the fixture's results must not need the fixture
+	expected := runCalls(t, &fixture.Fixture{})
+	replayer := newReplayer(t, calls)
+	replayed := runCalls(t, replayer)
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Errorf("replayed %+v, want %+v", replayed, expected)
+	}
+	if err := replayer.Err(); err != nil {
+		t.Errorf("unexpected mismatch: %v", err)
+	}
+	if remaining := replayer.Remaining(); len(remaining) != 0 {
+		t.Errorf("calls were not replayed: %v", remaining)
+	}
+}
+
+func TestReplayFileRoundTrip(t *testing.T) {
+	calls := record(t, &fixture.Fixture{})
+
+	buf := &bytes.Buffer{}
+	for _, call := range calls {
+		if err := replay.WriteCall(buf, call); err != nil {
+			t.Fatalf("WriteCall returned unexpected error: %v", err)
+		}
+	}
+	read, err := replay.ReadCalls(buf)
+	if err != nil {
+		t.Fatalf("ReadCalls returned unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(read, calls) {
+		t.Errorf("read %+v, want %+v", read, calls)
+	}
+}
+
+func TestReplayUnexpectedMethod(t *testing.T) {
+	replayer := newReplayer(t, []replay.Call{
+		{Method: "NewProvisioner", Host: "metal3/host"},
+		{Method: "Deprovision", Host: "metal3/host"},
+	})
+
+	prov, err := replayer.NewProvisioner(context.Background(), hostData, nil)
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+	if _, err := prov.PowerOn(context.Background(), false); err == nil {
+		t.Fatal("expected PowerOn to fail")
+	}
+	expected := "replay: unexpected call to PowerOn for host metal3/host, recorded Deprovision"
+	if err := replayer.Err(); err == nil || err.Error() != expected {
+		t.Errorf("Err() = %v, want %q", err, expected)
+	}
+	if remaining := replayer.Remaining(); len(remaining) != 1 {
+		t.Errorf("expected the Deprovision call to remain, got %v", remaining)
+	}
+
+	other := hostData
+	other.ObjectMeta.Name = "other"
+	if _, err := replayer.NewProvisioner(context.Background(), other, nil); err == nil {
+		t.Error("expected a call for a host without recording to fail")
+	}
+}
+
+func TestReplayArguments(t *testing.T) {
+	testCases := []struct {
+		Scenario      string
+		Recorded      string
+		ExpectedError string
+	}{
+		{
+			Scenario: "not recorded",
+		},
+		{
+			Scenario: "trimmed",
+			Recorded: `{"data":{"state":"registering"}}`,
+		},
+		{
+			Scenario:      "different value",
+			Recorded:      `{"data":{"state":"available"},"credentialsChanged":false}`,
+			ExpectedError: "data.state is registering, recorded available",
+		},
+		{
+			Scenario:      "not an object",
+			Recorded:      `{"data":"registering"}`,
+			ExpectedError: "data is map",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Scenario, func(t *testing.T) {
+			replayer := newReplayer(t, []replay.Call{
+				{Method: "NewProvisioner", Host: "metal3/host"},
+				{
+					Method:    "Register",
+					Host:      "metal3/host",
+					Arguments: json.RawMessage(tc.Recorded),
+					Values:    json.RawMessage(`{"provisionerId":"uuid"}`),
+				},
+			})
+
+			prov, err := replayer.NewProvisioner(context.Background(), hostData, nil)
+			if err != nil {
+				t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+			}
+			_, provID, err := prov.Register(context.Background(), provisioner.ManagementAccessData{State: metal3api.StateRegistering}, false, false)
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Register returned unexpected error: %v", err)
+				}
+				if provID != "uuid" {
+					t.Errorf("provID = %q, want %q", provID, "uuid")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Errorf("expected an error containing %q, got %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	replayer := newReplayer(t, []replay.Call{
+		{
+			Method: "NewProvisioner",
+			Host:   "metal3/host",
+			Error:  &replay.Error{Kind: "ERROR_KIND_NOT_READY", Message: "Not ready"},
+		},
+		{Method: "NewProvisioner", Host: "metal3/host"},
+		{
+			Method: "Deprovision",
+			Host:   "metal3/host",
+			Result: replay.Result{ErrorMessage: "cleaning failed"},
+			Events: []replay.Event{{Reason: "DeprovisioningFailed", Message: "cleaning failed"}},
+		},
+	})
+
+	_, err := replayer.NewProvisioner(context.Background(), hostData, nil)
+	if !errors.Is(err, provisioner.ErrNotReady) {
+		t.Fatalf("expected ErrNotReady, got %v", err)
+	}
+
+	var events []event
+	prov, err := replayer.NewProvisioner(context.Background(), hostData, func(reason, message string) {
+		events = append(events, event{reason, message})
+	})
+	if err != nil {
+		t.Fatalf("NewProvisioner returned unexpected error: %v", err)
+	}
+	result, err := prov.Deprovision(context.Background(), false, metal3api.CleaningModeDisabled)
+	if err != nil {
+		t.Fatalf("Deprovision returned unexpected error: %v", err)
+	}
+	if result.ErrorMessage != "cleaning failed" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(events) != 1 || events[0].reason != "DeprovisioningFailed" {
+		t.Errorf("expected the recorded event to be published, got %v", events)
+	}
+}
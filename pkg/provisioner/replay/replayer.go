@@ -0,0 +1,218 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/grpcplugin/api/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Replayer is a provisioner.Factory whose provisioners return the outcomes
+// of recorded calls instead of calling a backend.
+//
+// The calls of each host are expected in the order of the recording, the
+// calls of different hosts may interleave differently. A call that does not
+// match the next recorded call of its host fails with an error, which is
+// also reported by Err.
+type Replayer struct {
+	provisioner.Factory
+
+	name   string
+	plugin *grpcplugin.Plugin
+
+	lock     sync.Mutex
+	pending  map[string][]Call
+	mismatch error
+}
+
+// NewReplayer returns a Replayer of calls, whose provisioners are named name
+// in their errors.
+func NewReplayer(ctx context.Context, name string, calls []Call, logger logr.Logger) (*Replayer, error) {
+	r := &Replayer{name: name, pending: make(map[string][]Call)}
+	for _, call := range calls {
+		r.pending[call.Host] = append(r.pending[call.Host], call)
+	}
+
+	// The interceptor answers every call, nothing is ever dialed.
+	plugin, err := grpcplugin.Dial(ctx, "passthrough:///"+pluginName, name,
+		grpc.WithUnaryInterceptor(r.intercept))
+	if err != nil {
+		return nil, err
+	}
+	r.plugin = plugin
+
+	r.Factory, err = plugin.NewFactory(provisioner.PluginConfig{Logger: logger})
+	if err != nil {
+		plugin.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Close releases the connection of the replayer.
+func (r *Replayer) Close() error {
+	return r.plugin.Close()
+}
+
+// Err returns the first mismatch between the calls made and the recording.
+func (r *Replayer) Err() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.mismatch
+}
+
+// Remaining returns the recorded calls that have not been replayed.
+func (r *Replayer) Remaining() []Call {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var remaining []Call
+	for _, calls := range r.pending {
+		remaining = append(remaining, calls...)
+	}
+	return remaining
+}
+
+func (r *Replayer) intercept(_ context.Context, fullMethod string, req, reply any, _ *grpc.ClientConn, _ grpc.UnaryInvoker, _ ...grpc.CallOption) error {
+	switch reply := reply.(type) {
+	case *v1alpha1.HandshakeResponse:
+		reply.Name = r.name
+		reply.ProtocolVersion = grpcplugin.ProtocolVersion
+		return nil
+	case *v1alpha1.ConfigureResponse:
+		return nil
+	default:
+		callReq, isCall := req.(hostRequest)
+		callResp, hasResponse := reply.(proto.Message)
+		if !isCall || !hasResponse {
+			return status.Errorf(codes.Unimplemented, "method %s is not replayed", fullMethod)
+		}
+		call, err := r.next(methodName(fullMethod), callReq)
+		if err != nil {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if err := call.setResponse(callResp); err != nil {
+			return status.Errorf(codes.Internal, "replay: %s for host %s: %s", call.Method, call.Host, err)
+		}
+		return nil
+	}
+}
+
+// next checks req against the next recorded call of its host and returns
+// that call.
+func (r *Replayer) next(method string, req hostRequest) (Call, error) {
+	host, err := hostKey(req.GetHost())
+	if err != nil {
+		return Call{}, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	calls := r.pending[host]
+	if len(calls) == 0 {
+		return Call{}, r.fail(fmt.Errorf("replay: unexpected call to %s for host %s after the end of the recording", method, host))
+	}
+	call := calls[0]
+	if call.Method != method {
+		return Call{}, r.fail(fmt.Errorf("replay: unexpected call to %s for host %s, recorded %s", method, host, call.Method))
+	}
+	arguments, err := requestArguments(req)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to encode the arguments: %w", err)
+	}
+	if err := matchArguments(call.Arguments, arguments); err != nil {
+		return Call{}, r.fail(fmt.Errorf("replay: unexpected arguments of %s for host %s: %w", method, host, err))
+	}
+
+	r.pending[host] = calls[1:]
+	if len(r.pending[host]) == 0 {
+		delete(r.pending, host)
+	}
+	return call, nil
+}
+
+func (r *Replayer) fail(err error) error {
+	if r.mismatch == nil {
+		r.mismatch = err
+	}
+	return err
+}
+
+// matchArguments checks that the arguments of a call have the values of the
+// recorded arguments. Fields missing from the recording are not compared,
+// so that recordings can be trimmed to what matters to a test.
+func matchArguments(recorded, actual []byte) error {
+	if len(recorded) == 0 {
+		return nil
+	}
+	var recordedValue, actualValue any
+	if err := json.Unmarshal(recorded, &recordedValue); err != nil {
+		return fmt.Errorf("invalid recorded arguments: %w", err)
+	}
+	if len(actual) > 0 {
+		if err := json.Unmarshal(actual, &actualValue); err != nil {
+			return fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	return matchValue("", recordedValue, actualValue)
+}
+
+func matchValue(field string, recorded, actual any) error {
+	recordedMap, ok := recorded.(map[string]any)
+	if !ok {
+		if !reflect.DeepEqual(recorded, actual) {
+			return fmt.Errorf("%s is %v, recorded %v", describeField(field), actual, recorded)
+		}
+		return nil
+	}
+
+	actualMap, ok := actual.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s is %v, recorded an object", describeField(field), actual)
+	}
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(recordedMap)) {
+		subfield := key
+		if field != "" {
+			subfield = field + "." + key
+		}
+		errs = append(errs, matchValue(subfield, recordedMap[key], actualMap[key]))
+	}
+	return errors.Join(errs...)
+}
+
+func describeField(field string) string {
+	if field == "" {
+		return "the value"
+	}
+	return field
+}
@@ -0,0 +1,40 @@
+package provisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+)
+
+// ErrUnknownProvisioner is returned by the Router when a host selects a
+// provisioner that is not loaded.
+var ErrUnknownProvisioner = errors.New("unknown provisioner")
+
+// Router is a Factory creating the provisioners of each host with the
+// factory selected by the metal3api.ProvisionerLabel of the host, or with
+// the default factory when the label is not set.
+type Router struct {
+	defaultName string
+	factories   map[string]Factory
+}
+
+// NewRouter returns a Router between factories, indexed by provisioner name.
+func NewRouter(defaultName string, factories map[string]Factory) *Router {
+	return &Router{defaultName: defaultName, factories: factories}
+}
+
+// NewProvisioner creates the provisioner of the host with the factory it
+// selects.
+func (r *Router) NewProvisioner(ctx context.Context, hostData HostData, publish EventPublisher) (Provisioner, error) {
+	name := hostData.ObjectMeta.Labels[metal3api.ProvisionerLabel]
+	if name == "" {
+		name = r.defaultName
+	}
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProvisioner, name)
+	}
+	return factory.NewProvisioner(ctx, hostData, publish)
+}
@@ -0,0 +1,50 @@
+package provisioner_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metal3api "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner"
+	"github.com/metal3-io/baremetal-operator/pkg/provisioner/fixture"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRouter(t *testing.T) {
+	ironic := &fixture.Fixture{}
+	vendor := &fixture.Fixture{}
+	router := provisioner.NewRouter("ironic", map[string]provisioner.Factory{
+		"ironic": ironic,
+		"vendor": vendor,
+	})
+
+	powerOn := func(labels map[string]string) error {
+		hostData := provisioner.HostData{ObjectMeta: metav1.ObjectMeta{Name: "host", Labels: labels}}
+		prov, err := router.NewProvisioner(context.Background(), hostData, func(string, string) {})
+		if err != nil {
+			return err
+		}
+		_, err = prov.PowerOn(context.Background(), false)
+		return err
+	}
+
+	if err := powerOn(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ironic.PoweredOn || vendor.PoweredOn {
+		t.Errorf("hosts without the label should use the default provisioner")
+	}
+
+	if err := powerOn(map[string]string{metal3api.ProvisionerLabel: "vendor"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !vendor.PoweredOn {
+		t.Errorf("the label should select the vendor provisioner")
+	}
+
+	err := powerOn(map[string]string{metal3api.ProvisionerLabel: "other"})
+	if !errors.Is(err, provisioner.ErrUnknownProvisioner) {
+		t.Errorf("expected ErrUnknownProvisioner, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package secretutils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ErrCredentialsNotFound is matched by the errors returned by a
+// CredentialsProvider that does not know the requested credentials.
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+// httpCredentialsTimeout bounds a single lookup of an HTTP provider.
+const httpCredentialsTimeout = 10 * time.Second
+
+// Credentials are BMC credentials read from a CredentialsProvider.
+type Credentials struct {
+	Username string
+	Password string
+	// Version changes whenever the credentials change.
+	Version string
+}
+
+// CredentialsProvider looks up BMC credentials stored outside of the
+// cluster. The key is the namespace of the host and the name of its
+// credentials.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context, key types.NamespacedName) (Credentials, error)
+}
+
+// NewCredentialsProvider returns the provider for the location, which is
+// either a file:// URL of a directory or the https:// URL of a lookup
+// service. Plain http:// is refused, as the lookup service answers with
+// passwords. The token file, if any, holds a bearer token sent to the lookup
+// service, and the CA file the certificates it is trusted with.
+func NewCredentialsProvider(location, tokenFile, caFile string) (CredentialsProvider, error) {
+	parsedURL, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials provider location %q: %w", location, err)
+	}
+	switch parsedURL.Scheme {
+	case "file":
+		return &FileCredentialsProvider{Dir: parsedURL.Path}, nil
+	case "http":
+		return nil, fmt.Errorf("credentials provider %q must use https", location)
+	case "https":
+		provider := &HTTPCredentialsProvider{URL: location, TokenFile: tokenFile}
+		if caFile != "" {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the CA of the credentials provider: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in %s", caFile)
+			}
+			provider.Client = &http.Client{
+				Timeout: httpCredentialsTimeout,
+				Transport: &http.Transport{
+					Proxy:           http.ProxyFromEnvironment,
+					TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+				},
+			}
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unsupported credentials provider location %q", location)
+	}
+}
+
+// contentVersionKey keys the versions derived from the content of
+// credentials. Versions end up in the status of hosts, a plain hash of the
+// password there could be brute-forced offline by anyone reading the host.
+var contentVersionKey = sync.OnceValue(func() []byte {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key)
+	return key
+})
+
+// contentVersion returns a version derived from the credentials, for the
+// providers that do not report one. It only holds for the lifetime of the
+// process.
+func contentVersion(username, password string) string {
+	mac := hmac.New(sha256.New, contentVersionKey())
+	mac.Write([]byte(username + "\x00" + password))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// FileCredentialsProvider reads credentials from files, such as those
+// mounted by the Secrets Store CSI driver. The credentials of a key are in
+// the username and password files of the <Dir>/<namespace>/<name>
+// directory.
+type FileCredentialsProvider struct {
+	Dir string
+}
+
+func (p *FileCredentialsProvider) Credentials(_ context.Context, key types.NamespacedName) (Credentials, error) {
+	// Keys come from the spec of hosts, do not let them escape the directory.
+	if strings.ContainsAny(key.Namespace+key.Name, `/\`) || key.Name == ".." || key.Namespace == ".." {
+		return Credentials{}, fmt.Errorf("invalid credentials name %s", key)
+	}
+	dir := filepath.Join(p.Dir, key.Namespace, key.Name)
+
+	var values, modTimes [2]string
+	for i, file := range []string{"username", "password"} {
+		path := filepath.Join(dir, file)
+		info, err := os.Stat(path)
+		if err == nil {
+			modTimes[i] = strconv.FormatInt(info.ModTime().UnixNano(), 36)
+			var data []byte
+			data, err = os.ReadFile(path)
+			values[i] = strings.TrimSpace(string(data))
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return Credentials{}, fmt.Errorf("%s of %s: %w", file, key, ErrCredentialsNotFound)
+		}
+		if err != nil {
+			return Credentials{}, err
+		}
+	}
+	// The files are replaced on every change, their modification times
+	// version the credentials across restarts without exposing them.
+	return Credentials{
+		Username: values[0],
+		Password: values[1],
+		Version:  modTimes[0] + "-" + modTimes[1],
+	}, nil
+}
+
+// HTTPCredentialsProvider looks credentials up with a GET request to
+// <URL>/<namespace>/<name>, answered with a JSON object with the username,
+// password and optionally version fields.
+type HTTPCredentialsProvider struct {
+	URL string
+	// TokenFile is read on every lookup, so that the token can be renewed.
+	TokenFile string
+	// Client defaults to a client with a short timeout.
+	Client *http.Client
+}
+
+type httpCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Version  string `json:"version"`
+}
+
+func (p *HTTPCredentialsProvider) Credentials(ctx context.Context, key types.NamespacedName) (Credentials, error) {
+	lookupURL := strings.TrimSuffix(p.URL, "/") + "/" + url.PathEscape(key.Namespace) + "/" + url.PathEscape(key.Name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookupURL, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.TokenFile != "" {
+		token, err := os.ReadFile(p.TokenFile)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("failed to read the credentials provider token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: httpCredentialsTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return Credentials{}, fmt.Errorf("%s: %w", key, ErrCredentialsNotFound)
+	default:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return Credentials{}, fmt.Errorf("credentials lookup of %s returned %s", key, resp.Status)
+	}
+
+	var body httpCredentials
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Credentials{}, fmt.Errorf("invalid credentials of %s: %w", key, err)
+	}
+	creds := Credentials{
+		Username: strings.TrimSpace(body.Username),
+		Password: strings.TrimSpace(body.Password),
+		Version:  body.Version,
+	}
+	if creds.Version == "" {
+		creds.Version = contentVersion(creds.Username, creds.Password)
+	}
+	return creds, nil
+}
+
+type cachedCredentials struct {
+	creds   Credentials
+	expires time.Time
+}
+
+// CachingCredentialsProvider keeps the credentials returned by another
+// provider for a while, as they are read on every reconcile of a host.
+// Expired credentials are dropped, so that the passwords of deleted hosts
+// do not stay in memory.
+type CachingCredentialsProvider struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	lock  sync.Mutex
+	cache map[types.NamespacedName]cachedCredentials
+}
+
+// NewCachingCredentialsProvider returns a provider caching the credentials
+// of the provider for the TTL. Errors are not cached.
+func NewCachingCredentialsProvider(provider CredentialsProvider, ttl time.Duration) *CachingCredentialsProvider {
+	return &CachingCredentialsProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    make(map[types.NamespacedName]cachedCredentials),
+	}
+}
+
+func (p *CachingCredentialsProvider) Credentials(ctx context.Context, key types.NamespacedName) (Credentials, error) {
+	p.lock.Lock()
+	cached, found := p.cache[key]
+	p.lock.Unlock()
+	if found && p.now().Before(cached.expires) {
+		return cached.creds, nil
+	}
+
+	creds, err := p.provider.Credentials(ctx, key)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.now()
+	for cachedKey, cached := range p.cache {
+		if !now.Before(cached.expires) {
+			delete(p.cache, cachedKey)
+		}
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	p.cache[key] = cachedCredentials{creds: creds, expires: now.Add(p.ttl)}
+	return creds, nil
+}
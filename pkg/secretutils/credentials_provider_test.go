@@ -0,0 +1,145 @@
+package secretutils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var testCredentialsKey = types.NamespacedName{Namespace: "test", Name: "bmc-creds"}
+
+func writeCredentialsFiles(t *testing.T, dir, username, password string) {
+	t.Helper()
+	credsDir := filepath.Join(dir, testCredentialsKey.Namespace, testCredentialsKey.Name)
+	require.NoError(t, os.MkdirAll(credsDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(credsDir, "username"), []byte(username), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(credsDir, "password"), []byte(password), 0o600))
+}
+
+func TestFileCredentialsProvider(t *testing.T) {
+	dir := t.TempDir()
+	provider, err := NewCredentialsProvider("file://"+dir, "", "")
+	require.NoError(t, err)
+
+	_, err = provider.Credentials(t.Context(), testCredentialsKey)
+	require.ErrorIs(t, err, ErrCredentialsNotFound)
+
+	writeCredentialsFiles(t, dir, "admin\n", "secret\n")
+	creds, err := provider.Credentials(t.Context(), testCredentialsKey)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", creds.Username)
+	assert.Equal(t, "secret", creds.Password)
+	assert.NotEmpty(t, creds.Version)
+
+	again, err := provider.Credentials(t.Context(), testCredentialsKey)
+	require.NoError(t, err)
+	assert.Equal(t, creds.Version, again.Version)
+
+	writeCredentialsFiles(t, dir, "admin", "rotated")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, testCredentialsKey.Namespace, testCredentialsKey.Name, "password"), later, later))
+	rotated, err := provider.Credentials(t.Context(), testCredentialsKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, creds.Version, rotated.Version)
+
+	_, err = provider.Credentials(t.Context(), types.NamespacedName{Namespace: "..", Name: "etc"})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCredentialsNotFound)
+}
+
+func TestHTTPCredentialsProvider(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/bmc/test/bmc-creds":
+			_, _ = w.Write([]byte(`{"username": "admin", "password": "secret", "version": "7"}`))
+		case "/v1/bmc/test/unversioned":
+			_, _ = w.Write([]byte(`{"username": "admin", "password": "secret"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+	provider, err := NewCredentialsProvider(server.URL+"/v1/bmc/", tokenFile, caFile)
+	require.NoError(t, err)
+
+	creds, err := provider.Credentials(t.Context(), testCredentialsKey)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "secret", Version: "7"}, creds)
+
+	creds, err = provider.Credentials(t.Context(), types.NamespacedName{Namespace: "test", Name: "unversioned"})
+	require.NoError(t, err)
+	assert.Equal(t, contentVersion("admin", "secret"), creds.Version)
+	plain := sha256.Sum256([]byte("admin\x00secret"))
+	assert.NotEqual(t, hex.EncodeToString(plain[:8]), creds.Version)
+
+	_, err = provider.Credentials(t.Context(), types.NamespacedName{Namespace: "test", Name: "missing"})
+	require.ErrorIs(t, err, ErrCredentialsNotFound)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("expired"), 0o600))
+	_, err = provider.Credentials(t.Context(), testCredentialsKey)
+	require.ErrorContains(t, err, "403 Forbidden")
+}
+
+func TestNewCredentialsProviderInvalid(t *testing.T) {
+	_, err := NewCredentialsProvider("vault://secrets", "", "")
+	require.Error(t, err)
+
+	_, err = NewCredentialsProvider("http://vault.example.com/v1/bmc", "", "")
+	require.ErrorContains(t, err, "must use https")
+}
+
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Credentials(_ context.Context, _ types.NamespacedName) (Credentials, error) {
+	p.calls++
+	return Credentials{Username: "admin", Password: "secret", Version: "1"}, nil
+}
+
+func TestCachingCredentialsProvider(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	backend := &countingProvider{}
+	provider := NewCachingCredentialsProvider(backend, time.Minute)
+	provider.now = func() time.Time { return now }
+
+	for range 3 {
+		_, err := provider.Credentials(t.Context(), testCredentialsKey)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, backend.calls)
+
+	now = now.Add(2 * time.Minute)
+	_, err := provider.Credentials(t.Context(), testCredentialsKey)
+	require.NoError(t, err)
+	assert.Equal(t, 2, backend.calls)
+
+	// The credentials of hosts that are not looked up any more expire.
+	now = now.Add(2 * time.Minute)
+	other := types.NamespacedName{Namespace: "test", Name: "other"}
+	_, err = provider.Credentials(t.Context(), other)
+	require.NoError(t, err)
+	assert.NotContains(t, provider.cache, testCredentialsKey)
+	assert.Contains(t, provider.cache, other)
+}